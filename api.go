@@ -25,6 +25,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/zephyrtronium/robot/audit"
 	"github.com/zephyrtronium/robot/brain"
 	"github.com/zephyrtronium/robot/command"
 	"github.com/zephyrtronium/robot/spoken"
@@ -57,6 +58,14 @@ func (robo *Robot) api(ctx context.Context, listen string, mux *http.ServeMux, m
 	// TODO(zeph): this setup for thinking &c. is TMI-specific
 	mux.HandleFunc("GET /api/think/{channel...}", robo.apiThink)
 	mux.HandleFunc("GET /api/spoken/{channel...}", robo.apiSpoken)
+	mux.HandleFunc("GET /api/why/{channel...}", robo.apiWhy)
+	mux.HandleFunc("GET /api/feedback/{tag...}", robo.apiFeedback)
+	mux.HandleFunc("POST /api/reload", robo.apiReload)
+	mux.HandleFunc("POST /api/channel/{name}", robo.apiJoin)
+	mux.HandleFunc("DELETE /api/channel/{name}", robo.apiPart)
+	mux.HandleFunc("DELETE /api/user/{channel}/{login}", robo.apiForgetUser)
+	mux.HandleFunc("POST /api/unforget/{channel}", robo.apiUndoForget)
+	mux.HandleFunc("GET /api/audit", robo.apiAudit)
 	l, err := net.Listen("tcp", listen)
 	if err != nil {
 		return fmt.Errorf("couldn't start API server: %w", err)
@@ -222,12 +231,25 @@ func (robo *Robot) apiForget(w http.ResponseWriter, r *http.Request) {
 	tag := r.PathValue("tag")
 	d := jsontext.NewDecoder(r.Body)
 	var all error
+	// API forgets are by tag rather than by channel, so the entry has no
+	// channel. That also means they can't be undone with undo forget.
+	e := audit.Entry{
+		Time:   time.Now(),
+		Actor:  "api " + r.RemoteAddr,
+		Action: "forget",
+		Reason: "api request",
+		Tag:    tag,
+	}
 	for {
 		tok, err := d.ReadToken()
 		switch err {
 		case nil: // do nothing
 		case io.EOF:
 			// Done; transmit any forget errors.
+			if len(e.Messages) != 0 {
+				robo.dropQuotes(ctx, e.Messages...)
+				robo.record(ctx, e)
+			}
 			if all != nil {
 				jsonerror(w, http.StatusInternalServerError, all.Error())
 				return
@@ -245,6 +267,7 @@ func (robo *Robot) apiForget(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		id := tok.String()
+		e.Messages = append(e.Messages, id)
 		if err := robo.brain.Forget(ctx, tag, id); err != nil {
 			log.ErrorContext(ctx, "forget failed", slog.String("tag", tag), slog.String("id", id), slog.Any("err", err))
 			all = errors.Join(all, err)
@@ -301,13 +324,14 @@ func (robo *Robot) apiThink(w http.ResponseWriter, r *http.Request) {
 		Trace    []string `json:"trace"`
 		Cost     string   `json:"cost"`
 	}
+	weights := robo.thinkWeights(ctx, ch.Send)
 	var mu sync.Mutex
 	out := make([]gen, 0, n)
 	group, ctx := errgroup.WithContext(ctx)
 	for range n {
 		group.Go(func() error {
 			start := time.Now()
-			m, tr, err := brain.Think(ctx, robo.brain, ch.Send, prompt)
+			m, tr, err := brain.ThinkWeighted(ctx, robo.brain, ch.Send, prompt, weights)
 			cost := time.Since(start)
 			if err != nil {
 				return err
@@ -414,3 +438,376 @@ func (robo *Robot) apiSpoken(w http.ResponseWriter, r *http.Request) {
 		log.ErrorContext(ctx, "write response failed", slog.Any("err", err))
 	}
 }
+
+// apiWhy explains a spoken message like the why command does, but with the
+// IDs of the messages that produced it.
+// The msg form value selects the message; it defaults to the latest one.
+func (robo *Robot) apiWhy(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := slog.With(slog.String("api", "why"), slog.Any("trace", uuid.New()))
+	log.InfoContext(ctx, "handle", slog.String("route", r.Pattern), slog.String("remote", r.RemoteAddr))
+	defer log.InfoContext(ctx, "done")
+	w.Header().Set("Content-Type", "application/json")
+	where := r.PathValue("channel")
+	// TODO(zeph): this processing is TMI-specific
+	where = strings.ToLower(where)
+	if !strings.HasPrefix(where, "#") {
+		where = "#" + where
+	}
+	ch, _ := robo.channels.Load(where)
+	if ch == nil {
+		log.InfoContext(ctx, "not found", slog.String("channel", where))
+		jsonerror(w, http.StatusNotFound, "no such channel")
+		return
+	}
+	if ch.Send == "" {
+		log.InfoContext(ctx, "no send tag", slog.String("channel", where))
+		jsonerror(w, http.StatusNotFound, "channel has no send tag")
+		return
+	}
+	var m spoken.Message
+	if msg := r.FormValue("msg"); msg != "" {
+		var err error
+		m, err = robo.spoken.Find(ctx, ch.Send, msg)
+		if err != nil {
+			log.ErrorContext(ctx, "finding spoken message", slog.Any("err", err))
+			jsonerror(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	} else {
+		it, errf := robo.spoken.Previous(ctx, ch.Send, 1)
+		for v := range it {
+			m = v
+		}
+		if err := errf(); err != nil {
+			log.ErrorContext(ctx, "getting previous spoken message", slog.Any("err", err))
+			jsonerror(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+	}
+	if m.Text == "" {
+		jsonerror(w, http.StatusNotFound, "no such spoken message")
+		return
+	}
+	type why struct {
+		Text   string     `json:"text"`
+		Time   time.Time  `json:"time"`
+		Prompt string     `json:"prompt,omitzero"`
+		Count  int        `json:"count"`
+		IDs    []string   `json:"ids"`
+		Oldest *time.Time `json:"oldest,omitzero"`
+		Newest *time.Time `json:"newest,omitzero"`
+	}
+	u := struct {
+		Data why `json:"data"`
+	}{why{Text: m.Text, Time: m.Time, Prompt: m.Prompt, Count: len(m.Trace), IDs: m.Trace}}
+	times, err := brain.Dates(ctx, robo.brain, ch.Send, m.Trace...)
+	if err != nil && !errors.Is(err, errors.ErrUnsupported) {
+		log.ErrorContext(ctx, "dating trace", slog.Any("err", err))
+		jsonerror(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if len(times) != 0 {
+		oldest := slices.MinFunc(times, time.Time.Compare)
+		newest := slices.MaxFunc(times, time.Time.Compare)
+		u.Data.Oldest, u.Data.Newest = &oldest, &newest
+	}
+	log.InfoContext(ctx, "why", slog.String("text", m.Text), slog.Int("count", len(m.Trace)))
+	b, err := json.Marshal(&u)
+	if err != nil {
+		panic(err)
+	}
+	if _, err := w.Write(b); err != nil {
+		log.ErrorContext(ctx, "write response failed", slog.Any("err", err))
+	}
+}
+
+// apiFeedback lists the feedback chat has given on spoken messages in a tag.
+func (robo *Robot) apiFeedback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := slog.With(slog.String("api", "feedback"), slog.Any("trace", uuid.New()))
+	log.InfoContext(ctx, "handle", slog.String("route", r.Pattern), slog.String("remote", r.RemoteAddr))
+	defer log.InfoContext(ctx, "done")
+	w.Header().Set("Content-Type", "application/json")
+	tag := r.PathValue("tag")
+	v, err := robo.spoken.Scores(ctx, tag)
+	if err != nil {
+		log.ErrorContext(ctx, "getting scores", slog.Any("err", err))
+		jsonerror(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	log.InfoContext(ctx, "scores", slog.String("tag", tag), slog.Int("count", len(v)))
+	if len(v) == 0 {
+		jsonerror(w, http.StatusNotFound, "no feedback for tag")
+		return
+	}
+	u := struct {
+		Data []spoken.Score `json:"data"`
+	}{v}
+	b, err := json.Marshal(&u)
+	if err != nil {
+		panic(err)
+	}
+	if _, err := w.Write(b); err != nil {
+		log.ErrorContext(ctx, "write response failed", slog.Any("err", err))
+	}
+}
+
+func (robo *Robot) apiReload(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := slog.With(slog.String("api", "reload"), slog.Any("trace", uuid.New()))
+	log.InfoContext(ctx, "handle", slog.String("route", r.Pattern), slog.String("remote", r.RemoteAddr))
+	defer log.InfoContext(ctx, "done")
+	if robo.configFile == "" {
+		jsonerror(w, http.StatusNotFound, "no config file to reload")
+		return
+	}
+	res := make(chan error, 1)
+	select {
+	case <-ctx.Done():
+		jsonerror(w, http.StatusServiceUnavailable, "reload not available")
+		return
+	case robo.reloads <- res:
+	}
+	select {
+	case <-ctx.Done():
+		jsonerror(w, http.StatusServiceUnavailable, "reload did not finish")
+	case err := <-res:
+		if err != nil {
+			log.ErrorContext(ctx, "reload failed", slog.Any("err", err))
+			jsonerror(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (robo *Robot) apiJoin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := slog.With(slog.String("api", "join"), slog.Any("trace", uuid.New()))
+	log.InfoContext(ctx, "handle", slog.String("route", r.Pattern), slog.String("remote", r.RemoteAddr))
+	defer log.InfoContext(ctx, "done")
+	name, err := robo.JoinTwitch(ctx, r.PathValue("name"))
+	switch {
+	case err == nil: // do nothing
+	case errors.Is(err, errJoined):
+		jsonerror(w, http.StatusConflict, err.Error())
+		return
+	case errors.Is(err, errNoUser), errors.Is(err, errNoTwitch):
+		jsonerror(w, http.StatusNotFound, err.Error())
+		return
+	default:
+		log.ErrorContext(ctx, "join failed", slog.String("channel", name), slog.Any("err", err))
+		jsonerror(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	u := struct {
+		Channel string `json:"channel"`
+	}{name}
+	b, err := json.Marshal(&u)
+	if err != nil {
+		panic(err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if _, err := w.Write(b); err != nil {
+		log.ErrorContext(ctx, "write response failed", slog.Any("err", err))
+	}
+}
+
+func (robo *Robot) apiPart(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := slog.With(slog.String("api", "part"), slog.Any("trace", uuid.New()))
+	log.InfoContext(ctx, "handle", slog.String("route", r.Pattern), slog.String("remote", r.RemoteAddr))
+	defer log.InfoContext(ctx, "done")
+	name, err := robo.PartTwitch(ctx, r.PathValue("name"))
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusNoContent)
+	case errors.Is(err, errConfigured):
+		jsonerror(w, http.StatusConflict, err.Error())
+	case errors.Is(err, errNotJoined), errors.Is(err, errNoTwitch):
+		jsonerror(w, http.StatusNotFound, err.Error())
+	default:
+		log.ErrorContext(ctx, "part failed", slog.String("channel", name), slog.Any("err", err))
+		jsonerror(w, http.StatusInternalServerError, err.Error())
+	}
+}
+
+func (robo *Robot) apiForgetUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := slog.With(slog.String("api", "forget-user"), slog.Any("trace", uuid.New()))
+	log.InfoContext(ctx, "handle", slog.String("route", r.Pattern), slog.String("remote", r.RemoteAddr))
+	defer log.InfoContext(ctx, "done")
+	where := twitchName(r.PathValue("channel"))
+	ch, _ := robo.channels.Load(where)
+	if ch == nil {
+		log.InfoContext(ctx, "not found", slog.String("channel", where))
+		jsonerror(w, http.StatusNotFound, "no such channel")
+		return
+	}
+	var window time.Duration
+	if s := r.FormValue("within"); s != "" {
+		var err error
+		window, err = time.ParseDuration(s)
+		if err != nil || window <= 0 {
+			log.InfoContext(ctx, "parsing window", slog.String("within", s), slog.Any("err", err))
+			jsonerror(w, http.StatusBadRequest, "bad window")
+			return
+		}
+	}
+	n, window, err := robo.ForgetTwitchUser(ctx, ch, "api "+r.RemoteAddr, r.PathValue("login"), window)
+	switch {
+	case err == nil: // do nothing
+	case errors.Is(err, errors.ErrUnsupported):
+		jsonerror(w, http.StatusNotImplemented, err.Error())
+		return
+	case errors.Is(err, errNoUser), errors.Is(err, errNoTwitch):
+		jsonerror(w, http.StatusNotFound, err.Error())
+		return
+	default:
+		log.ErrorContext(ctx, "forget user failed", slog.Int("count", n), slog.Any("err", err))
+		jsonerror(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	u := struct {
+		Forgotten int    `json:"forgotten"`
+		Within    string `json:"within"`
+	}{n, window.String()}
+	b, err := json.Marshal(&u)
+	if err != nil {
+		panic(err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(b); err != nil {
+		log.ErrorContext(ctx, "write response failed", slog.Any("err", err))
+	}
+}
+
+func (robo *Robot) apiUndoForget(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := slog.With(slog.String("api", "undo-forget"), slog.Any("trace", uuid.New()))
+	log.InfoContext(ctx, "handle", slog.String("route", r.Pattern), slog.String("remote", r.RemoteAddr))
+	defer log.InfoContext(ctx, "done")
+	where := twitchName(r.PathValue("channel"))
+	ch, _ := robo.channels.Load(where)
+	if ch == nil {
+		log.InfoContext(ctx, "not found", slog.String("channel", where))
+		jsonerror(w, http.StatusNotFound, "no such channel")
+		return
+	}
+	n, total, err := robo.UndoForget(ctx, ch, "api "+r.RemoteAddr)
+	switch {
+	case err == nil: // do nothing
+	case errors.Is(err, errors.ErrUnsupported):
+		jsonerror(w, http.StatusNotImplemented, err.Error())
+		return
+	default:
+		log.ErrorContext(ctx, "undo forget failed", slog.Int("count", n), slog.Any("err", err))
+		jsonerror(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if total == 0 {
+		jsonerror(w, http.StatusNotFound, "no recent forget to undo")
+		return
+	}
+	u := struct {
+		Restored int `json:"restored"`
+		Batch    int `json:"batch"`
+	}{n, total}
+	b, err := json.Marshal(&u)
+	if err != nil {
+		panic(err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(b); err != nil {
+		log.ErrorContext(ctx, "write response failed", slog.Any("err", err))
+	}
+}
+
+type apiAuditEntry struct {
+	Time     string   `json:"time"`
+	Actor    string   `json:"actor"`
+	Channel  string   `json:"channel,omitzero"`
+	Action   string   `json:"action"`
+	Reason   string   `json:"reason,omitzero"`
+	Tag      string   `json:"tag,omitzero"`
+	Messages []string `json:"messages,omitzero"`
+}
+
+func (robo *Robot) apiAudit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	log := slog.With(slog.String("api", "audit"), slog.Any("trace", uuid.New()))
+	log.InfoContext(ctx, "handle", slog.String("route", r.Pattern), slog.String("remote", r.RemoteAddr))
+	defer log.InfoContext(ctx, "done")
+	f := audit.Filter{
+		Channel: r.FormValue("channel"),
+		Actor:   r.FormValue("actor"),
+		Action:  r.FormValue("action"),
+		Limit:   100,
+	}
+	if f.Channel != "" {
+		f.Channel = twitchName(f.Channel)
+	}
+	if s := r.FormValue("n"); s != "" {
+		var err error
+		f.Limit, err = strconv.Atoi(s)
+		if err != nil || f.Limit <= 0 {
+			log.WarnContext(ctx, "bad request", slog.String("n", s), slog.Any("err", err))
+			jsonerror(w, http.StatusBadRequest, "invalid page size")
+			return
+		}
+	}
+	for _, v := range []struct {
+		name string
+		t    *time.Time
+	}{{"since", &f.Since}, {"until", &f.Until}} {
+		s := r.FormValue(v.name)
+		if s == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			log.WarnContext(ctx, "bad request", slog.String(v.name, s), slog.Any("err", err))
+			jsonerror(w, http.StatusBadRequest, "invalid "+v.name+" time")
+			return
+		}
+		*v.t = t
+	}
+	if robo.audit == nil {
+		jsonerror(w, http.StatusNotImplemented, "no audit log")
+		return
+	}
+	l, err := robo.audit.Query(ctx, f)
+	if err != nil {
+		log.ErrorContext(ctx, "couldn't query audit log", slog.Any("err", err))
+		jsonerror(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	u := struct {
+		Data   []apiAuditEntry `json:"data"`
+		Status int             `json:"status"`
+	}{
+		Data:   make([]apiAuditEntry, len(l)),
+		Status: http.StatusOK,
+	}
+	for i, e := range l {
+		u.Data[i] = apiAuditEntry{
+			Time:     e.Time.Format(time.RFC3339),
+			Actor:    e.Actor,
+			Channel:  e.Channel,
+			Action:   e.Action,
+			Reason:   e.Reason,
+			Tag:      e.Tag,
+			Messages: e.Messages,
+		}
+	}
+	b, err := json.Marshal(&u)
+	if err != nil {
+		panic(err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(b); err != nil {
+		log.ErrorContext(ctx, "write response failed", slog.Any("err", err))
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/zephyrtronium/robot/audit"
+)
+
+// defaultAuditRetain is the time to keep audit log entries when the config
+// does not set one.
+const defaultAuditRetain = 90 * 24 * time.Hour
+
+// record adds an entry to the audit log, logging any error.
+func (robo *Robot) record(ctx context.Context, e audit.Entry) {
+	if robo.audit == nil {
+		return
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if err := robo.audit.Record(ctx, e); err != nil {
+		slog.ErrorContext(ctx, "failed to record audit entry",
+			slog.Any("err", err),
+			slog.String("channel", e.Channel),
+			slog.String("action", e.Action),
+		)
+	}
+}
+
+// auditLoop periodically removes audit log entries older than the configured
+// retention time.
+func (robo *Robot) auditLoop(ctx context.Context) error {
+	tick := time.NewTicker(time.Hour)
+	defer tick.Stop()
+	for {
+		robo.cfgMu.Lock()
+		retain := fseconds(robo.twitchCfg.global.Audit.Retain)
+		robo.cfgMu.Unlock()
+		if retain == 0 {
+			retain = defaultAuditRetain
+		}
+		if retain > 0 {
+			n, err := robo.audit.Prune(ctx, time.Now().Add(-retain))
+			if err != nil {
+				slog.ErrorContext(ctx, "failed to prune audit log", slog.Any("err", err))
+			} else {
+				slog.InfoContext(ctx, "pruned audit log", slog.Int("count", n), slog.Duration("retain", retain))
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tick.C: // continue on
+		}
+	}
+}
@@ -0,0 +1,187 @@
+// Package audit records moderation actions durably.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+)
+
+// Log is a moderation audit log backed by an SQL database.
+type Log struct {
+	db *sqlitex.Pool
+}
+
+// Entry is a single moderation action.
+type Entry struct {
+	// Time is the time at which the action happened.
+	Time time.Time
+	// Actor describes who triggered the action, e.g. the login of the
+	// moderator who used a command.
+	Actor string
+	// Channel is the channel where the action applies.
+	Channel string
+	// Action is the kind of action, e.g. "clearmsg" or "quiet".
+	Action string
+	// Reason describes the action in more detail, e.g. the term that was
+	// forgotten or the duration of quiet time.
+	Reason string
+	// Tag is the brain tag the action affected, if any.
+	Tag string
+	// Messages is the IDs of the messages the action affected, if any.
+	Messages []string
+}
+
+// Filter selects entries in the log.
+// Zero fields match all entries.
+type Filter struct {
+	// Channel selects entries in a channel.
+	Channel string
+	// Actor selects entries triggered by an actor.
+	Actor string
+	// Action selects entries of a kind of action.
+	Action string
+	// Since selects entries at or after a time.
+	Since time.Time
+	// Until selects entries before a time.
+	Until time.Time
+	// Limit is the maximum number of entries to return.
+	Limit int
+}
+
+// Open opens an existing audit log in an SQL database.
+func Open(ctx context.Context, db *sqlitex.Pool) (*Log, error) {
+	conn, err := db.Take(ctx)
+	defer db.Put(conn)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get connection from pool: %w", err)
+	}
+	const schemaSQL = `
+CREATE TABLE IF NOT EXISTS audit (
+	id      INTEGER PRIMARY KEY,
+	time    INTEGER NOT NULL,
+	actor   TEXT NOT NULL,
+	channel TEXT NOT NULL,
+	action  TEXT NOT NULL,
+	reason  TEXT NOT NULL,
+	tag     TEXT NOT NULL
+) STRICT;
+CREATE INDEX IF NOT EXISTS audit_time ON audit (time);
+CREATE TABLE IF NOT EXISTS audit_messages (
+	entry INTEGER NOT NULL,
+	id    TEXT NOT NULL,
+	PRIMARY KEY (entry, id)
+) STRICT, WITHOUT ROWID;
+`
+	if err := sqlitex.ExecuteScript(conn, schemaSQL, nil); err != nil {
+		return nil, fmt.Errorf("couldn't run migration: %w", err)
+	}
+	return &Log{db: db}, nil
+}
+
+// Record adds an entry to the log.
+func (l *Log) Record(ctx context.Context, e Entry) (err error) {
+	conn, err := l.db.Take(ctx)
+	defer l.db.Put(conn)
+	if err != nil {
+		return fmt.Errorf("couldn't get connection to record audit entry: %w", err)
+	}
+	defer sqlitex.Transaction(conn)(&err)
+	opts := sqlitex.ExecOptions{Args: []any{e.Time.UnixNano(), e.Actor, e.Channel, e.Action, e.Reason, e.Tag}}
+	err = sqlitex.Execute(conn, `INSERT INTO audit (time, actor, channel, action, reason, tag) VALUES (?, ?, ?, ?, ?, ?)`, &opts)
+	if err != nil {
+		return fmt.Errorf("couldn't record audit entry: %w", err)
+	}
+	entry := conn.LastInsertRowID()
+	for _, id := range e.Messages {
+		opts := sqlitex.ExecOptions{Args: []any{entry, id}}
+		err = sqlitex.Execute(conn, `INSERT OR IGNORE INTO audit_messages (entry, id) VALUES (?, ?)`, &opts)
+		if err != nil {
+			return fmt.Errorf("couldn't record audit message: %w", err)
+		}
+	}
+	return nil
+}
+
+// Query gets entries matching a filter, most recent first.
+func (l *Log) Query(ctx context.Context, f Filter) ([]Entry, error) {
+	conn, err := l.db.Take(ctx)
+	defer l.db.Put(conn)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get connection to query audit log: %w", err)
+	}
+	var until any
+	if !f.Until.IsZero() {
+		until = f.Until.UnixNano()
+	}
+	limit := f.Limit
+	if limit <= 0 {
+		limit = -1
+	}
+	const querySQL = `
+SELECT a.time, a.actor, a.channel, a.action, a.reason, a.tag, group_concat(m.id, ' ')
+FROM audit AS a LEFT JOIN audit_messages AS m ON m.entry = a.id
+WHERE (?1 = '' OR a.channel = ?1)
+	AND (?2 = '' OR a.actor = ?2)
+	AND (?3 = '' OR a.action = ?3)
+	AND a.time >= ?4
+	AND (?5 IS NULL OR a.time < ?5)
+GROUP BY a.id
+ORDER BY a.time DESC, a.id DESC
+LIMIT ?6`
+	var r []Entry
+	opts := sqlitex.ExecOptions{
+		Args: []any{f.Channel, f.Actor, f.Action, since(f.Since), until, limit},
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			e := Entry{
+				Time:     time.Unix(0, stmt.ColumnInt64(0)),
+				Actor:    stmt.ColumnText(1),
+				Channel:  stmt.ColumnText(2),
+				Action:   stmt.ColumnText(3),
+				Reason:   stmt.ColumnText(4),
+				Tag:      stmt.ColumnText(5),
+				Messages: strings.Fields(stmt.ColumnText(6)),
+			}
+			r = append(r, e)
+			return nil
+		},
+	}
+	if err := sqlitex.Execute(conn, querySQL, &opts); err != nil {
+		return nil, fmt.Errorf("couldn't query audit log: %w", err)
+	}
+	return r, nil
+}
+
+// Prune removes entries older than a time.
+// It returns the number of entries removed.
+func (l *Log) Prune(ctx context.Context, before time.Time) (n int, err error) {
+	conn, err := l.db.Take(ctx)
+	defer l.db.Put(conn)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get connection to prune audit log: %w", err)
+	}
+	defer sqlitex.Transaction(conn)(&err)
+	opts := sqlitex.ExecOptions{Args: []any{before.UnixNano()}}
+	err = sqlitex.Execute(conn, `DELETE FROM audit_messages WHERE entry IN (SELECT id FROM audit WHERE time < ?)`, &opts)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't prune audit messages: %w", err)
+	}
+	err = sqlitex.Execute(conn, `DELETE FROM audit WHERE time < ?`, &opts)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't prune audit log: %w", err)
+	}
+	return conn.Changes(), nil
+}
+
+// since converts a lower time bound to nanoseconds.
+// The zero time selects everything.
+func since(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano()
+}
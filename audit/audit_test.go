@@ -0,0 +1,143 @@
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+
+	"github.com/zephyrtronium/robot/audit"
+)
+
+var dbcount atomic.Uint64
+
+func testConn() *sqlitex.Pool {
+	k := dbcount.Add(1)
+	pool, err := sqlitex.NewPool(fmt.Sprintf("file:audit-%d.db?mode=memory&cache=shared", k), sqlitex.PoolOptions{Flags: sqlite.OpenReadWrite | sqlite.OpenCreate | sqlite.OpenMemory | sqlite.OpenSharedCache | sqlite.OpenURI})
+	if err != nil {
+		panic(err)
+	}
+	return pool
+}
+
+func TestQuery(t *testing.T) {
+	t0 := time.Unix(1e9, 0)
+	entries := []audit.Entry{
+		{Time: t0, Actor: "twitch", Channel: "#bocchi", Action: "clearmsg", Reason: "ryo", Tag: "kessoku", Messages: []string{"1"}},
+		{Time: t0.Add(time.Minute), Actor: "nijika", Channel: "#bocchi", Action: "forget", Reason: "curry", Tag: "kessoku", Messages: []string{"2", "3"}},
+		{Time: t0.Add(2 * time.Minute), Actor: "nijika", Channel: "#bocchi", Action: "quiet", Reason: "2h0m0s"},
+		{Time: t0.Add(3 * time.Minute), Actor: "kita", Channel: "#kita", Action: "forget", Reason: "bocchi", Tag: "kita", Messages: []string{"4"}},
+	}
+	cases := []struct {
+		name   string
+		filter audit.Filter
+		want   []int
+	}{
+		{
+			name:   "all",
+			filter: audit.Filter{},
+			want:   []int{3, 2, 1, 0},
+		},
+		{
+			name:   "channel",
+			filter: audit.Filter{Channel: "#bocchi"},
+			want:   []int{2, 1, 0},
+		},
+		{
+			name:   "actor",
+			filter: audit.Filter{Actor: "nijika"},
+			want:   []int{2, 1},
+		},
+		{
+			name:   "action",
+			filter: audit.Filter{Action: "forget"},
+			want:   []int{3, 1},
+		},
+		{
+			name:   "since",
+			filter: audit.Filter{Since: t0.Add(time.Minute)},
+			want:   []int{3, 2, 1},
+		},
+		{
+			name:   "until",
+			filter: audit.Filter{Until: t0.Add(time.Minute)},
+			want:   []int{0},
+		},
+		{
+			name:   "limit",
+			filter: audit.Filter{Channel: "#bocchi", Limit: 2},
+			want:   []int{2, 1},
+		},
+		{
+			name:   "none",
+			filter: audit.Filter{Channel: "#seika"},
+			want:   nil,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			l, err := audit.Open(ctx, testConn())
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range entries {
+				if err := l.Record(ctx, e); err != nil {
+					t.Fatalf("couldn't record %v: %v", e, err)
+				}
+			}
+			got, err := l.Query(ctx, c.filter)
+			if err != nil {
+				t.Fatalf("couldn't query: %v", err)
+			}
+			var want []audit.Entry
+			for _, k := range c.want {
+				want = append(want, entries[k])
+			}
+			if diff := cmp.Diff(want, got, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("wrong entries (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	ctx := context.Background()
+	l, err := audit.Open(ctx, testConn())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t0 := time.Unix(1e9, 0)
+	for i := range 5 {
+		e := audit.Entry{Time: t0.Add(time.Duration(i) * time.Hour), Actor: "nijika", Channel: "#bocchi", Action: "forget", Messages: []string{fmt.Sprint(i)}}
+		if err := l.Record(ctx, e); err != nil {
+			t.Fatalf("couldn't record %v: %v", e, err)
+		}
+	}
+	n, err := l.Prune(ctx, t0.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("couldn't prune: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("wrong number pruned: want 2, got %d", n)
+	}
+	got, err := l.Query(ctx, audit.Filter{})
+	if err != nil {
+		t.Fatalf("couldn't query: %v", err)
+	}
+	if len(got) != 3 {
+		t.Errorf("wrong number of entries left: want 3, got %d", len(got))
+	}
+	for _, e := range got {
+		if e.Time.Before(t0.Add(2 * time.Hour)) {
+			t.Errorf("entry %v should have been pruned", e)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zephyrtronium/robot/channel"
+)
+
+// LoadBlockTerms loads the terms moderators have blocked in every channel.
+// It must be called after the channels are set.
+func (robo *Robot) LoadBlockTerms(ctx context.Context) error {
+	for _, ch := range robo.channels.All() {
+		if err := robo.loadBlockTerms(ctx, ch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadBlockTerms loads the terms moderators have blocked in a channel.
+func (robo *Robot) loadBlockTerms(ctx context.Context, ch *channel.Channel) error {
+	terms, err := robo.blocklist.Terms(ctx, ch.Name)
+	if err != nil {
+		return fmt.Errorf("couldn't load blocked terms for %s: %w", ch.Name, err)
+	}
+	ch.Block.SetTerms(terms)
+	return nil
+}
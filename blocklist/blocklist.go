@@ -0,0 +1,84 @@
+// Package blocklist stores terms that moderators have blocked per channel.
+package blocklist
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+)
+
+// List is a per-channel list of blocked terms backed by an SQL database.
+type List struct {
+	db *sqlitex.Pool
+}
+
+// Open opens an existing block list in an SQL database.
+func Open(ctx context.Context, db *sqlitex.Pool) (*List, error) {
+	conn, err := db.Take(ctx)
+	defer db.Put(conn)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get connection from pool: %w", err)
+	}
+	const schemaSQL = `CREATE TABLE IF NOT EXISTS blocklist (channel TEXT NOT NULL, term TEXT NOT NULL, PRIMARY KEY (channel, term)) STRICT, WITHOUT ROWID`
+	if err := sqlitex.ExecuteTransient(conn, schemaSQL, nil); err != nil {
+		return nil, fmt.Errorf("couldn't run migration: %w", err)
+	}
+	return &List{db: db}, nil
+}
+
+// Add adds a term to a channel's list. Terms are stored in lower case.
+// Adding a term that is already present has no effect.
+func (l *List) Add(ctx context.Context, channel, term string) error {
+	conn, err := l.db.Take(ctx)
+	defer l.db.Put(conn)
+	if err != nil {
+		return fmt.Errorf("couldn't get connection to add blocked term: %w", err)
+	}
+	opts := sqlitex.ExecOptions{Args: []any{channel, strings.ToLower(term)}}
+	err = sqlitex.Execute(conn, `INSERT OR IGNORE INTO blocklist (channel, term) VALUES (?, ?)`, &opts)
+	if err != nil {
+		return fmt.Errorf("couldn't add blocked term: %w", err)
+	}
+	return nil
+}
+
+// Remove removes a term from a channel's list.
+// It reports whether the term was present.
+func (l *List) Remove(ctx context.Context, channel, term string) (bool, error) {
+	conn, err := l.db.Take(ctx)
+	defer l.db.Put(conn)
+	if err != nil {
+		return false, fmt.Errorf("couldn't get connection to remove blocked term: %w", err)
+	}
+	opts := sqlitex.ExecOptions{Args: []any{channel, strings.ToLower(term)}}
+	err = sqlitex.Execute(conn, `DELETE FROM blocklist WHERE channel=? AND term=?`, &opts)
+	if err != nil {
+		return false, fmt.Errorf("couldn't remove blocked term: %w", err)
+	}
+	return conn.Changes() > 0, nil
+}
+
+// Terms gets all terms in a channel's list.
+func (l *List) Terms(ctx context.Context, channel string) ([]string, error) {
+	conn, err := l.db.Take(ctx)
+	defer l.db.Put(conn)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get connection to list blocked terms: %w", err)
+	}
+	var r []string
+	opts := sqlitex.ExecOptions{
+		Args: []any{channel},
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			r = append(r, stmt.ColumnText(0))
+			return nil
+		},
+	}
+	err = sqlitex.Execute(conn, `SELECT term FROM blocklist WHERE channel=? ORDER BY term`, &opts)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list blocked terms: %w", err)
+	}
+	return r, nil
+}
@@ -0,0 +1,100 @@
+package blocklist_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+
+	"github.com/zephyrtronium/robot/blocklist"
+)
+
+var dbcount atomic.Uint64
+
+func testConn() *sqlitex.Pool {
+	k := dbcount.Add(1)
+	pool, err := sqlitex.NewPool(fmt.Sprintf("file:blocklist-%d.db?mode=memory&cache=shared", k), sqlitex.PoolOptions{Flags: sqlite.OpenReadWrite | sqlite.OpenCreate | sqlite.OpenMemory | sqlite.OpenSharedCache | sqlite.OpenURI})
+	if err != nil {
+		panic(err)
+	}
+	return pool
+}
+
+func TestList(t *testing.T) {
+	type term struct {
+		channel string
+		term    string
+	}
+	cases := []struct {
+		name string
+		add  []term
+		rem  []term
+		// removed is whether each removal should find its term.
+		removed []bool
+		want    map[string][]string
+	}{
+		{
+			name: "empty",
+			want: map[string][]string{"#kessoku": nil},
+		},
+		{
+			name: "present",
+			add:  []term{{"#kessoku", "bocchi"}, {"#kessoku", "Ryo"}},
+			want: map[string][]string{"#kessoku": {"bocchi", "ryo"}},
+		},
+		{
+			name: "channels",
+			add:  []term{{"#kessoku", "bocchi"}, {"#sickhack", "hiroi"}},
+			want: map[string][]string{"#kessoku": {"bocchi"}, "#sickhack": {"hiroi"}},
+		},
+		{
+			name: "duplicate",
+			add:  []term{{"#kessoku", "bocchi"}, {"#kessoku", "BOCCHI"}},
+			want: map[string][]string{"#kessoku": {"bocchi"}},
+		},
+		{
+			name:    "remove",
+			add:     []term{{"#kessoku", "bocchi"}, {"#kessoku", "ryo"}, {"#sickhack", "ryo"}},
+			rem:     []term{{"#kessoku", "RYO"}, {"#kessoku", "kita"}},
+			removed: []bool{true, false},
+			want:    map[string][]string{"#kessoku": {"bocchi"}, "#sickhack": {"ryo"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			l, err := blocklist.Open(ctx, testConn())
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, v := range c.add {
+				if err := l.Add(ctx, v.channel, v.term); err != nil {
+					t.Errorf("couldn't add %v: %v", v, err)
+				}
+			}
+			for i, v := range c.rem {
+				ok, err := l.Remove(ctx, v.channel, v.term)
+				if err != nil {
+					t.Errorf("couldn't remove %v: %v", v, err)
+				}
+				if ok != c.removed[i] {
+					t.Errorf("wrong removal result for %v: want %t, got %t", v, c.removed[i], ok)
+				}
+			}
+			for ch, want := range c.want {
+				got, err := l.Terms(ctx, ch)
+				if err != nil {
+					t.Errorf("couldn't list %s: %v", ch, err)
+				}
+				if !slices.Equal(got, want) {
+					t.Errorf("wrong terms for %s: want %q, got %q", ch, want, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/zephyrtronium/robot/userhash"
+)
+
+// UserForgetter is a brain which can forget messages by their senders.
+// Brains which don't record senders need not implement it.
+type UserForgetter interface {
+	// ForgetUser forgets everything learned from messages with a given
+	// sender userhash. It returns the IDs of the messages forgotten.
+	ForgetUser(ctx context.Context, tag string, user *userhash.Hash) ([]string, error)
+}
+
+// ForgetUser forgets everything learned from messages sent by a user in a
+// location between two times. It computes the userhash for each
+// [userhash.TimeQuantum] in the window to find the user's messages.
+// It returns the IDs of the messages forgotten.
+// If br does not implement [UserForgetter], the error is
+// [errors.ErrUnsupported].
+func ForgetUser(ctx context.Context, br Interface, hasher userhash.Hasher, tag, uid, where string, since, until time.Time) ([]string, error) {
+	f, ok := br.(UserForgetter)
+	if !ok {
+		return nil, fmt.Errorf("brain can't forget by user: %w", errors.ErrUnsupported)
+	}
+	q := userhash.TimeQuantum.Nanoseconds()
+	var ids []string
+	for t := since.UnixNano() / q * q; t <= until.UnixNano(); t += q {
+		u := hasher.Hash(uid, where, time.Unix(0, t))
+		l, err := f.ForgetUser(ctx, tag, &u)
+		ids = append(ids, l...)
+		if err != nil {
+			return ids, err
+		}
+	}
+	return ids, nil
+}
+
+// Reason is why a message is forgotten.
+type Reason int
+
+const (
+	// Deleted is for a single message deleted by platform moderation.
+	// It is the reason used by [Interface.Forget].
+	Deleted Reason = iota
+	// Cleared is for messages removed because the chat was cleared or because
+	// their sender was banned or timed out.
+	Cleared
+	// Moderated is for messages forgotten by moderators through the robot's
+	// own forget commands. Only these messages can be restored.
+	Moderated
+	// ForgotUser is for messages forgotten because moderators forgot
+	// everything from their sender. It is the reason used by
+	// [UserForgetter.ForgetUser].
+	ForgotUser
+)
+
+// ReasonForgetter is a brain which records why messages are forgotten.
+type ReasonForgetter interface {
+	// ForgetFor forgets everything learned from a single given message for a
+	// given reason. Otherwise it is the same as Forget.
+	ForgetFor(ctx context.Context, tag, id string, why Reason) error
+}
+
+// ForgetFor forgets everything learned from a single message for a reason.
+// If br does not implement [ReasonForgetter], it uses br.Forget instead.
+func ForgetFor(ctx context.Context, br Interface, tag, id string, why Reason) error {
+	if f, ok := br.(ReasonForgetter); ok {
+		return f.ForgetFor(ctx, tag, id, why)
+	}
+	return br.Forget(ctx, tag, id)
+}
+
+// Restorer is a brain which can restore forgotten messages.
+type Restorer interface {
+	// Restore restores everything learned from a single message that was
+	// forgotten for [Moderated]. It reports whether the message was restored.
+	// Messages forgotten for any other reason must not be restored.
+	Restore(ctx context.Context, tag, id string) (bool, error)
+}
+
+// Restore restores messages forgotten for [Moderated].
+// It returns the IDs of the messages restored.
+// If br does not implement [Restorer], the error is [errors.ErrUnsupported].
+func Restore(ctx context.Context, br Interface, tag string, ids ...string) ([]string, error) {
+	r, ok := br.(Restorer)
+	if !ok {
+		return nil, fmt.Errorf("brain can't restore messages: %w", errors.ErrUnsupported)
+	}
+	var restored []string
+	for _, id := range ids {
+		ok, err := r.Restore(ctx, tag, id)
+		if err != nil {
+			return restored, err
+		}
+		if ok {
+			restored = append(restored, id)
+		}
+	}
+	return restored, nil
+}
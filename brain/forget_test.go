@@ -0,0 +1,158 @@
+package brain_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/zephyrtronium/robot/brain"
+	"github.com/zephyrtronium/robot/userhash"
+)
+
+type userForgetter struct {
+	brain.Interface
+	// msgs maps userhashes to message IDs.
+	msgs map[userhash.Hash][]string
+}
+
+func (f *userForgetter) ForgetUser(ctx context.Context, tag string, user *userhash.Hash) ([]string, error) {
+	r := f.msgs[*user]
+	delete(f.msgs, *user)
+	return r, nil
+}
+
+func TestForgetUser(t *testing.T) {
+	h := userhash.New([]byte("kessoku"))
+	base := time.Unix(1e9, 0)
+	at := func(uid string, d time.Duration) userhash.Hash { return h.Hash(uid, "#kessoku", base.Add(d)) }
+	cases := []struct {
+		name  string
+		msgs  map[userhash.Hash][]string
+		uid   string
+		since time.Duration
+		until time.Duration
+		want  []string
+	}{
+		{
+			name:  "none",
+			msgs:  map[userhash.Hash][]string{},
+			uid:   "bocchi",
+			since: -time.Hour,
+			until: 0,
+			want:  nil,
+		},
+		{
+			name: "window",
+			msgs: map[userhash.Hash][]string{
+				at("bocchi", -2*time.Hour): {"1"},
+				at("bocchi", -time.Hour):   {"2", "3"},
+				at("bocchi", 0):            {"4"},
+				at("ryo", 0):               {"5"},
+			},
+			uid:   "bocchi",
+			since: -time.Hour,
+			until: 0,
+			want:  []string{"2", "3", "4"},
+		},
+		{
+			name: "partial-quantum",
+			msgs: map[userhash.Hash][]string{
+				at("bocchi", -30*time.Minute): {"1"},
+			},
+			uid:   "bocchi",
+			since: -20 * time.Minute,
+			until: 0,
+			want:  []string{"1"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			br := &userForgetter{msgs: c.msgs}
+			got, err := brain.ForgetUser(context.Background(), br, h, "kessoku", c.uid, "#kessoku", base.Add(c.since), base.Add(c.until))
+			if err != nil {
+				t.Errorf("couldn't forget: %v", err)
+			}
+			slices.Sort(got)
+			if !slices.Equal(got, c.want) {
+				t.Errorf("wrong ids: want %q, got %q", c.want, got)
+			}
+		})
+	}
+}
+
+func TestForgetUserUnsupported(t *testing.T) {
+	h := userhash.New([]byte("kessoku"))
+	_, err := brain.ForgetUser(context.Background(), nil, h, "kessoku", "bocchi", "#kessoku", time.Unix(0, 0), time.Unix(1, 0))
+	if !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("wrong error: want %v, got %v", errors.ErrUnsupported, err)
+	}
+}
+
+type restorer struct {
+	brain.Interface
+	// forgotten is the set of forgotten message IDs.
+	forgotten map[string]bool
+}
+
+func (r *restorer) Restore(ctx context.Context, tag, id string) (bool, error) {
+	ok := r.forgotten[id]
+	delete(r.forgotten, id)
+	return ok, nil
+}
+
+func TestRestore(t *testing.T) {
+	br := &restorer{forgotten: map[string]bool{"1": true, "3": true}}
+	got, err := brain.Restore(context.Background(), br, "kessoku", "1", "2", "3")
+	if err != nil {
+		t.Errorf("couldn't restore: %v", err)
+	}
+	want := []string{"1", "3"}
+	if !slices.Equal(got, want) {
+		t.Errorf("wrong ids: want %q, got %q", want, got)
+	}
+	_, err = brain.Restore(context.Background(), nil, "kessoku", "1")
+	if !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("wrong error: want %v, got %v", errors.ErrUnsupported, err)
+	}
+}
+
+type reasonForgetter struct {
+	brain.Interface
+	// forgotten maps forgotten message IDs to the reasons given.
+	forgotten map[string]brain.Reason
+}
+
+func (f *reasonForgetter) ForgetFor(ctx context.Context, tag, id string, why brain.Reason) error {
+	f.forgotten[id] = why
+	return nil
+}
+
+type plainForgetter struct {
+	brain.Interface
+	// forgotten is the set of forgotten message IDs.
+	forgotten map[string]bool
+}
+
+func (f *plainForgetter) Forget(ctx context.Context, tag, id string) error {
+	f.forgotten[id] = true
+	return nil
+}
+
+func TestForgetFor(t *testing.T) {
+	r := &reasonForgetter{forgotten: make(map[string]brain.Reason)}
+	if err := brain.ForgetFor(context.Background(), r, "kessoku", "1", brain.Cleared); err != nil {
+		t.Errorf("couldn't forget: %v", err)
+	}
+	if got, ok := r.forgotten["1"]; !ok || got != brain.Cleared {
+		t.Errorf("wrong reason: want %v, got %v (present %t)", brain.Cleared, got, ok)
+	}
+	p := &plainForgetter{forgotten: make(map[string]bool)}
+	if err := brain.ForgetFor(context.Background(), p, "kessoku", "1", brain.Moderated); err != nil {
+		t.Errorf("couldn't forget: %v", err)
+	}
+	if !p.forgotten["1"] {
+		t.Errorf("brain without reasons didn't forget")
+	}
+}
@@ -2,6 +2,7 @@ package kvbrain
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/dgraph-io/badger/v4"
@@ -22,3 +23,10 @@ func (br *Brain) Forget(ctx context.Context, tag, id string) error {
 	}
 	return nil
 }
+
+// Restore would restore a forgotten message, but kvbrain does not record why
+// messages were forgotten, so it can't tell which ones are allowed to come
+// back. It always returns an error wrapping [errors.ErrUnsupported].
+func (br *Brain) Restore(ctx context.Context, tag, id string) (bool, error) {
+	return false, fmt.Errorf("kvbrain can't restore messages: %w", errors.ErrUnsupported)
+}
@@ -2,6 +2,7 @@ package kvbrain
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -131,3 +132,33 @@ func TestForget(t *testing.T) {
 		})
 	}
 }
+
+func TestRestore(t *testing.T) {
+	ctx := context.Background()
+	db, err := badger.Open(badger.DefaultOptions("").WithInMemory(true).WithLogger(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	br := New(db)
+	m := brain.Message{ID: "1", Sender: userhash.Hash{2}}
+	if err := br.Learn(ctx, "kessoku", &m, []brain.Tuple{{Prefix: []string{"bocchi"}, Suffix: "ryou"}}); err != nil {
+		t.Errorf("failed to learn: %v", err)
+	}
+	if err := br.Forget(ctx, "kessoku", "1"); err != nil {
+		t.Errorf("couldn't forget: %v", err)
+	}
+	// kvbrain doesn't know why a message was forgotten, so it must never
+	// bring one back.
+	ok, err := br.Restore(ctx, "kessoku", "1")
+	if !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("wrong error: want %v, got %v", errors.ErrUnsupported, err)
+	}
+	if ok {
+		t.Errorf("restored a message")
+	}
+	want := map[string]string{
+		mkey("kessoku", "bocchi\xff\xff", "1"): "ryou",
+		mkey("kessoku", "\xfe\xfe", "1"):       "",
+	}
+	dbcheck(t, db, want)
+}
@@ -2,8 +2,12 @@ package brain
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"iter"
+	"math/rand/v2"
 	"slices"
+	"time"
 
 	"github.com/zephyrtronium/robot/tpool"
 )
@@ -74,3 +78,43 @@ func Recall(ctx context.Context, br Interface, tag string) iter.Seq2[Message, er
 		}
 	}
 }
+
+// Sampler is a brain which can read out a random selection of the messages
+// it knows without reading all of them.
+type Sampler interface {
+	// Sample fills out with messages learned with a given tag before a given
+	// time, chosen roughly at random. It may fill fewer than len(out), e.g.
+	// if the brain knows fewer messages. At minimum, the ID, text, timestamp,
+	// and sender of each message must be retrieved.
+	Sample(ctx context.Context, tag string, before time.Time, out []Message) (int, error)
+}
+
+// Sample chooses a message at random from up to n messages a brain knows
+// with a given tag, learned before a given time, for which keep returns true.
+// If none of them are kept, the result is the zero Message with a nil error.
+// If br does not implement [Sampler], the error is [errors.ErrUnsupported].
+func Sample(ctx context.Context, br Interface, tag string, before time.Time, n int, keep func(*Message) bool) (Message, error) {
+	s, ok := br.(Sampler)
+	if !ok {
+		return Message{}, fmt.Errorf("brain can't sample messages: %w", errors.ErrUnsupported)
+	}
+	msgs := make([]Message, n)
+	n, err := s.Sample(ctx, tag, before, msgs)
+	if err != nil {
+		return Message{}, err
+	}
+	var (
+		r Message
+		k int
+	)
+	for i := range msgs[:n] {
+		if !keep(&msgs[i]) {
+			continue
+		}
+		k++
+		if rand.IntN(k) == 0 {
+			r = msgs[i]
+		}
+	}
+	return r, nil
+}
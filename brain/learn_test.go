@@ -2,10 +2,12 @@ package brain_test
 
 import (
 	"context"
+	"errors"
 	"iter"
 	"slices"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
@@ -155,3 +157,65 @@ func TestRecall(t *testing.T) {
 		})
 	}
 }
+
+// testSampler samples messages in order.
+type testSampler struct {
+	testLearner
+}
+
+func (t *testSampler) Sample(ctx context.Context, tag string, before time.Time, out []brain.Message) (int, error) {
+	n := 0
+	for _, m := range t.msg {
+		if n == len(out) {
+			break
+		}
+		if m.Time().Before(before) {
+			out[n] = m
+			n++
+		}
+	}
+	return n, nil
+}
+
+func TestSample(t *testing.T) {
+	l := testSampler{
+		testLearner{
+			msg: []brain.Message{
+				{ID: "1", Text: "bocchi", Timestamp: 1},
+				{ID: "2", Text: "ryo", Timestamp: 2},
+				{ID: "3", Text: "nijika", Timestamp: 3},
+				{ID: "4", Text: "kita", Timestamp: 4},
+				{ID: "5", Text: "kikuri", Timestamp: 5},
+			},
+		},
+	}
+	odd := func(m *brain.Message) bool { return m.Timestamp%2 == 1 }
+	seen := make(map[string]bool)
+	for range 200 {
+		m, err := brain.Sample(context.Background(), &l, "kessoku", time.UnixMilli(5), 4, odd)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !odd(&m) {
+			t.Fatalf("sampled unkept message %+v", m)
+		}
+		seen[m.ID] = true
+	}
+	if !seen["1"] || !seen["3"] {
+		t.Errorf("didn't sample all kept messages: %v", seen)
+	}
+	if seen["5"] {
+		t.Errorf("sampled message from after the limit: %v", seen)
+	}
+	m, err := brain.Sample(context.Background(), &l, "kessoku", time.UnixMilli(5), 4, func(*brain.Message) bool { return false })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.ID != "" {
+		t.Errorf("sampled %+v with nothing kept", m)
+	}
+	_, err = brain.Sample(context.Background(), &l.testLearner, "kessoku", time.UnixMilli(5), 4, odd)
+	if !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("wrong error sampling without sampler: want %v, got %v", errors.ErrUnsupported, err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"math"
 	"math/rand/v2"
 	"slices"
 
@@ -17,6 +18,7 @@ var (
 	tokensPool    tpool.Pool[[]string]
 	prependerPool tpool.Pool[deque.Deque[string]]
 	bytesPool     tpool.Pool[[]byte]
+	samplePool    tpool.Pool[*sample]
 )
 
 // Think produces a new message and the trace of message IDs used to form it
@@ -24,23 +26,49 @@ var (
 // If the brain does not produce any terms, the result is the empty string
 // regardless of the prompt, with no error.
 func Think(ctx context.Context, s Interface, tag, prompt string) (string, []string, error) {
-	w := bytesPool.Get()
+	return ThinkWeighted(ctx, s, tag, prompt, nil)
+}
+
+// Weights maps message IDs to factors scaling how likely [ThinkWeighted] is
+// to choose terms learned from them. Messages absent from the map have
+// weight 1.
+type Weights map[string]float64
+
+// key computes a random sort key for a term learned from the message id,
+// such that choosing the term with the largest key among a set is a weighted
+// random choice.
+func (w Weights) key(id []byte) float64 {
+	x, ok := w[string(id)]
+	if !ok {
+		x = 1
+	}
+	// Efraimidis-Spirakis, in log space.
+	return math.Log(rand.Float64()) / max(x, 1e-6)
+}
+
+// ThinkWeighted is like [Think], but it chooses each term with probability
+// proportional to the weight of the message it was learned from instead of
+// uniformly. If w is empty, it is the same as Think.
+// When a term has many options, the choice is weighted among a uniform
+// sample of them.
+func ThinkWeighted(ctx context.Context, s Interface, tag, prompt string, w Weights) (string, []string, error) {
+	b := bytesPool.Get()
 	toks := tokens(tokensPool.Get(), prompt)
 	for i, t := range toks {
-		w = append(w, t...)
+		b = append(b, t...)
 		toks[i] = reduceEntropy(t)
 	}
 	slices.Reverse(toks)
 	search := prependerPool.Get().Prepend(toks...)
 	defer func() {
-		bytesPool.Put(w[:0])
+		bytesPool.Put(b[:0])
 		tokensPool.Put(toks[:0])
 		prependerPool.Put(search.Reset())
 	}()
 
 	var ids []string
 	// We handle the first search specially.
-	id, tok, err := first(ctx, s, tag, search.Slice())
+	id, tok, err := first(ctx, s, tag, search.Slice(), w)
 	if len(tok) == 0 {
 		return "", nil, err
 	}
@@ -48,35 +76,35 @@ func Think(ctx context.Context, s Interface, tag, prompt string) (string, []stri
 	if !ok {
 		ids = slices.Insert(ids, k, id)
 	}
-	w = append(w, tok...)
+	b = append(b, tok...)
 	search = search.Prepend(reduceEntropy(tok))
 
 	for range 1024 {
-		id, tok, l, err := next(ctx, s, tag, search.Slice())
+		id, tok, l, err := next(ctx, s, tag, search.Slice(), w)
 		if len(tok) == 0 {
 			// This could mean the message is done, there was no match for
 			// the prefix, or an error occurred.
-			return string(bytes.TrimSpace(w)), ids, err
+			return string(bytes.TrimSpace(b)), ids, err
 		}
 		k, ok := slices.BinarySearch(ids, id)
 		if !ok {
 			ids = slices.Insert(ids, k, id)
 		}
-		w = append(w, tok...)
+		b = append(b, tok...)
 		search = search.DropEnd(search.Len() - l - 1).Prepend(reduceEntropy(tok))
 	}
-	return string(bytes.TrimSpace(w)), ids, nil
+	return string(bytes.TrimSpace(b)), ids, nil
 }
 
 // next finds a single next term from a brain given a prompt.
-func next(ctx context.Context, s Interface, tag string, prompt []string) (id, tok string, l int, err error) {
+func next(ctx context.Context, s Interface, tag string, prompt []string, w Weights) (id, tok string, l int, err error) {
 	wid := make([]byte, 0, 64)
 	wtok := make([]byte, 0, 64)
 	var skip pick.Skip
 	var n uint64
 	for {
 		var seen uint64
-		n, seen, err = term(ctx, s, tag, prompt, &wid, &wtok, &skip, n)
+		n, seen, err = term(ctx, s, tag, prompt, &wid, &wtok, &skip, n, w)
 		if err != nil {
 			return "", "", 0, err
 		}
@@ -102,7 +130,11 @@ func next(ctx context.Context, s Interface, tag string, prompt []string) (id, to
 
 // term gets the thought for a single prompt and skip sequence with a starting
 // skip length, returning the new skip length and the total number skipped.
-func term(ctx context.Context, s Interface, tag string, prompt []string, wid, wtok *[]byte, skip *pick.Skip, n uint64) (uint64, uint64, error) {
+// If w is not empty, it makes a weighted choice instead, ignoring the skip.
+func term(ctx context.Context, s Interface, tag string, prompt []string, wid, wtok *[]byte, skip *pick.Skip, n uint64, w Weights) (uint64, uint64, error) {
+	if len(w) != 0 {
+		return termWeighted(ctx, s, tag, prompt, wid, wtok, w)
+	}
 	var seen uint64
 	for f := range s.Think(ctx, tag, prompt) {
 		seen++
@@ -119,10 +151,81 @@ func term(ctx context.Context, s Interface, tag string, prompt []string, wid, wt
 	return n, seen, nil
 }
 
+// weightedSample is the number of options for a term that weighted choice
+// considers. When there are more, it chooses among a uniform sample of them,
+// so that it decodes about as few options as uniform choice does.
+const weightedSample = 32
+
+// sample holds the options considered for a weighted choice.
+type sample [weightedSample]struct{ id, tok []byte }
+
+// termWeighted gets the thought for a single prompt by weighted choice,
+// returning the total number of options seen.
+func termWeighted(ctx context.Context, s Interface, tag string, prompt []string, wid, wtok *[]byte, w Weights) (uint64, uint64, error) {
+	var (
+		seen uint64
+		// opts is a uniform sample of the options, filled by Algorithm L.
+		opts = samplePool.Get()
+		// lw is the log of Algorithm L's W, and take is the index of the
+		// next option to take into the sample.
+		lw   float64
+		take uint64
+	)
+	if opts == nil {
+		opts = new(sample)
+	}
+	defer samplePool.Put(opts)
+	for f := range s.Think(ctx, tag, prompt) {
+		i := seen
+		seen++
+		var k int
+		switch {
+		case i < weightedSample:
+			k = int(i)
+			if seen == weightedSample {
+				lw = math.Log(rand.Float64()) / weightedSample
+				take = seen + skipL(lw)
+			}
+		case i == take:
+			k = rand.IntN(weightedSample)
+			lw += math.Log(rand.Float64()) / weightedSample
+			take = seen + skipL(lw)
+		default:
+			continue
+		}
+		o := &opts[k]
+		o.id, o.tok = o.id[:0], o.tok[:0]
+		if err := f(&o.id, &o.tok); err != nil {
+			return 0, seen, fmt.Errorf("couldn't think: %w", err)
+		}
+	}
+	best := math.Inf(-1)
+	for i := range min(seen, weightedSample) {
+		o := &opts[i]
+		if k := w.key(o.id); k > best || i == 0 {
+			best = k
+			*wid = append((*wid)[:0], o.id...)
+			*wtok = append((*wtok)[:0], o.tok...)
+		}
+	}
+	return 0, seen, nil
+}
+
+// skipL computes the number of options to skip before the next one to take
+// into a sample by Algorithm L, given the log of its W.
+func skipL(lw float64) uint64 {
+	n := math.Floor(math.Log(rand.Float64()) / math.Log1p(-math.Exp(lw)))
+	if !(n < 1<<62) {
+		// Includes NaN, which shouldn't happen but would end sampling.
+		return 1 << 62
+	}
+	return uint64(n)
+}
+
 // first finds a single first term from a brain given a prompt.
 // Unlike next, it requires the entire prompt to match, and it skips empty
 // continuations if the prompt is not empty.
-func first(ctx context.Context, s Interface, tag string, prompt []string) (id, tok string, err error) {
+func first(ctx context.Context, s Interface, tag string, prompt []string, w Weights) (id, tok string, err error) {
 	wid := make([]byte, 0, 64)
 	wtok := make([]byte, 0, 64)
 	var skip pick.Skip
@@ -131,7 +234,7 @@ func first(ctx context.Context, s Interface, tag string, prompt []string) (id, t
 	// into the same loop, but it's easier and probably more efficient to
 	// split the control flow.
 	if len(prompt) == 0 {
-		_, _, err := term(ctx, s, tag, prompt, &wid, &wtok, &skip, 0)
+		_, _, err := term(ctx, s, tag, prompt, &wid, &wtok, &skip, 0, w)
 		if err != nil {
 			return "", "", fmt.Errorf("couldn't think of first term: %w", err)
 		}
@@ -139,6 +242,7 @@ func first(ctx context.Context, s Interface, tag string, prompt []string) (id, t
 	}
 
 	var rid, rtok []byte
+	best := math.Inf(-1)
 	for f := range s.Think(ctx, tag, prompt) {
 		// The downside with a prompt is that we have to read every option so
 		// that we only count non-empty continuations.
@@ -150,7 +254,13 @@ func first(ctx context.Context, s Interface, tag string, prompt []string) (id, t
 			// Empty suffix. Don't care.
 			continue
 		}
-		if n > 0 {
+		if len(w) != 0 {
+			k := w.key(wid)
+			if k <= best && len(rtok) != 0 {
+				continue
+			}
+			best = k
+		} else if n > 0 {
 			n--
 			continue
 		}
@@ -149,3 +149,74 @@ func TestThink(t *testing.T) {
 		})
 	}
 }
+
+type idTuple struct {
+	id string
+	brain.Tuple
+}
+
+type weightThinker struct {
+	testThinker
+	tups []idTuple
+}
+
+func (t *weightThinker) Think(ctx context.Context, tag string, prefix []string) iter.Seq[func(id *[]byte, suf *[]byte) error] {
+	return func(yield func(func(id *[]byte, suf *[]byte) error) bool) {
+		var v idTuple
+		f := func(id, suf *[]byte) error {
+			*id = append(*id, v.id...)
+			*suf = append(*suf, v.Suffix...)
+			return nil
+		}
+		for _, v = range t.tups {
+			if !slices.Equal(v.Prefix, prefix) {
+				continue
+			}
+			if !yield(f) {
+				break
+			}
+		}
+	}
+}
+
+func TestThinkWeighted(t *testing.T) {
+	s := weightThinker{
+		tups: []idTuple{
+			{"1", brain.Tuple{Prefix: nil, Suffix: "bocchi "}},
+			{"2", brain.Tuple{Prefix: nil, Suffix: "ryo "}},
+			{"3", brain.Tuple{Prefix: []string{"bocchi "}, Suffix: "kita "}},
+			{"4", brain.Tuple{Prefix: []string{"bocchi "}, Suffix: "nijika "}},
+			{"5", brain.Tuple{Prefix: []string{"ryo "}, Suffix: "kita "}},
+			{"6", brain.Tuple{Prefix: []string{"ryo "}, Suffix: "nijika "}},
+		},
+	}
+	cases := []struct {
+		name   string
+		prompt string
+		w      brain.Weights
+		say    string
+		trace  []string
+	}{
+		{"first", "", brain.Weights{"2": 0, "4": 0}, "bocchi kita", []string{"1", "3"}},
+		{"second", "", brain.Weights{"1": 0, "5": 0}, "ryo nijika", []string{"2", "6"}},
+		{"prompted", "ryo", brain.Weights{"5": 0}, "ryo nijika", []string{"6"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			// Zero weights are almost never chosen when there's another option,
+			// so try many times.
+			for range 100 {
+				r, trace, err := brain.ThinkWeighted(context.Background(), &s, "", c.prompt, c.w)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if r != c.say {
+					t.Fatalf("wrong result: want %q, got %q", c.say, r)
+				}
+				if !slices.Equal(trace, c.trace) {
+					t.Fatalf("wrong trace: want %q, got %q", c.trace, trace)
+				}
+			}
+		})
+	}
+}
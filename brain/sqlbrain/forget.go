@@ -7,12 +7,33 @@ import (
 
 	"zombiezen.com/go/sqlite"
 	"zombiezen.com/go/sqlite/sqlitex"
+
+	"github.com/zephyrtronium/robot/brain"
+	"github.com/zephyrtronium/robot/userhash"
 )
 
 // Forget forgets everything learned from a single given message.
 // If nothing has been learned from the message, a message with that ID cannot
 // be learned in the future.
-func (br *Brain) Forget(ctx context.Context, tag, id string) (err error) {
+func (br *Brain) Forget(ctx context.Context, tag, id string) error {
+	return br.ForgetFor(ctx, tag, id, brain.Deleted)
+}
+
+// reasons maps forget reasons to the values recorded in the deleted columns.
+var reasons = [...]string{
+	brain.Deleted:    "CLEARMSG",
+	brain.Cleared:    "CLEARCHAT",
+	brain.Moderated:  "FORGET",
+	brain.ForgotUser: "FORGETUSER",
+}
+
+// ForgetFor forgets everything learned from a single given message, recording
+// why. If nothing has been learned from the message, a message with that ID
+// cannot be learned in the future.
+func (br *Brain) ForgetFor(ctx context.Context, tag, id string, why brain.Reason) (err error) {
+	if int(why) < 0 || int(why) >= len(reasons) {
+		return fmt.Errorf("couldn't forget message %v: unknown reason %d", id, why)
+	}
 	conn, err := br.db.Take(ctx)
 	defer br.db.Put(conn)
 	if err != nil {
@@ -21,9 +42,16 @@ func (br *Brain) Forget(ctx context.Context, tag, id string) (err error) {
 	defer sqlitex.Transaction(conn)(&err)
 	{
 		// First forget the message, so that an attempt to learn it later will fail.
+		// Keep the existing reason if the message is already deleted, so that
+		// e.g. a message forgotten by user is never restored. The exception is
+		// a message forgotten by a mod, which any other reason replaces so that
+		// messages removed in chat can't come back with an undo.
 		const forget = `
-			INSERT INTO messages (tag, id, deleted) VALUES (:tag, :id, 'CLEARMSG')
-			ON CONFLICT DO UPDATE SET deleted = 'CLEARMSG'
+			INSERT INTO messages (tag, id, deleted) VALUES (:tag, :id, :reason)
+			ON CONFLICT DO UPDATE SET deleted = CASE
+				WHEN deleted IS NULL OR deleted = 'FORGET' THEN :reason
+				ELSE deleted
+			END
 		`
 		st, err := conn.Prepare(forget)
 		if err != nil {
@@ -31,19 +59,21 @@ func (br *Brain) Forget(ctx context.Context, tag, id string) (err error) {
 		}
 		st.SetText(":tag", tag)
 		st.SetText(":id", id)
+		st.SetText(":reason", reasons[why])
 		if err := allsteps(st); err != nil {
 			return fmt.Errorf("couldn't delete message %v: %w", id, err)
 		}
 	}
 	{
 		// Now forget tuples.
-		const forget = `UPDATE knowledge SET deleted = 'CLEARMSG' WHERE tag=:tag AND id=:id`
+		const forget = `UPDATE knowledge SET deleted = :reason WHERE tag=:tag AND id=:id AND (deleted IS NULL OR deleted = 'FORGET')`
 		st, err := conn.Prepare(forget)
 		if err != nil {
 			return fmt.Errorf("couldn't prepare delete for tuples of message %v: %w", id, err)
 		}
 		st.SetText(":tag", tag)
 		st.SetText(":id", id)
+		st.SetText(":reason", reasons[why])
 		if err := allsteps(st); err != nil {
 			return fmt.Errorf("couldn't delete tuples of message %v: %w", id, err)
 		}
@@ -51,6 +81,110 @@ func (br *Brain) Forget(ctx context.Context, tag, id string) (err error) {
 	return nil
 }
 
+// ForgetUser forgets everything learned from messages with a given sender
+// userhash at the request of a moderator. It returns the IDs of the messages
+// forgotten. Messages which are already forgotten are not included.
+func (br *Brain) ForgetUser(ctx context.Context, tag string, user *userhash.Hash) (ids []string, err error) {
+	conn, err := br.db.Take(ctx)
+	defer br.db.Put(conn)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get connection to forget user: %w", err)
+	}
+	defer sqlitex.Transaction(conn)(&err)
+	{
+		// Forget tuples first, while we can still identify the messages
+		// which aren't already deleted.
+		const forget = `
+			UPDATE knowledge SET deleted = :reason
+			WHERE tag=:tag AND deleted IS NULL AND id IN (
+				SELECT id FROM messages WHERE tag=:tag AND user=:user AND deleted IS NULL
+			)
+		`
+		st, err := conn.Prepare(forget)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't prepare delete for tuples by user: %w", err)
+		}
+		st.SetText(":tag", tag)
+		st.SetBytes(":user", user[:])
+		st.SetText(":reason", reasons[brain.ForgotUser])
+		if err := allsteps(st); err != nil {
+			return nil, fmt.Errorf("couldn't delete tuples by user: %w", err)
+		}
+	}
+	{
+		const forget = `
+			UPDATE messages SET deleted = :reason
+			WHERE tag=:tag AND user=:user AND deleted IS NULL
+			RETURNING id
+		`
+		st, err := conn.Prepare(forget)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't prepare delete for messages by user: %w", err)
+		}
+		st.SetText(":tag", tag)
+		st.SetBytes(":user", user[:])
+		st.SetText(":reason", reasons[brain.ForgotUser])
+		for {
+			ok, err := st.Step()
+			if err != nil {
+				return nil, fmt.Errorf("couldn't delete messages by user: %w", err)
+			}
+			if !ok {
+				break
+			}
+			ids = append(ids, st.ColumnText(0))
+		}
+	}
+	return ids, nil
+}
+
+// Restore restores everything learned from a single message that was
+// forgotten for [brain.Moderated]. It reports whether the message was
+// restored. Messages forgotten for any other reason stay forgotten.
+func (br *Brain) Restore(ctx context.Context, tag, id string) (ok bool, err error) {
+	conn, err := br.db.Take(ctx)
+	defer br.db.Put(conn)
+	if err != nil {
+		return false, fmt.Errorf("couldn't get connection to restore message %v: %w", id, err)
+	}
+	defer sqlitex.Transaction(conn)(&err)
+	{
+		const restore = `UPDATE messages SET deleted = NULL WHERE tag=:tag AND id=:id AND deleted = 'FORGET' RETURNING id`
+		st, err := conn.Prepare(restore)
+		if err != nil {
+			return false, fmt.Errorf("couldn't prepare restore for message %v: %w", id, err)
+		}
+		st.SetText(":tag", tag)
+		st.SetText(":id", id)
+		for {
+			more, err := st.Step()
+			if err != nil {
+				return false, fmt.Errorf("couldn't restore message %v: %w", id, err)
+			}
+			if !more {
+				break
+			}
+			ok = true
+		}
+	}
+	if !ok {
+		return false, nil
+	}
+	{
+		const restore = `UPDATE knowledge SET deleted = NULL WHERE tag=:tag AND id=:id AND deleted = 'FORGET'`
+		st, err := conn.Prepare(restore)
+		if err != nil {
+			return false, fmt.Errorf("couldn't prepare restore for tuples of message %v: %w", id, err)
+		}
+		st.SetText(":tag", tag)
+		st.SetText(":id", id)
+		if err := allsteps(st); err != nil {
+			return false, fmt.Errorf("couldn't restore tuples of message %v: %w", id, err)
+		}
+	}
+	return true, nil
+}
+
 func allsteps(st *sqlite.Stmt) error {
 	for {
 		ok, err := st.Step()
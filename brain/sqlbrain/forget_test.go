@@ -428,3 +428,361 @@ func TestForget(t *testing.T) {
 		})
 	}
 }
+
+func TestForgetUser(t *testing.T) {
+	learn := []learn{
+		{
+			tag:  "kessoku",
+			user: userhash.Hash{1},
+			id:   "1",
+			t:    1,
+			tups: []brain.Tuple{
+				{Prefix: []string{"bocchi"}, Suffix: ""},
+				{Prefix: nil, Suffix: "bocchi"},
+			},
+		},
+		{
+			tag:  "kessoku",
+			user: userhash.Hash{1},
+			id:   "2",
+			t:    2,
+			tups: []brain.Tuple{
+				{Prefix: []string{"ryo"}, Suffix: ""},
+				{Prefix: nil, Suffix: "ryo"},
+			},
+		},
+		{
+			tag:  "kessoku",
+			user: userhash.Hash{2},
+			id:   "3",
+			t:    3,
+			tups: []brain.Tuple{
+				{Prefix: []string{"nijika"}, Suffix: ""},
+				{Prefix: nil, Suffix: "nijika"},
+			},
+		},
+		{
+			tag:  "sickhack",
+			user: userhash.Hash{1},
+			id:   "4",
+			t:    4,
+			tups: []brain.Tuple{
+				{Prefix: []string{"kikuri"}, Suffix: ""},
+				{Prefix: nil, Suffix: "kikuri"},
+			},
+		},
+	}
+	ctx := context.Background()
+	db := testDB(ctx)
+	br, err := sqlbrain.Open(ctx, db)
+	if err != nil {
+		t.Fatalf("couldn't open brain: %v", err)
+	}
+	for _, m := range learn {
+		msg := brain.Message{
+			ID:        m.id,
+			Sender:    m.user,
+			Timestamp: m.t,
+		}
+		if err := br.Learn(ctx, m.tag, &msg, m.tups); err != nil {
+			t.Fatalf("failed to learn %v/%v: %v", m.tag, m.id, err)
+		}
+	}
+	// Forget one message individually first. It shouldn't be reported, and
+	// its deletion reason shouldn't change.
+	if err := br.Forget(ctx, "kessoku", "2"); err != nil {
+		t.Fatalf("failed to forget: %v", err)
+	}
+	ids, err := br.ForgetUser(ctx, "kessoku", &userhash.Hash{1})
+	if err != nil {
+		t.Errorf("failed to forget user: %v", err)
+	}
+	if len(ids) != 1 || ids[0] != "1" {
+		t.Errorf("wrong ids forgotten: want [1], got %q", ids)
+	}
+	conn, err := db.Take(ctx)
+	defer db.Put(conn)
+	if err != nil {
+		t.Fatalf("couldn't get conn to check db state: %v", err)
+	}
+	know := []know{
+		{tag: "kessoku", id: "1", prefix: "bocchi\x00\x00", suffix: "", deleted: ref("FORGETUSER")},
+		{tag: "kessoku", id: "1", prefix: "\x00", suffix: "bocchi", deleted: ref("FORGETUSER")},
+		{tag: "kessoku", id: "2", prefix: "ryo\x00\x00", suffix: "", deleted: ref("CLEARMSG")},
+		{tag: "kessoku", id: "2", prefix: "\x00", suffix: "ryo", deleted: ref("CLEARMSG")},
+		{tag: "kessoku", id: "3", prefix: "nijika\x00\x00", suffix: ""},
+		{tag: "kessoku", id: "3", prefix: "\x00", suffix: "nijika"},
+		{tag: "sickhack", id: "4", prefix: "kikuri\x00\x00", suffix: ""},
+		{tag: "sickhack", id: "4", prefix: "\x00", suffix: "kikuri"},
+	}
+	msgs := []msg{
+		{tag: "kessoku", id: "1", time: 1e6, user: userhash.Hash{1}, deleted: ref("FORGETUSER")},
+		{tag: "kessoku", id: "2", time: 2e6, user: userhash.Hash{1}, deleted: ref("CLEARMSG")},
+		{tag: "kessoku", id: "3", time: 3e6, user: userhash.Hash{2}},
+		{tag: "sickhack", id: "4", time: 4e6, user: userhash.Hash{1}},
+	}
+	contents(t, conn, know, msgs)
+}
+
+func TestForgetFor(t *testing.T) {
+	learn := []learn{
+		{
+			tag:  "kessoku",
+			user: userhash.Hash{1},
+			id:   "1",
+			t:    1,
+			tups: []brain.Tuple{
+				{Prefix: []string{"bocchi"}, Suffix: ""},
+			},
+		},
+		{
+			tag:  "kessoku",
+			user: userhash.Hash{2},
+			id:   "2",
+			t:    2,
+			tups: []brain.Tuple{
+				{Prefix: []string{"ryo"}, Suffix: ""},
+			},
+		},
+	}
+	ctx := context.Background()
+	db := testDB(ctx)
+	br, err := sqlbrain.Open(ctx, db)
+	if err != nil {
+		t.Fatalf("couldn't open brain: %v", err)
+	}
+	for _, m := range learn {
+		msg := brain.Message{
+			ID:        m.id,
+			Sender:    m.user,
+			Timestamp: m.t,
+		}
+		if err := br.Learn(ctx, m.tag, &msg, m.tups); err != nil {
+			t.Fatalf("failed to learn %v/%v: %v", m.tag, m.id, err)
+		}
+	}
+	if err := br.ForgetFor(ctx, "kessoku", "1", brain.Cleared); err != nil {
+		t.Errorf("failed to forget cleared message: %v", err)
+	}
+	if err := br.ForgetFor(ctx, "kessoku", "2", brain.Moderated); err != nil {
+		t.Errorf("failed to forget moderated message: %v", err)
+	}
+	// Forgetting by a moderator keeps a reason from chat.
+	if err := br.ForgetFor(ctx, "kessoku", "1", brain.Moderated); err != nil {
+		t.Errorf("failed to forget again: %v", err)
+	}
+	// Deleting in chat replaces a moderator's forget.
+	if err := br.Forget(ctx, "kessoku", "2"); err != nil {
+		t.Errorf("failed to forget again: %v", err)
+	}
+	if err := br.ForgetFor(ctx, "kessoku", "3", brain.Reason(-1)); err == nil {
+		t.Errorf("forgot with unknown reason")
+	}
+	conn, err := db.Take(ctx)
+	defer db.Put(conn)
+	if err != nil {
+		t.Fatalf("couldn't get conn to check db state: %v", err)
+	}
+	know := []know{
+		{tag: "kessoku", id: "1", prefix: "bocchi\x00\x00", suffix: "", deleted: ref("CLEARCHAT")},
+		{tag: "kessoku", id: "2", prefix: "ryo\x00\x00", suffix: "", deleted: ref("CLEARMSG")},
+	}
+	msgs := []msg{
+		{tag: "kessoku", id: "1", time: 1e6, user: userhash.Hash{1}, deleted: ref("CLEARCHAT")},
+		{tag: "kessoku", id: "2", time: 2e6, user: userhash.Hash{2}, deleted: ref("CLEARMSG")},
+	}
+	contents(t, conn, know, msgs)
+}
+
+func TestRestore(t *testing.T) {
+	learn := []learn{
+		{
+			tag:  "kessoku",
+			user: userhash.Hash{1},
+			id:   "1",
+			t:    1,
+			tups: []brain.Tuple{
+				{Prefix: []string{"bocchi"}, Suffix: ""},
+				{Prefix: nil, Suffix: "bocchi"},
+			},
+		},
+		{
+			tag:  "kessoku",
+			user: userhash.Hash{2},
+			id:   "2",
+			t:    2,
+			tups: []brain.Tuple{
+				{Prefix: []string{"ryo"}, Suffix: ""},
+				{Prefix: nil, Suffix: "ryo"},
+			},
+		},
+		{
+			tag:  "kessoku",
+			user: userhash.Hash{3},
+			id:   "3",
+			t:    3,
+			tups: []brain.Tuple{
+				{Prefix: []string{"nijika"}, Suffix: ""},
+				{Prefix: nil, Suffix: "nijika"},
+			},
+		},
+	}
+	ctx := context.Background()
+	db := testDB(ctx)
+	br, err := sqlbrain.Open(ctx, db)
+	if err != nil {
+		t.Fatalf("couldn't open brain: %v", err)
+	}
+	for _, m := range learn {
+		msg := brain.Message{
+			ID:        m.id,
+			Sender:    m.user,
+			Timestamp: m.t,
+		}
+		if err := br.Learn(ctx, m.tag, &msg, m.tups); err != nil {
+			t.Fatalf("failed to learn %v/%v: %v", m.tag, m.id, err)
+		}
+	}
+	// Message 1 is forgotten by a moderator, then restored.
+	// Message 2 is forgotten by user, then by a moderator. It must not be
+	// restored.
+	// Message 3 is deleted in chat. It must not be restored.
+	if err := br.ForgetFor(ctx, "kessoku", "1", brain.Moderated); err != nil {
+		t.Fatalf("failed to forget: %v", err)
+	}
+	if _, err := br.ForgetUser(ctx, "kessoku", &userhash.Hash{2}); err != nil {
+		t.Fatalf("failed to forget user: %v", err)
+	}
+	if err := br.ForgetFor(ctx, "kessoku", "2", brain.Moderated); err != nil {
+		t.Fatalf("failed to forget: %v", err)
+	}
+	if err := br.Forget(ctx, "kessoku", "3"); err != nil {
+		t.Fatalf("failed to forget: %v", err)
+	}
+	for _, c := range []struct {
+		id string
+		ok bool
+	}{{"1", true}, {"1", false}, {"2", false}, {"3", false}} {
+		ok, err := br.Restore(ctx, "kessoku", c.id)
+		if err != nil {
+			t.Errorf("failed to restore %s: %v", c.id, err)
+		}
+		if ok != c.ok {
+			t.Errorf("wrong restore result for %s: want %t, got %t", c.id, c.ok, ok)
+		}
+	}
+	conn, err := db.Take(ctx)
+	defer db.Put(conn)
+	if err != nil {
+		t.Fatalf("couldn't get conn to check db state: %v", err)
+	}
+	know := []know{
+		{tag: "kessoku", id: "1", prefix: "bocchi\x00\x00", suffix: ""},
+		{tag: "kessoku", id: "1", prefix: "\x00", suffix: "bocchi"},
+		{tag: "kessoku", id: "2", prefix: "ryo\x00\x00", suffix: "", deleted: ref("FORGETUSER")},
+		{tag: "kessoku", id: "2", prefix: "\x00", suffix: "ryo", deleted: ref("FORGETUSER")},
+		{tag: "kessoku", id: "3", prefix: "nijika\x00\x00", suffix: "", deleted: ref("CLEARMSG")},
+		{tag: "kessoku", id: "3", prefix: "\x00", suffix: "nijika", deleted: ref("CLEARMSG")},
+	}
+	msgs := []msg{
+		{tag: "kessoku", id: "1", time: 1e6, user: userhash.Hash{1}},
+		{tag: "kessoku", id: "2", time: 2e6, user: userhash.Hash{2}, deleted: ref("FORGETUSER")},
+		{tag: "kessoku", id: "3", time: 3e6, user: userhash.Hash{3}, deleted: ref("CLEARMSG")},
+	}
+	contents(t, conn, know, msgs)
+}
+
+func TestRestoreCleared(t *testing.T) {
+	learn := []learn{
+		{
+			tag:  "kessoku",
+			user: userhash.Hash{1},
+			id:   "1",
+			t:    1,
+			tups: []brain.Tuple{
+				{Prefix: nil, Suffix: "bocchi"},
+			},
+		},
+		{
+			tag:  "kessoku",
+			user: userhash.Hash{2},
+			id:   "2",
+			t:    2,
+			tups: []brain.Tuple{
+				{Prefix: nil, Suffix: "ryo"},
+			},
+		},
+		{
+			tag:  "kessoku",
+			user: userhash.Hash{3},
+			id:   "3",
+			t:    3,
+			tups: []brain.Tuple{
+				{Prefix: nil, Suffix: "nijika"},
+			},
+		},
+	}
+	ctx := context.Background()
+	db := testDB(ctx)
+	br, err := sqlbrain.Open(ctx, db)
+	if err != nil {
+		t.Fatalf("couldn't open brain: %v", err)
+	}
+	for _, m := range learn {
+		msg := brain.Message{
+			ID:        m.id,
+			Sender:    m.user,
+			Timestamp: m.t,
+		}
+		if err := br.Learn(ctx, m.tag, &msg, m.tups); err != nil {
+			t.Fatalf("failed to learn %v/%v: %v", m.tag, m.id, err)
+		}
+	}
+	// Each message is forgotten by a moderator, then removed again.
+	// Messages 1 and 2 are removed in chat and must not be restored.
+	// Message 3 is forgotten by a moderator again and can be restored.
+	steps := []struct {
+		id  string
+		why brain.Reason
+	}{
+		{"1", brain.Cleared},
+		{"2", brain.Deleted},
+		{"3", brain.Moderated},
+	}
+	for _, s := range steps {
+		if err := br.ForgetFor(ctx, "kessoku", s.id, brain.Moderated); err != nil {
+			t.Fatalf("failed to forget %s: %v", s.id, err)
+		}
+		if err := br.ForgetFor(ctx, "kessoku", s.id, s.why); err != nil {
+			t.Fatalf("failed to forget %s again: %v", s.id, err)
+		}
+	}
+	for _, c := range []struct {
+		id string
+		ok bool
+	}{{"1", false}, {"2", false}, {"3", true}} {
+		ok, err := br.Restore(ctx, "kessoku", c.id)
+		if err != nil {
+			t.Errorf("failed to restore %s: %v", c.id, err)
+		}
+		if ok != c.ok {
+			t.Errorf("wrong restore result for %s: want %t, got %t", c.id, c.ok, ok)
+		}
+	}
+	conn, err := db.Take(ctx)
+	defer db.Put(conn)
+	if err != nil {
+		t.Fatalf("couldn't get conn to check db state: %v", err)
+	}
+	know := []know{
+		{tag: "kessoku", id: "1", prefix: "\x00", suffix: "bocchi", deleted: ref("CLEARCHAT")},
+		{tag: "kessoku", id: "2", prefix: "\x00", suffix: "ryo", deleted: ref("CLEARMSG")},
+		{tag: "kessoku", id: "3", prefix: "\x00", suffix: "nijika"},
+	}
+	msgs := []msg{
+		{tag: "kessoku", id: "1", time: 1e6, user: userhash.Hash{1}, deleted: ref("CLEARCHAT")},
+		{tag: "kessoku", id: "2", time: 2e6, user: userhash.Hash{2}, deleted: ref("CLEARMSG")},
+		{tag: "kessoku", id: "3", time: 3e6, user: userhash.Hash{3}},
+	}
+	contents(t, conn, know, msgs)
+}
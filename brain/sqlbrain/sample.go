@@ -0,0 +1,89 @@
+package sqlbrain
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+
+	"github.com/zephyrtronium/robot/brain"
+	"github.com/zephyrtronium/robot/userhash"
+)
+
+// Sample fills out with consecutive messages learned with a given tag before
+// a given time, starting from a random time and wrapping around to the oldest
+// messages. Messages which follow long gaps in chat are more likely to be
+// first in the sample.
+func (br *Brain) Sample(ctx context.Context, tag string, before time.Time, out []brain.Message) (n int, err error) {
+	conn, err := br.db.Take(ctx)
+	defer br.db.Put(conn)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get connection to sample: %w", err)
+	}
+	// Separate subqueries so that each can use the time index.
+	const bounds = `SELECT
+	(SELECT MIN(time) FROM messages WHERE tag=:tag),
+	(SELECT MAX(time) FROM messages WHERE tag=:tag AND time < :before)`
+	var lo, hi int64
+	var empty bool
+	opts := sqlitex.ExecOptions{
+		Named: map[string]any{":tag": tag, ":before": before.UnixNano()},
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			empty = stmt.ColumnType(1) == sqlite.TypeNull
+			lo, hi = stmt.ColumnInt64(0), stmt.ColumnInt64(1)
+			return nil
+		},
+	}
+	if err := sqlitex.Execute(conn, bounds, &opts); err != nil {
+		return 0, fmt.Errorf("couldn't get sample bounds: %w", err)
+	}
+	if empty || hi < lo {
+		return 0, nil
+	}
+	start := lo + rand.Int64N(hi-lo+1)
+
+	st, err := conn.Prepare(sampleQuery)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't prepare sample: %w", err)
+	}
+	// Read from the start to the end, then wrap around to fill the rest.
+	ranges := [...][2]int64{{start, before.UnixNano()}, {lo, start}}
+	for _, r := range ranges {
+		st.SetText(":tag", tag)
+		st.SetInt64(":start", r[0])
+		st.SetInt64(":end", r[1])
+		st.SetInt64(":n", int64(len(out)-n))
+		for n < len(out) {
+			ok, err := st.Step()
+			if err != nil {
+				return n, fmt.Errorf("couldn't step sample: %w", err)
+			}
+			if !ok {
+				break
+			}
+			var u userhash.Hash
+			st.ColumnBytes(2, u[:])
+			out[n] = brain.Message{
+				ID:        st.ColumnText(0),
+				Timestamp: st.ColumnInt64(1) / 1e6, // convert ns to ms
+				Sender:    u,
+				Text:      st.ColumnText(3),
+			}
+			n++
+		}
+		if err := st.Reset(); err != nil {
+			return n, fmt.Errorf("resetting sample statement failed: %w", err)
+		}
+		if n == len(out) {
+			break
+		}
+	}
+	return n, nil
+}
+
+//go:embed sample.sql
+var sampleQuery string
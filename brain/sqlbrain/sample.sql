@@ -0,0 +1,26 @@
+WITH m AS (
+	SELECT
+		tag,
+		id,
+		time,
+		user
+	FROM messages
+	WHERE tag = :tag AND time >= :start AND time < :end AND deleted IS NULL
+	ORDER BY time
+	LIMIT :n
+), k AS (
+	SELECT
+		m.id,
+		m.time,
+		m.user,
+		knowledge.suffix
+	FROM m JOIN knowledge ON m.tag = knowledge.tag AND m.id = knowledge.id
+	ORDER BY LENGTH(knowledge.prefix)
+)
+SELECT
+	id,
+	time,
+	user,
+	TRIM(GROUP_CONCAT(suffix, '')) AS msg
+FROM k
+GROUP BY id, time, user
\ No newline at end of file
@@ -0,0 +1,78 @@
+package sqlbrain_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zephyrtronium/robot/brain"
+	"github.com/zephyrtronium/robot/brain/sqlbrain"
+	"github.com/zephyrtronium/robot/userhash"
+)
+
+func TestSample(t *testing.T) {
+	learn := []learn{
+		{tag: "kessoku", user: userhash.Hash{1}, id: "1", t: 1, tups: []brain.Tuple{{Prefix: nil, Suffix: "bocchi"}}},
+		{tag: "kessoku", user: userhash.Hash{2}, id: "2", t: 2, tups: []brain.Tuple{{Prefix: nil, Suffix: "ryo"}}},
+		{tag: "kessoku", user: userhash.Hash{3}, id: "3", t: 3, tups: []brain.Tuple{{Prefix: nil, Suffix: "nijika"}}},
+		{tag: "kessoku", user: userhash.Hash{4}, id: "4", t: 4, tups: []brain.Tuple{{Prefix: nil, Suffix: "kita"}}},
+		{tag: "kessoku", user: userhash.Hash{5}, id: "5", t: 5, tups: []brain.Tuple{{Prefix: nil, Suffix: "seika"}}},
+		{tag: "sickhack", user: userhash.Hash{6}, id: "6", t: 1, tups: []brain.Tuple{{Prefix: nil, Suffix: "kikuri"}}},
+	}
+	ctx := context.Background()
+	db := testDB(ctx)
+	br, err := sqlbrain.Open(ctx, db)
+	if err != nil {
+		t.Fatalf("couldn't open brain: %v", err)
+	}
+	for _, m := range learn {
+		msg := brain.Message{
+			ID:        m.id,
+			Sender:    m.user,
+			Timestamp: m.t,
+		}
+		if err := br.Learn(ctx, m.tag, &msg, m.tups); err != nil {
+			t.Fatalf("failed to learn %v/%v: %v", m.tag, m.id, err)
+		}
+	}
+	if err := br.Forget(ctx, "kessoku", "2"); err != nil {
+		t.Fatalf("failed to forget: %v", err)
+	}
+	want := map[string]string{"1": "bocchi", "3": "nijika", "4": "kita"}
+	seen := make(map[string]bool)
+	out := make([]brain.Message, 2)
+	for range 100 {
+		n, err := br.Sample(ctx, "kessoku", time.UnixMilli(5), out)
+		if err != nil {
+			t.Fatalf("couldn't sample: %v", err)
+		}
+		if n > len(out) {
+			t.Fatalf("sampled too many: %d", n)
+		}
+		for _, m := range out[:n] {
+			if want[m.ID] != m.Text {
+				t.Errorf("wrong message sampled: %+v", m)
+			}
+			seen[m.ID] = true
+		}
+	}
+	for id := range want {
+		if !seen[id] {
+			t.Errorf("never sampled %s", id)
+		}
+	}
+	n, err := br.Sample(ctx, "kessoku", time.UnixMilli(1), out)
+	if err != nil {
+		t.Errorf("couldn't sample before all messages: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("sampled %d messages before all messages", n)
+	}
+	n, err = br.Sample(ctx, "seisoku", time.UnixMilli(5), out)
+	if err != nil {
+		t.Errorf("couldn't sample empty tag: %v", err)
+	}
+	if n != 0 {
+		t.Errorf("sampled %d messages from empty tag", n)
+	}
+}
@@ -12,13 +12,14 @@ CREATE TABLE IF NOT EXISTS knowledge (
 	suffix BLOB NOT NULL,
 	-- Reason for delete, if any.
 	-- Values may include:
-	-- 'FORGET', for tuples deleted by content;
-	-- 'CLEARMSG', for messages deleted by ID;
-	-- 'CLEARCHAT', for messages deleted by userhash;
+	-- 'FORGET', for messages deleted by moderators' forget commands;
+	-- 'FORGETUSER', for messages deleted by moderators by userhash;
+	-- 'CLEARMSG', for single messages deleted by ID;
+	-- 'CLEARCHAT', for messages deleted by clearing chat or banning the sender;
 	-- 'TIME', for messages deleted in a time range;
 	-- or NULL, for tuples which have not been deleted.
-	-- These values are only for analytics; any non-null value indicates the
-	-- tuple should be treated as deleted.
+	-- Any non-null value indicates the tuple should be treated as deleted.
+	-- Only 'FORGET' can be restored; the others are for analytics.
 	deleted TEXT
 ) STRICT;
 
@@ -36,8 +37,7 @@ CREATE TABLE IF NOT EXISTS messages (
 	-- deleted before being fully learned.
 	user BLOB,
 	-- Reason for delete, if any.
-	-- Same meaning as in knowledge, except that the value 'FORGET' will never
-	-- appear (since that is specifically for operating on tuples).
+	-- Same meaning as in knowledge.
 	-- Denormalized here to allow soft deletes of messages before they are
 	-- actually learned.
 	deleted TEXT,
@@ -530,3 +530,42 @@ func BenchmarkSpeak(b *testing.B) {
 	}
 	braintest.BenchSpeak(context.Background(), b, new, cleanup)
 }
+
+func BenchmarkThinkWeighted(b *testing.B) {
+	ctx := context.Background()
+	br, err := sqlbrain.Open(ctx, testDB(ctx))
+	if err != nil {
+		b.Fatal(err)
+	}
+	// Every message continues "bocchi" differently, so choosing the second
+	// term has many options.
+	const n = 10000
+	for i := range n {
+		w := fmt.Sprintf("w%d ", i)
+		msg := brain.Message{ID: fmt.Sprint(i), Timestamp: int64(i)}
+		tups := []brain.Tuple{
+			{Prefix: []string{w, "bocchi "}, Suffix: ""},
+			{Prefix: []string{"bocchi "}, Suffix: w},
+			{Prefix: nil, Suffix: "bocchi "},
+		}
+		if err := br.Learn(ctx, "kessoku", &msg, tups); err != nil {
+			b.Fatal(err)
+		}
+	}
+	cases := []struct {
+		name string
+		w    brain.Weights
+	}{
+		{"uniform", nil},
+		{"weighted", brain.Weights{"1": 16}},
+	}
+	for _, c := range cases {
+		b.Run(c.name, func(b *testing.B) {
+			for b.Loop() {
+				if _, _, err := brain.ThinkWeighted(ctx, br, "kessoku", "", c.w); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
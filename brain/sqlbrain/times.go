@@ -0,0 +1,39 @@
+package sqlbrain
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Dates reports the send times of messages by ID. Messages which are
+// forgotten or which have no recorded time are omitted.
+func (br *Brain) Dates(ctx context.Context, tag string, ids ...string) ([]time.Time, error) {
+	conn, err := br.db.Take(ctx)
+	defer br.db.Put(conn)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get connection to date messages: %w", err)
+	}
+	const sel = `SELECT time FROM messages WHERE tag=:tag AND id=:id AND time IS NOT NULL AND deleted IS NULL`
+	st, err := conn.Prepare(sel)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't prepare statement to date messages: %w", err)
+	}
+	var r []time.Time
+	for _, id := range ids {
+		st.SetText(":tag", tag)
+		st.SetText(":id", id)
+		ok, err := st.Step()
+		if err != nil {
+			st.Reset()
+			return r, fmt.Errorf("couldn't date message %v: %w", id, err)
+		}
+		if ok {
+			r = append(r, time.Unix(0, st.ColumnInt64(0)))
+		}
+		if err := st.Reset(); err != nil {
+			return r, fmt.Errorf("couldn't reset statement to date messages: %w", err)
+		}
+	}
+	return r, nil
+}
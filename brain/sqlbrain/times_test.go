@@ -0,0 +1,48 @@
+package sqlbrain_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/zephyrtronium/robot/brain"
+	"github.com/zephyrtronium/robot/brain/sqlbrain"
+	"github.com/zephyrtronium/robot/userhash"
+)
+
+func TestDates(t *testing.T) {
+	ctx := context.Background()
+	db := testDB(ctx)
+	br, err := sqlbrain.Open(ctx, db)
+	if err != nil {
+		t.Fatalf("couldn't open brain: %v", err)
+	}
+	for _, m := range []struct {
+		tag string
+		id  string
+		t   int64
+	}{
+		{"kessoku", "1", 1000},
+		{"kessoku", "2", 2000},
+		{"kessoku", "3", 3000},
+		{"sickhack", "4", 4000},
+	} {
+		msg := brain.Message{ID: m.id, Sender: userhash.Hash{1}, Timestamp: m.t}
+		tups := []brain.Tuple{{Prefix: []string{"bocchi"}, Suffix: ""}, {Prefix: nil, Suffix: "bocchi"}}
+		if err := br.Learn(ctx, m.tag, &msg, tups); err != nil {
+			t.Fatalf("failed to learn %v/%v: %v", m.tag, m.id, err)
+		}
+	}
+	if err := br.Forget(ctx, "kessoku", "2"); err != nil {
+		t.Fatalf("failed to forget: %v", err)
+	}
+	got, err := br.Dates(ctx, "kessoku", "1", "2", "3", "4", "5")
+	if err != nil {
+		t.Errorf("couldn't date messages: %v", err)
+	}
+	want := []time.Time{time.UnixMilli(1000), time.UnixMilli(3000)}
+	if !slices.EqualFunc(got, want, time.Time.Equal) {
+		t.Errorf("wrong times: want %v, got %v", want, got)
+	}
+}
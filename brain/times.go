@@ -0,0 +1,28 @@
+package brain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Dater is a brain which can report when messages were sent.
+// Brains which don't record message times need not implement it.
+type Dater interface {
+	// Dates reports the send times of messages by ID, in no particular order.
+	// Messages whose times are unknown, including forgotten messages, are
+	// omitted.
+	Dates(ctx context.Context, tag string, ids ...string) ([]time.Time, error)
+}
+
+// Dates reports the send times of the messages with the given IDs that the
+// brain still knows, in no particular order.
+// If br does not implement [Dater], the error is [errors.ErrUnsupported].
+func Dates(ctx context.Context, br Interface, tag string, ids ...string) ([]time.Time, error) {
+	d, ok := br.(Dater)
+	if !ok {
+		return nil, fmt.Errorf("brain can't date messages: %w", errors.ErrUnsupported)
+	}
+	return d.Dates(ctx, tag, ids...)
+}
@@ -0,0 +1,42 @@
+package brain_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/zephyrtronium/robot/brain"
+)
+
+type dater struct {
+	brain.Interface
+	times map[string]time.Time
+}
+
+func (d *dater) Dates(ctx context.Context, tag string, ids ...string) ([]time.Time, error) {
+	var r []time.Time
+	for _, id := range ids {
+		if t, ok := d.times[id]; ok {
+			r = append(r, t)
+		}
+	}
+	return r, nil
+}
+
+func TestDates(t *testing.T) {
+	br := &dater{times: map[string]time.Time{"1": time.Unix(1, 0), "3": time.Unix(3, 0)}}
+	got, err := brain.Dates(context.Background(), br, "kessoku", "1", "2", "3")
+	if err != nil {
+		t.Errorf("couldn't date: %v", err)
+	}
+	want := []time.Time{time.Unix(1, 0), time.Unix(3, 0)}
+	if !slices.Equal(got, want) {
+		t.Errorf("wrong times: want %v, got %v", want, got)
+	}
+	_, err = brain.Dates(context.Background(), nil, "kessoku", "1")
+	if !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("wrong error: want %v, got %v", errors.ErrUnsupported, err)
+	}
+}
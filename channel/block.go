@@ -0,0 +1,89 @@
+package channel
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+// Blocker matches messages which should not be used for learning or
+// copypasta. It combines a static expression from configuration with a list
+// of terms that may change at runtime.
+type Blocker struct {
+	// re is the static expression.
+	re *regexp.Regexp
+	// terms is the list of lowercased blocked terms.
+	terms atomic.Pointer[[]string]
+}
+
+// NewBlocker creates a Blocker with a static expression and no terms.
+func NewBlocker(re *regexp.Regexp) *Blocker {
+	return &Blocker{re: re}
+}
+
+// MatchString reports whether s matches the static expression or contains
+// any blocked term, ignoring case. Both s and its [Normalize]d form are
+// checked, so that disguised text can't evade blocking.
+func (b *Blocker) MatchString(s string) bool {
+	if b.match(s) {
+		return true
+	}
+	n := Normalize(s)
+	return n != s && b.match(n)
+}
+
+func (b *Blocker) match(s string) bool {
+	if b.re.MatchString(s) {
+		return true
+	}
+	p := b.terms.Load()
+	if p == nil || len(*p) == 0 {
+		return false
+	}
+	s = strings.ToLower(s)
+	for _, t := range *p {
+		if strings.Contains(s, t) {
+			return true
+		}
+	}
+	return false
+}
+
+// ContainsTerm reports whether s contains a term in the way that a [Blocker]
+// matches its terms: ignoring case, in either s or its [Normalize]d form.
+// The term must already be lowercase.
+func ContainsTerm(s, term string) bool {
+	if strings.Contains(strings.ToLower(s), term) {
+		return true
+	}
+	n := Normalize(s)
+	return n != s && strings.Contains(strings.ToLower(n), term)
+}
+
+// SetTerms replaces the list of blocked terms.
+// It is safe to call concurrently with MatchString.
+func (b *Blocker) SetTerms(terms []string) {
+	l := make([]string, 0, len(terms))
+	for _, t := range terms {
+		t = strings.ToLower(t)
+		if t != "" {
+			l = append(l, t)
+		}
+	}
+	b.terms.Store(&l)
+}
+
+// Terms returns the list of blocked terms.
+func (b *Blocker) Terms() []string {
+	p := b.terms.Load()
+	if p == nil {
+		return nil
+	}
+	return slices.Clone(*p)
+}
+
+// String returns the source of the static expression.
+func (b *Blocker) String() string {
+	return b.re.String()
+}
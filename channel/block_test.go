@@ -0,0 +1,64 @@
+package channel_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/zephyrtronium/robot/channel"
+)
+
+func TestBlocker(t *testing.T) {
+	cases := []struct {
+		name  string
+		re    string
+		terms []string
+		msg   string
+		want  bool
+	}{
+		{"none", "$^", nil, "bocchi the rock", false},
+		{"re", "rock", nil, "bocchi the rock", true},
+		{"term", "$^", []string{"bocchi"}, "bocchi the rock", true},
+		{"term-case", "$^", []string{"BOCCHI"}, "Bocchi the Rock", true},
+		{"term-inside", "$^", []string{"occ"}, "bocchi the rock", true},
+		{"term-miss", "$^", []string{"kita"}, "bocchi the rock", false},
+		{"empty-term", "$^", []string{""}, "bocchi the rock", false},
+		{"both", "ryo", []string{"kita"}, "nijika", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			b := channel.NewBlocker(regexp.MustCompile(c.re))
+			b.SetTerms(c.terms)
+			if got := b.MatchString(c.msg); got != c.want {
+				t.Errorf("wrong match for %q: want %t, got %t", c.msg, c.want, got)
+			}
+		})
+	}
+}
+
+func TestContainsTerm(t *testing.T) {
+	cases := []struct {
+		name string
+		term string
+		msg  string
+		want bool
+	}{
+		{"plain", "bocchi", "bocchi the rock", true},
+		{"case", "bocchi", "BOCCHI the rock", true},
+		{"miss", "kita", "bocchi the rock", false},
+		{"fullwidth", "bocchi", "ｂｏｃｃｈｉ the rock", true},
+		{"invisible", "bocchi", "boc\u200bchi the rock", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := channel.ContainsTerm(c.msg, c.term); got != c.want {
+				t.Errorf("wrong match for %q: want %t, got %t", c.msg, c.want, got)
+			}
+			// Blocking and forgetting must agree on what contains a term.
+			b := channel.NewBlocker(regexp.MustCompile("$^"))
+			b.SetTerms([]string{c.term})
+			if got := b.MatchString(c.msg); got != c.want {
+				t.Errorf("blocker disagrees for %q: want %t, got %t", c.msg, c.want, got)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package channel
 import (
 	"context"
 	"errors"
+	"math"
 	"regexp"
 	"strconv"
 	"sync"
@@ -13,8 +14,14 @@ import (
 	"golang.org/x/time/rate"
 
 	"github.com/zephyrtronium/robot/message"
+	"github.com/zephyrtronium/robot/pet"
 )
 
+// Channel is a channel's configuration and state.
+//
+// The configuration fields of a channel may be replaced by creating a new
+// Channel that takes over the state fields, History, Silent, Cooldowns, Extra,
+// and Enabled, of the old one. Those are pointers so that they can be shared.
 type Channel struct {
 	// Name is the name of the channel.
 	Name string
@@ -22,18 +29,28 @@ type Channel struct {
 	Message func(ctx context.Context, msg message.Sent)
 	// Learn and Send are the channel tags.
 	Learn, Send string
+	// Lang is the language of responses in the channel as a BCP 47 tag.
+	Lang string
+	// Pet is the pet the bot takes care of in the channel.
+	// It may be shared with other channels.
+	Pet *pet.Status
 	// Links, BotCommands, and OneWord control handling of messages that contain
 	// apparent links, commands for other bots, and other messages not containing
 	// whitespace, respectively.
 	Links, BotCommands, OneWord BlockOption
-	// Block is a regex that matches messages which should not be used for
-	// learning or copypasta.
-	Block *regexp.Regexp
+	// Caps, EmoteOnly, Long, Repeated, and Mentions control handling of
+	// messages that are shouted in capitals, contain only emotes, are very
+	// long, repeat a character many times, and mention other users,
+	// respectively.
+	Caps, EmoteOnly, Long, Repeated, Mentions BlockOption
+	// Block matches messages which should not be used for learning or
+	// copypasta.
+	Block *Blocker
 	// Meme is a regex that matches messages which bypass Block only for copypasta.
 	Meme *regexp.Regexp
-	// Responses is the probability that a received message will trigger a
-	// random response.
-	Responses float64
+	// responses is the probability that a received message will trigger a
+	// random response, stored as float64 bits.
+	responses atomic.Uint64
 	// Rate is the rate limiter for messages. Attempts to speak in excess of
 	// the rate limit are dropped.
 	Rate *rate.Limiter
@@ -43,26 +60,47 @@ type Channel struct {
 	// History is a list of recent messages seen in the channel.
 	// Note that messages which are forgotten due to moderation are not removed
 	// from this list in general.
-	History History[*message.Received[message.User]]
+	History *History[*message.Received[message.User]]
 	// Memery is the meme detector for the channel.
 	Memery *MemeDetector
+	// Quota limits how many messages are learned from each user.
+	Quota *Quota
 	// Emotes is the distribution of emotes.
 	Emotes *pick.Dist[string]
 	// Effects is the distribution of effects.
 	Effects *pick.Dist[string]
 	// Silent is the earliest time that speaking and learning is allowed in the
 	// channel as nanoseconds from the Unix epoch.
-	Silent atomic.Int64
+	Silent *atomic.Int64
+	// Quiet is the channel's recurring quiet hours, or nil if it has none.
+	Quiet *QuietHours
+	// Commands is the list of commands defined in the channel's
+	// configuration.
+	Commands []*Command
+	// Cooldowns tracks cooldowns on built-in commands.
+	Cooldowns *Cooldowns
 	// Extra is extra channel data that may be added by commands.
-	Extra sync.Map // map[any]any; key is a type
+	Extra *sync.Map // map[any]any; key is a type
 	// Enabled indicates whether a channel is allowed to learn messages.
-	Enabled atomic.Bool
+	Enabled *atomic.Bool
 }
 
 func (ch *Channel) SilentTime() time.Time {
 	return time.Unix(0, ch.Silent.Load())
 }
 
+// Responses returns the probability that a received message will trigger a
+// random response.
+func (ch *Channel) Responses() float64 {
+	return math.Float64frombits(ch.responses.Load())
+}
+
+// SetResponses sets the probability that a received message will trigger a
+// random response. It is safe to call concurrently with Responses.
+func (ch *Channel) SetResponses(p float64) {
+	ch.responses.Store(math.Float64bits(p))
+}
+
 // UserPerms is the permissions for a user granted by static configuration.
 type UserPerms struct {
 	// DisableCommands means the user has access to no commands.
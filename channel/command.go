@@ -0,0 +1,43 @@
+package channel
+
+import (
+	"regexp"
+	"sync/atomic"
+	"text/template"
+	"time"
+)
+
+// Command is a command defined in a channel's configuration.
+type Command struct {
+	// Name is the name of the command.
+	Name string
+	// Parse matches invocations of the command. Its named captures are
+	// available to Response.
+	Parse *regexp.Regexp
+	// Level is the access level needed to use the command: "any",
+	// "moderator", or "owner".
+	Level string
+	// Cooldown is the minimum time between uses of the command.
+	Cooldown time.Duration
+	// Response is the template for the command's response.
+	Response *template.Template
+	// Help is a short description of the command for the help command.
+	Help string
+	// last is the time the command was last used as nanoseconds from the
+	// Unix epoch.
+	last atomic.Int64
+}
+
+// Use reports whether the command is off cooldown at t. If it is, Use starts
+// a new cooldown. It is safe to call concurrently.
+func (c *Command) Use(t time.Time) bool {
+	for {
+		l := c.last.Load()
+		if l != 0 && t.Sub(time.Unix(0, l)) < c.Cooldown {
+			return false
+		}
+		if c.last.CompareAndSwap(l, t.UnixNano()) {
+			return true
+		}
+	}
+}
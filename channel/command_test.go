@@ -0,0 +1,49 @@
+package channel_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zephyrtronium/robot/channel"
+)
+
+func TestCommandUse(t *testing.T) {
+	type use struct {
+		when time.Duration
+		want bool
+	}
+	cases := []struct {
+		name     string
+		cooldown time.Duration
+		uses     []use
+	}{
+		{
+			name:     "none",
+			cooldown: 0,
+			uses:     []use{{0, true}, {0, true}, {time.Second, true}},
+		},
+		{
+			name:     "cooldown",
+			cooldown: time.Minute,
+			uses: []use{
+				{0, true},
+				{time.Second, false},
+				{59 * time.Second, false},
+				{time.Minute, true},
+				{90 * time.Second, false},
+				{2 * time.Minute, true},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cmd := &channel.Command{Name: "bocchi", Cooldown: c.cooldown}
+			start := time.Unix(1717286400, 0)
+			for i, u := range c.uses {
+				if got := cmd.Use(start.Add(u.when)); got != u.want {
+					t.Errorf("use %d at %v: want %t, got %t", i, u.when, u.want, got)
+				}
+			}
+		})
+	}
+}
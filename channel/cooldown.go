@@ -0,0 +1,92 @@
+package channel
+
+import (
+	"sync"
+	"time"
+)
+
+// Cooldowns tracks cooldowns on commands in a channel, both for individual
+// users and for the channel as a whole. The zero value is ready to use.
+// Expired cooldowns are swept periodically, so the state holds only users who
+// have used commands within about the longest cooldown.
+type Cooldowns struct {
+	mu sync.Mutex
+	// cool maps commands and users to their cooldowns.
+	// The user is empty for channel-wide cooldowns.
+	cool map[cooldownKey]*cooldown
+	// sweep is the time after which to next remove expired cooldowns.
+	sweep time.Time
+}
+
+type cooldownKey struct {
+	cmd, user string
+}
+
+type cooldown struct {
+	// until is the time at which the cooldown ends.
+	until time.Time
+	// noticed indicates whether a use during the cooldown has been told so.
+	noticed bool
+}
+
+// sweepInterval is the minimum time between sweeps of expired cooldowns.
+const sweepInterval = time.Minute
+
+// Use attempts to use a command as a user at now. If the command is on
+// cooldown for either the user or the channel, Use returns the time remaining
+// on the longer cooldown, and notice is true if this is the first attempt
+// rejected during that cooldown for the user. Otherwise, Use starts the
+// cooldowns and returns zero. A zero duration means no cooldown of that kind.
+// It is safe to call concurrently.
+func (c *Cooldowns) Use(cmd, user string, now time.Time, perUser, perChannel time.Duration) (wait time.Duration, notice bool) {
+	if c == nil || perUser <= 0 && perChannel <= 0 {
+		return 0, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cool == nil {
+		c.cool = make(map[cooldownKey]*cooldown)
+	}
+	if now.After(c.sweep) {
+		for k, v := range c.cool {
+			if !now.Before(v.until) {
+				delete(c.cool, k)
+			}
+		}
+		c.sweep = now.Add(sweepInterval)
+	}
+	uk := cooldownKey{cmd: cmd, user: user}
+	ck := cooldownKey{cmd: cmd}
+	u, ch := c.cool[uk], c.cool[ck]
+	if u != nil && now.Before(u.until) {
+		wait = u.until.Sub(now)
+	}
+	if ch != nil && now.Before(ch.until) {
+		wait = max(wait, ch.until.Sub(now))
+	}
+	if wait > 0 {
+		// Track notices per user even when only the channel cooldown applies,
+		// so that each user hears about it at most once.
+		if u == nil || !now.Before(u.until) {
+			u = &cooldown{until: now.Add(wait)}
+			c.cool[uk] = u
+		}
+		notice = !u.noticed
+		u.noticed = true
+		return wait, notice
+	}
+	if perUser > 0 {
+		c.cool[uk] = &cooldown{until: now.Add(perUser)}
+	}
+	if perChannel > 0 {
+		c.cool[ck] = &cooldown{until: now.Add(perChannel)}
+	}
+	return 0, false
+}
+
+// Len returns the number of cooldowns tracked.
+func (c *Cooldowns) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.cool)
+}
@@ -0,0 +1,101 @@
+package channel_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zephyrtronium/robot/channel"
+)
+
+func TestCooldownsUse(t *testing.T) {
+	type use struct {
+		when   time.Duration
+		user   string
+		wait   time.Duration
+		notice bool
+	}
+	cases := []struct {
+		name       string
+		perUser    time.Duration
+		perChannel time.Duration
+		uses       []use
+	}{
+		{
+			name: "none",
+			uses: []use{{0, "bocchi", 0, false}, {0, "bocchi", 0, false}},
+		},
+		{
+			name:    "user",
+			perUser: time.Minute,
+			uses: []use{
+				{0, "bocchi", 0, false},
+				{0, "kita", 0, false},
+				{10 * time.Second, "bocchi", 50 * time.Second, true},
+				{20 * time.Second, "bocchi", 40 * time.Second, false},
+				{time.Minute, "bocchi", 0, false},
+				{70 * time.Second, "bocchi", 50 * time.Second, true},
+			},
+		},
+		{
+			name:       "channel",
+			perChannel: 30 * time.Second,
+			uses: []use{
+				{0, "bocchi", 0, false},
+				{10 * time.Second, "kita", 20 * time.Second, true},
+				{15 * time.Second, "ryo", 15 * time.Second, true},
+				{20 * time.Second, "kita", 10 * time.Second, false},
+				{30 * time.Second, "kita", 0, false},
+			},
+		},
+		{
+			name:       "both",
+			perUser:    time.Minute,
+			perChannel: 10 * time.Second,
+			uses: []use{
+				{0, "bocchi", 0, false},
+				{5 * time.Second, "kita", 5 * time.Second, true},
+				{10 * time.Second, "kita", 0, false},
+				{30 * time.Second, "bocchi", 30 * time.Second, true},
+				{30 * time.Second, "ryo", 0, false},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var cd channel.Cooldowns
+			start := time.Unix(1717286400, 0)
+			for i, u := range c.uses {
+				wait, notice := cd.Use("marry", u.user, start.Add(u.when), c.perUser, c.perChannel)
+				if wait != u.wait || notice != u.notice {
+					t.Errorf("use %d by %s at %v: want %v/%t, got %v/%t", i, u.user, u.when, u.wait, u.notice, wait, notice)
+				}
+			}
+		})
+	}
+}
+
+func TestCooldownsSweep(t *testing.T) {
+	var cd channel.Cooldowns
+	start := time.Unix(1717286400, 0)
+	for i := range 100 {
+		cd.Use("marry", string(rune('a'+i%26))+string(rune('a'+i/26)), start, time.Second, 0)
+	}
+	if got := cd.Len(); got != 100 {
+		t.Errorf("wrong count before sweep: want 100, got %d", got)
+	}
+	cd.Use("marry", "bocchi", start.Add(2*time.Minute), time.Second, 0)
+	if got := cd.Len(); got != 1 {
+		t.Errorf("wrong count after sweep: want 1, got %d", got)
+	}
+}
+
+func TestCooldownsSeparate(t *testing.T) {
+	var cd channel.Cooldowns
+	now := time.Unix(1717286400, 0)
+	if wait, _ := cd.Use("marry", "bocchi", now, time.Minute, time.Minute); wait != 0 {
+		t.Errorf("first use waits %v", wait)
+	}
+	if wait, _ := cd.Use("eat", "bocchi", now, time.Minute, time.Minute); wait != 0 {
+		t.Errorf("other command waits %v", wait)
+	}
+}
@@ -1,10 +1,14 @@
 package channel
 
 import (
+	"cmp"
 	_ "embed"
 	"errors"
+	"slices"
+	"strings"
 	"sync"
 	"time"
+	"unicode"
 )
 
 // MemeDetector is literally a meme detector.
@@ -23,6 +27,23 @@ type MemeDetector struct {
 	need int
 	// within is the duration to hold messages.
 	within time.Duration
+	// similarity is the minimum Jaccard similarity of the word sets of two
+	// messages for them to count as the same meme.
+	// If it is zero, messages must match exactly.
+	similarity float64
+	// clusters holds the words and original texts of fuzzy memes.
+	// Its keys are the keys used in counts.
+	clusters map[string]*cluster
+}
+
+// cluster is a group of similar messages in fuzzy meme detection.
+type cluster struct {
+	// words is the normalized words of the message which started the
+	// cluster, sorted and deduplicated.
+	words []string
+	// texts counts the original texts of messages in the cluster which are
+	// still in history.
+	texts map[string]int
 }
 
 // node is a node in a doubly linked list of messages sorted by time.
@@ -32,6 +53,9 @@ type node struct {
 	msg  string
 	user string
 	exp  int64
+	// text is the original text of the message in fuzzy detection, or empty
+	// if the node isn't counted in a cluster.
+	text string
 }
 
 // NewMemeDetector creates.
@@ -43,11 +67,32 @@ func NewMemeDetector(need int, within time.Duration) *MemeDetector {
 	}
 }
 
+// NewFuzzyMemeDetector creates a meme detector which treats messages as the
+// same meme when the Jaccard similarity of their normalized word sets is at
+// least similarity. Normalization ignores case, @mentions, spacing, and
+// punctuation around words. A similarity of zero or less is equivalent to
+// [NewMemeDetector].
+func NewFuzzyMemeDetector(need int, within time.Duration, similarity float64) *MemeDetector {
+	m := NewMemeDetector(need, within)
+	if similarity > 0 {
+		m.similarity = min(similarity, 1)
+		m.clusters = make(map[string]*cluster)
+	}
+	return m
+}
+
 func (m *MemeDetector) chopLocked(now int64) {
 	b := m.back
 	// For each expired node at the back:
 	for b != nil && b.exp <= now {
 		// We will drop this node.
+		// Its text no longer counts toward its cluster's representative.
+		if c := m.clusters[b.msg]; c != nil && b.text != "" {
+			c.texts[b.text]--
+			if c.texts[b.text] <= 0 {
+				delete(c.texts, b.text)
+			}
+		}
 		// If the most recent expiry time from this user isn't newer than b,
 		// we also need to stop tracking it in the map.
 		if m.counts[b.msg][b.user] <= b.exp {
@@ -57,6 +102,7 @@ func (m *MemeDetector) chopLocked(now int64) {
 				// stop tracking the message as well to control memory usage.
 				if len(m.counts[b.msg]) == 0 {
 					delete(m.counts, b.msg)
+					delete(m.clusters, b.msg)
 				}
 			}
 		}
@@ -71,7 +117,9 @@ func (m *MemeDetector) chopLocked(now int64) {
 	}
 }
 
-func (m *MemeDetector) insertLocked(msg, user string, exp int64) {
+// insertLocked adds a message to history. If c is not nil, the message's
+// text counts toward the cluster while it remains in history.
+func (m *MemeDetector) insertLocked(msg, text, user string, exp int64, c *cluster) {
 	if exp <= m.counts[msg][user] {
 		// This message is (somehow) older than another from the same user.
 		// We don't care about it.
@@ -82,6 +130,10 @@ func (m *MemeDetector) insertLocked(msg, user string, exp int64) {
 		user: user,
 		exp:  exp,
 	}
+	if c != nil {
+		new.text = text
+		c.texts[text]++
+	}
 	if m.counts[msg] == nil {
 		m.counts[msg] = make(map[string]int64)
 	}
@@ -110,34 +162,86 @@ func (m *MemeDetector) insertLocked(msg, user string, exp int64) {
 	l.older, m.back = new, new
 }
 
+// keyLocked finds the key in counts for a message.
+// In fuzzy detection, it finds the most similar cluster, creating one if
+// there is none and create is true. If there is no cluster and create is
+// false, the returned key is empty.
+func (m *MemeDetector) keyLocked(msg string, create bool) (string, *cluster) {
+	if m.similarity <= 0 {
+		return msg, nil
+	}
+	words := memeWords(msg)
+	if len(words) == 0 {
+		// Nothing to compare. Only exact matches count.
+		return msg, nil
+	}
+	var (
+		key  string
+		best *cluster
+		sim  float64
+	)
+	for k, c := range m.clusters {
+		// The similarity can't be more than the ratio of the sizes,
+		// so skip the full comparison for sets that are too different.
+		a, b := len(words), len(c.words)
+		if float64(min(a, b)) < m.similarity*float64(max(a, b)) {
+			continue
+		}
+		s := jaccard(words, c.words)
+		if s < m.similarity || s < sim || s == sim && k > key {
+			continue
+		}
+		key, best, sim = k, c, s
+	}
+	if best != nil || !create {
+		return key, best
+	}
+	key = strings.Join(words, " ")
+	best = &cluster{words: words, texts: make(map[string]int)}
+	m.clusters[key] = best
+	return key, best
+}
+
 // Check determines whether a message is a meme. If it is not, the returned
 // error is NotCopypasta. Times passed to Check should be monotonic, as
 // messages outside the detector's threshold are removed.
 func (m *MemeDetector) Check(t time.Time, from, msg string) error {
+	_, err := m.Detect(t, from, msg)
+	return err
+}
+
+// Detect is like Check, but it also returns the text to copypasta when the
+// message is a meme. For exact detection, that is always msg. For fuzzy
+// detection, it is the most common text among the similar messages.
+func (m *MemeDetector) Detect(t time.Time, from, msg string) (string, error) {
 	now := t.UnixMilli()
 	m.mu.Lock()
 	defer m.mu.Unlock()
 	if m.need <= 0 {
 		// This channel is memeless.
-		return ErrNotCopypasta
+		return "", ErrNotCopypasta
 	}
 	// Remove old messages and discard old memes.
 	m.chopLocked(now)
 	// Insert the new message.
-	m.insertLocked(msg, from, now+m.within.Milliseconds())
+	key, c := m.keyLocked(msg, true)
+	m.insertLocked(key, msg, from, now+m.within.Milliseconds(), c)
 	// Get the meme metric: number of distinct users who sent this message in
 	// the time window.
-	n := len(m.counts[msg])
+	n := len(m.counts[key])
 	if n < m.need {
-		return ErrNotCopypasta
+		return "", ErrNotCopypasta
 	}
 	// Genuine meme. But is it fresh?
-	if _, ok := m.counts[msg][""]; ok {
-		return ErrNotCopypasta
+	if _, ok := m.counts[key][""]; ok {
+		return "", ErrNotCopypasta
 	}
 	// It is, but not for the following fifteen minutes.
-	m.insertLocked(msg, "", now+15*60*1000)
-	return nil
+	m.insertLocked(key, "", "", now+15*60*1000, nil)
+	if c != nil {
+		return c.representative(), nil
+	}
+	return msg, nil
 }
 
 // Block adds a message as a meme directly, preventing its reuse
@@ -146,16 +250,74 @@ func (m *MemeDetector) Block(t time.Time, msg string) {
 	now := t.UnixMilli()
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.insertLocked(msg, "", now+15*60*1000)
+	key, _ := m.keyLocked(msg, true)
+	m.insertLocked(key, "", "", now+15*60*1000, nil)
 }
 
 // Unblock removes a message as a meme, allowing its reuse immediately.
 func (m *MemeDetector) Unblock(msg string) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	if m.counts[msg] != nil {
-		delete(m.counts[msg], "")
+	key, _ := m.keyLocked(msg, false)
+	if m.counts[key] != nil {
+		delete(m.counts[key], "")
+	}
+}
+
+// representative chooses the text to copypasta for a cluster.
+// It is the most common text among messages still in history, preferring
+// shorter ones on ties.
+func (c *cluster) representative() string {
+	var r string
+	n := 0
+	for s, k := range c.texts {
+		switch {
+		case k > n:
+		case k < n:
+			continue
+		case len(s) > len(r), len(s) == len(r) && s > r:
+			continue
+		}
+		r, n = s, k
+	}
+	return r
+}
+
+// memeWords normalizes a message into its set of words for fuzzy meme
+// detection. The result is sorted and has no duplicates.
+func memeWords(msg string) []string {
+	f := strings.Fields(strings.ToLower(msg))
+	w := f[:0]
+	for _, s := range f {
+		if strings.HasPrefix(s, "@") {
+			continue
+		}
+		s = strings.TrimFunc(s, unicode.IsPunct)
+		if s == "" {
+			continue
+		}
+		w = append(w, s)
+	}
+	slices.Sort(w)
+	return slices.Compact(w)
+}
+
+// jaccard computes the Jaccard similarity of two sorted sets.
+func jaccard(a, b []string) float64 {
+	n := 0
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch cmp.Compare(a[i], b[j]) {
+		case -1:
+			i++
+		case 1:
+			j++
+		default:
+			n++
+			i++
+			j++
+		}
 	}
+	return float64(n) / float64(len(a)+len(b)-n)
 }
 
 // ErrNotCopypasta is a sentinel error returned by MemeDetector.Check when a
@@ -1,6 +1,9 @@
 package channel_test
 
 import (
+	"fmt"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -141,3 +144,195 @@ func TestUnblock(t *testing.T) {
 		t.Errorf("wrong error on unblocked check: want %v, got %v", nil, err)
 	}
 }
+
+func TestFuzzyMemeDetector(t *testing.T) {
+	type meme struct {
+		when int64
+		who  string
+		text string
+		want string
+		err  error
+	}
+	cases := []struct {
+		name  string
+		need  int
+		sim   float64
+		memes []meme
+	}{
+		{
+			name: "exact",
+			need: 2,
+			sim:  0.75,
+			memes: []meme{
+				{0, "bocchi", "guitar hero of the year", "", channel.ErrNotCopypasta},
+				{1, "ryo", "guitar hero of the year", "guitar hero of the year", nil},
+			},
+		},
+		{
+			name: "spacing-case",
+			need: 2,
+			sim:  0.75,
+			memes: []meme{
+				{0, "bocchi", "guitar hero of the year", "", channel.ErrNotCopypasta},
+				{1, "ryo", "Guitar  HERO of the year!", "guitar hero of the year", nil},
+			},
+		},
+		{
+			name: "mention",
+			need: 2,
+			sim:  0.75,
+			memes: []meme{
+				{0, "bocchi", "@nijika guitar hero of the year", "", channel.ErrNotCopypasta},
+				{1, "ryo", "guitar hero of the year @kita", "guitar hero of the year @kita", nil},
+			},
+		},
+		{
+			name: "trailing-emote",
+			need: 3,
+			sim:  0.75,
+			memes: []meme{
+				{0, "bocchi", "guitar hero of the year", "", channel.ErrNotCopypasta},
+				{1, "ryo", "guitar hero of the year Kappa", "", channel.ErrNotCopypasta},
+				{2, "nijika", "guitar hero of the year", "guitar hero of the year", nil},
+			},
+		},
+		{
+			name: "changed-word",
+			need: 2,
+			sim:  0.6,
+			memes: []meme{
+				{0, "bocchi", "guitar hero of the year", "", channel.ErrNotCopypasta},
+				{1, "ryo", "bass hero of the year", "bass hero of the year", nil},
+			},
+		},
+		{
+			name: "different",
+			need: 2,
+			sim:  0.75,
+			memes: []meme{
+				{0, "bocchi", "guitar hero of the year", "", channel.ErrNotCopypasta},
+				{1, "ryo", "curry for dinner again", "", channel.ErrNotCopypasta},
+			},
+		},
+		{
+			name: "who",
+			need: 2,
+			sim:  0.75,
+			memes: []meme{
+				{0, "bocchi", "guitar hero of the year", "", channel.ErrNotCopypasta},
+				{1, "bocchi", "guitar hero of the year!!", "", channel.ErrNotCopypasta},
+				{2, "ryo", "guitar hero of the year", "guitar hero of the year", nil},
+			},
+		},
+		{
+			name: "once",
+			need: 2,
+			sim:  0.75,
+			memes: []meme{
+				{0, "bocchi", "guitar hero of the year", "", channel.ErrNotCopypasta},
+				{1, "ryo", "guitar hero of the year", "guitar hero of the year", nil},
+				{2, "nijika", "GUITAR HERO OF THE YEAR", "", channel.ErrNotCopypasta},
+			},
+		},
+		{
+			name: "time",
+			need: 2,
+			sim:  0.75,
+			memes: []meme{
+				{0, "bocchi", "guitar hero of the year", "", channel.ErrNotCopypasta},
+				{20, "ryo", "guitar hero of the year", "", channel.ErrNotCopypasta},
+			},
+		},
+		{
+			name: "expired-text",
+			need: 2,
+			sim:  0.75,
+			memes: []meme{
+				{0, "bocchi", "GUITAR HERO OF THE YEAR", "", channel.ErrNotCopypasta},
+				{1, "bocchi", "GUITAR HERO OF THE YEAR", "", channel.ErrNotCopypasta},
+				{2, "bocchi", "GUITAR HERO OF THE YEAR", "", channel.ErrNotCopypasta},
+				{10, "bocchi", "guitar hero of the year", "", channel.ErrNotCopypasta},
+				{17, "ryo", "guitar hero of the year", "guitar hero of the year", nil},
+			},
+		},
+		{
+			name: "punctuation-only",
+			need: 2,
+			sim:  0.75,
+			memes: []meme{
+				{0, "bocchi", ":)", "", channel.ErrNotCopypasta},
+				{1, "ryo", ":(", "", channel.ErrNotCopypasta},
+				{2, "nijika", ":)", ":)", nil},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d := channel.NewFuzzyMemeDetector(c.need, 15*time.Millisecond, c.sim)
+			for _, m := range c.memes {
+				got, err := d.Detect(time.UnixMilli(m.when), m.who, m.text)
+				if err != m.err {
+					t.Errorf("wrong error for %+v: want %v, got %v", m, m.err, err)
+				}
+				if got != m.want {
+					t.Errorf("wrong text for %+v: want %q, got %q", m, m.want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestFuzzyBlock(t *testing.T) {
+	d := channel.NewFuzzyMemeDetector(2, time.Minute, 0.75)
+	d.Block(time.UnixMilli(0), "guitar hero of the year")
+	if err := d.Check(time.UnixMilli(1), "bocchi", "Guitar hero of the year!"); err != channel.ErrNotCopypasta {
+		t.Errorf("wrong error on first check: want %v, got %v", channel.ErrNotCopypasta, err)
+	}
+	if err := d.Check(time.UnixMilli(2), "ryo", "guitar hero of the year"); err != channel.ErrNotCopypasta {
+		t.Errorf("wrong error on blocked check: want %v, got %v", channel.ErrNotCopypasta, err)
+	}
+	d.Unblock("guitar hero of the year @nijika")
+	if err := d.Check(time.UnixMilli(3), "kita", "guitar hero of the year"); err != nil {
+		t.Errorf("wrong error on unblocked check: want %v, got %v", nil, err)
+	}
+}
+
+// chatter generates a busy chat with some copypasta variants mixed into
+// mostly distinct messages.
+func chatter(n int) []string {
+	words := strings.Fields("bocchi ryo nijika kita seika kikuri pa-san guitar bass drums vocals starry live house curry weed band kessoku sick hack")
+	r := make([]string, n)
+	for i := range r {
+		if i%5 == 0 {
+			r[i] = fmt.Sprintf("kessoku band is the best band @viewer%d LUL", i%7)
+			continue
+		}
+		var b strings.Builder
+		for j := range 4 + i%8 {
+			if j > 0 {
+				b.WriteByte(' ')
+			}
+			b.WriteString(words[(i*7+j*j*3)%len(words)])
+		}
+		fmt.Fprintf(&b, " %d", i)
+		r[i] = b.String()
+	}
+	return r
+}
+
+func BenchmarkMemeDetector(b *testing.B) {
+	msgs := chatter(4096)
+	for _, sim := range []float64{0, 0.75} {
+		b.Run(fmt.Sprintf("similarity=%v", sim), func(b *testing.B) {
+			// Thirty seconds of a chat with about a hundred messages per second.
+			d := channel.NewFuzzyMemeDetector(3, 30*time.Second, sim)
+			b.ReportAllocs()
+			i := 0
+			for b.Loop() {
+				t := time.UnixMilli(int64(i) * 10)
+				d.Check(t, strconv.Itoa(i%500), msgs[i%len(msgs)])
+				i++
+			}
+		})
+	}
+}
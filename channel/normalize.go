@@ -0,0 +1,102 @@
+package channel
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxMarks is the most combining marks a single character may carry before
+// [Normalize] treats them as zalgo and removes them all. Real text rarely
+// needs more than two.
+const maxMarks = 2
+
+// Normalize folds text that has been disguised to evade block patterns into
+// what a human would read. It removes invisible characters, limits combining
+// marks, applies compatibility decomposition so that e.g. fullwidth and
+// mathematical letters become plain ones, and replaces common homoglyphs from
+// other scripts with the Latin letters they imitate.
+//
+// The result is meant only for matching. It should never be learned or sent.
+func Normalize(s string) string {
+	if isASCII(s) {
+		return s
+	}
+	s = norm.NFKD.String(s)
+	var b strings.Builder
+	b.Grow(len(s))
+	// Hold the marks on each character until we know how many there are.
+	var marks []rune
+	flush := func() {
+		if len(marks) <= maxMarks {
+			for _, m := range marks {
+				b.WriteRune(m)
+			}
+		}
+		marks = marks[:0]
+	}
+	for _, r := range s {
+		switch {
+		case isInvisible(r):
+			continue
+		case unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r):
+			marks = append(marks, r)
+			continue
+		}
+		flush()
+		if c, ok := confusables[r]; ok {
+			r = c
+		}
+		b.WriteRune(r)
+	}
+	flush()
+	return norm.NFC.String(b.String())
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// isInvisible reports whether r is a character that renders as nothing or
+// as blank space without being whitespace.
+func isInvisible(r rune) bool {
+	switch r {
+	case '\u034f', // combining grapheme joiner
+		'\u115f', '\u1160', '\u3164', '\uffa0', // hangul fillers
+		'\u2800': // braille blank
+		return true
+	}
+	return unicode.Is(unicode.Cf, r) || unicode.Is(unicode.Variation_Selector, r)
+}
+
+// confusables maps letters from other scripts to the Latin letters they
+// resemble. It covers the homoglyphs commonly used to evade filters rather
+// than the full Unicode confusables data.
+var confusables = map[rune]rune{
+	// Cyrillic lower case.
+	'а': 'a', 'в': 'b', 'е': 'e', 'о': 'o', 'р': 'p', 'с': 'c', 'у': 'y',
+	'х': 'x', 'і': 'i', 'ј': 'j', 'ѕ': 's', 'ԁ': 'd', 'һ': 'h', 'ӏ': 'l',
+	'ԛ': 'q', 'ԝ': 'w', 'ү': 'y', 'к': 'k', 'м': 'm', 'н': 'h', 'т': 't',
+	// Cyrillic upper case.
+	'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H', 'О': 'O',
+	'Р': 'P', 'С': 'C', 'Т': 'T', 'Х': 'X', 'У': 'Y', 'І': 'I', 'Ј': 'J',
+	'Ѕ': 'S', 'Ԛ': 'Q', 'Ԝ': 'W', 'Ү': 'Y',
+	// Greek lower case.
+	'α': 'a', 'ο': 'o', 'ρ': 'p', 'ν': 'v', 'ι': 'i', 'κ': 'k', 'υ': 'u',
+	'χ': 'x', 'ε': 'e', 'τ': 't',
+	// Greek upper case.
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K',
+	'Μ': 'M', 'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Χ': 'X', 'Υ': 'Y',
+	// Latin lookalikes.
+	'ı': 'i', 'ȷ': 'j', 'ɡ': 'g', 'ɑ': 'a', 'ʏ': 'y', 'ɴ': 'n', 'ʀ': 'r',
+	'ᴀ': 'a', 'ʙ': 'b', 'ᴄ': 'c', 'ᴅ': 'd', 'ᴇ': 'e', 'ɢ': 'g', 'ʜ': 'h',
+	'ɪ': 'i', 'ᴊ': 'j', 'ᴋ': 'k', 'ʟ': 'l', 'ᴍ': 'm', 'ᴏ': 'o', 'ᴘ': 'p',
+	'ꜱ': 's', 'ᴛ': 't', 'ᴜ': 'u', 'ᴠ': 'v', 'ᴡ': 'w', 'ᴢ': 'z',
+}
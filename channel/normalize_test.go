@@ -0,0 +1,82 @@
+package channel_test
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/zephyrtronium/robot/channel"
+)
+
+// evasions is a corpus of ways trolls have disguised text to get past block
+// patterns, with the blocked word replaced by "cucumber".
+var evasions = []struct {
+	name string
+	in   string
+}{
+	{"zero-width-space", "cu\u200bcum\u200bber"},
+	{"zero-width-joiner", "c\u200du\u200dc\u200du\u200dm\u200db\u200de\u200dr"},
+	{"zero-width-non-joiner", "cucu\u200cmber"},
+	{"word-joiner", "cuc\u2060umber"},
+	{"soft-hyphen", "cucum\u00adber"},
+	{"bom", "\ufeffcucumber"},
+	{"tags", "cucu\U000e0041mber"},
+	{"variation-selector", "cucum\ufe0fber"},
+	{"bidi-override", "cu\u202ecum\u202cber"},
+	{"hangul-filler", "cucu\u3164mber"},
+	{"grapheme-joiner", "cu\u034fcumber"},
+	{"zalgo", "c\u0301\u0302\u0303\u0304\u0305u\u0306\u0307\u0308\u0309\u030ac\u030b\u030c\u030d\u030eumber"},
+	{"fullwidth", "ｃｕｃｕｍｂｅｒ"},
+	{"math-bold", "𝐜𝐮𝐜𝐮𝐦𝐛𝐞𝐫"},
+	{"math-script", "𝒸𝓊𝒸𝓊𝓂𝒷𝑒𝓇"},
+	{"math-monospace", "𝚌𝚞𝚌𝚞𝚖𝚋𝚎𝚛"},
+	{"circled", "ⓒⓤⓒⓤⓜⓑⓔⓡ"},
+	{"cyrillic", "\u0441u\u0441umb\u0435r"},
+	{"greek", "c\u03c5c\u03c5mber"},
+	{"small-caps", "ᴄᴜᴄᴜᴍʙᴇʀ"},
+	{"mixed", "ｃ\u200bᴜ\u0441\u03c5𝐦\u00adber"},
+}
+
+func TestNormalizeEvasions(t *testing.T) {
+	b := channel.NewBlocker(regexp.MustCompile(`(?i)cucumber`))
+	terms := channel.NewBlocker(regexp.MustCompile(`$^`))
+	terms.SetTerms([]string{"cucumber"})
+	for _, c := range evasions {
+		t.Run(c.name, func(t *testing.T) {
+			if got := channel.Normalize(c.in); !strings.Contains(strings.ToLower(got), "cucumber") {
+				t.Errorf("normalizing %q gave %q", c.in, got)
+			}
+			if !b.MatchString(c.in) {
+				t.Errorf("expression didn't block %q", c.in)
+			}
+			if !terms.MatchString(c.in) {
+				t.Errorf("term didn't block %q", c.in)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"ascii", "bocchi the rock", "bocchi the rock"},
+		{"accents", "caf\u00e9 na\u00efve", "caf\u00e9 na\u00efve"},
+		{"vietnamese", "Vi\u1ec7t", "Vi\u1ec7t"},
+		{"japanese", "ぼっち・ざ・ろっく", "ぼっち・ざ・ろっく"},
+		{"halfwidth-kana", "ﾎﾞｯﾁ", "ボッチ"},
+		{"emoji", "🎸", "🎸"},
+		{"two-marks", "e\u0301\u0302", "\u00e9\u0302"},
+		{"zalgo-marks", "e\u0301\u0302\u0303\u0304", "e"},
+		{"invisible", "bo\u200bcchi", "bocchi"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := channel.Normalize(c.in); got != c.want {
+				t.Errorf("wrong normalization of %q: want %q, got %q", c.in, c.want, got)
+			}
+		})
+	}
+}
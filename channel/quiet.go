@@ -0,0 +1,185 @@
+package channel
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QuietHours is a weekly schedule of times during which the bot neither
+// speaks nor learns in a channel.
+type QuietHours struct {
+	// Windows is the list of recurring quiet windows.
+	Windows []QuietWindow
+	// Loc is the time zone in which the windows are interpreted.
+	Loc *time.Location
+	// Announce indicates whether to send messages when quiet hours begin
+	// and end.
+	Announce bool
+}
+
+// QuietWindow is a single recurring quiet window.
+type QuietWindow struct {
+	// Days is the set of weekdays on which the window starts, as a bit set
+	// indexed by [time.Weekday].
+	Days uint8
+	// Start and End are the times of day at which the window starts and ends,
+	// as minutes after midnight. If End is not after Start, the window ends
+	// on the following day.
+	Start, End int
+}
+
+// ParseQuietHours parses a quiet schedule. Each window has the form
+// "<days> <start>-<end>", where days is "daily", "weekdays", "weekends", or a
+// comma-separated list of day names and ranges like "mon-fri,sun", and start
+// and end are 24-hour times like "22:00". tz names the time zone of the
+// schedule; the empty string means UTC. A nil slice of windows gives a nil
+// schedule.
+func ParseQuietHours(windows []string, tz string, announce bool) (*QuietHours, error) {
+	if len(windows) == 0 {
+		return nil, nil
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("bad quiet hours time zone: %w", err)
+	}
+	r := &QuietHours{Windows: make([]QuietWindow, 0, len(windows)), Loc: loc, Announce: announce}
+	for _, s := range windows {
+		w, err := parseQuietWindow(s)
+		if err != nil {
+			return nil, fmt.Errorf("bad quiet hours window %q: %w", s, err)
+		}
+		r.Windows = append(r.Windows, w)
+	}
+	return r, nil
+}
+
+func parseQuietWindow(s string) (QuietWindow, error) {
+	days, span, ok := strings.Cut(strings.TrimSpace(s), " ")
+	if !ok {
+		return QuietWindow{}, fmt.Errorf("want days and a time range")
+	}
+	var w QuietWindow
+	switch strings.ToLower(days) {
+	case "daily", "everyday":
+		w.Days = 0x7f
+	case "weekdays":
+		w.Days = 0x3e
+	case "weekends":
+		w.Days = 0x41
+	default:
+		for d := range strings.SplitSeq(days, ",") {
+			a, b, rng := strings.Cut(d, "-")
+			x, err := parseWeekday(a)
+			if err != nil {
+				return QuietWindow{}, err
+			}
+			y := x
+			if rng {
+				y, err = parseWeekday(b)
+				if err != nil {
+					return QuietWindow{}, err
+				}
+			}
+			for {
+				w.Days |= 1 << x
+				if x == y {
+					break
+				}
+				x = (x + 1) % 7
+			}
+		}
+	}
+	start, end, ok := strings.Cut(strings.TrimSpace(span), "-")
+	if !ok {
+		return QuietWindow{}, fmt.Errorf("want a time range like 22:00-06:00")
+	}
+	var err error
+	w.Start, err = parseClock(start)
+	if err != nil {
+		return QuietWindow{}, err
+	}
+	w.End, err = parseClock(end)
+	if err != nil {
+		return QuietWindow{}, err
+	}
+	return w, nil
+}
+
+func parseWeekday(s string) (time.Weekday, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) >= 3 {
+		for d := time.Sunday; d <= time.Saturday; d++ {
+			n := strings.ToLower(d.String())
+			if strings.HasPrefix(n, s) {
+				return d, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unknown day %q", s)
+}
+
+func parseClock(s string) (int, error) {
+	h, m, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		return 0, fmt.Errorf("bad time %q; want hh:mm", s)
+	}
+	hh, err := strconv.Atoi(h)
+	if err != nil || hh < 0 || hh > 24 {
+		return 0, fmt.Errorf("bad hour in %q", s)
+	}
+	mm, err := strconv.Atoi(m)
+	if err != nil || mm < 0 || mm > 59 || hh == 24 && mm != 0 {
+		return 0, fmt.Errorf("bad minute in %q", s)
+	}
+	return hh*60 + mm, nil
+}
+
+// Next returns the first quiet period that has not ended as of t. If start is
+// not after t, then t is within quiet hours until end. Windows which overlap
+// or abut are merged. ok is false if there are no quiet hours.
+func (q *QuietHours) Next(t time.Time) (start, end time.Time, ok bool) {
+	if q == nil || len(q.Windows) == 0 {
+		return time.Time{}, time.Time{}, false
+	}
+	type span struct{ start, end time.Time }
+	var spans []span
+	lt := t.In(q.Loc)
+	y, m, d := lt.Date()
+	// Windows can start the day before and run past midnight, and the next
+	// window can be up to a week out. Look a couple of days past that to
+	// merge anything abutting it.
+	for i := -1; i <= 9; i++ {
+		day := time.Date(y, m, d+i, 0, 0, 0, 0, q.Loc)
+		for _, w := range q.Windows {
+			if w.Days&(1<<day.Weekday()) == 0 {
+				continue
+			}
+			s := time.Date(y, m, d+i, 0, w.Start, 0, 0, q.Loc)
+			e := time.Date(y, m, d+i, 0, w.End, 0, 0, q.Loc)
+			if w.End <= w.Start {
+				e = time.Date(y, m, d+i+1, 0, w.End, 0, 0, q.Loc)
+			}
+			spans = append(spans, span{s, e})
+		}
+	}
+	slices.SortFunc(spans, func(a, b span) int { return a.start.Compare(b.start) })
+	for i, s := range spans {
+		if !s.end.After(t) {
+			continue
+		}
+		start, end = s.start, s.end
+		for _, u := range spans[i+1:] {
+			if u.start.After(end) {
+				break
+			}
+			if u.end.After(end) {
+				end = u.end
+			}
+		}
+		return start, end, true
+	}
+	return time.Time{}, time.Time{}, false
+}
@@ -0,0 +1,207 @@
+package channel_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zephyrtronium/robot/channel"
+)
+
+func TestParseQuietHours(t *testing.T) {
+	cases := []struct {
+		name    string
+		windows []string
+		tz      string
+		want    []channel.QuietWindow
+		err     bool
+	}{
+		{
+			name:    "none",
+			windows: nil,
+			want:    nil,
+		},
+		{
+			name:    "daily",
+			windows: []string{"daily 22:00-06:30"},
+			want:    []channel.QuietWindow{{Days: 0x7f, Start: 22 * 60, End: 6*60 + 30}},
+		},
+		{
+			name:    "weekdays",
+			windows: []string{"weekdays 09:00-17:00"},
+			want:    []channel.QuietWindow{{Days: 0x3e, Start: 9 * 60, End: 17 * 60}},
+		},
+		{
+			name:    "list",
+			windows: []string{"Sun,wed 12:00-18:00"},
+			want:    []channel.QuietWindow{{Days: 1<<time.Sunday | 1<<time.Wednesday, Start: 12 * 60, End: 18 * 60}},
+		},
+		{
+			name:    "range-wrap",
+			windows: []string{"fri-mon 00:00-24:00"},
+			want:    []channel.QuietWindow{{Days: 1<<time.Friday | 1<<time.Saturday | 1<<time.Sunday | 1<<time.Monday, Start: 0, End: 24 * 60}},
+		},
+		{
+			name:    "full-names",
+			windows: []string{"saturday 1:00-2:00", "tuesday 3:00-4:00"},
+			tz:      "America/New_York",
+			want: []channel.QuietWindow{
+				{Days: 1 << time.Saturday, Start: 60, End: 120},
+				{Days: 1 << time.Tuesday, Start: 180, End: 240},
+			},
+		},
+		{
+			name:    "bad-day",
+			windows: []string{"caturday 12:00-13:00"},
+			err:     true,
+		},
+		{
+			name:    "bad-time",
+			windows: []string{"daily 25:00-26:00"},
+			err:     true,
+		},
+		{
+			name:    "no-range",
+			windows: []string{"daily 12:00"},
+			err:     true,
+		},
+		{
+			name:    "no-days",
+			windows: []string{"12:00-13:00"},
+			err:     true,
+		},
+		{
+			name:    "bad-zone",
+			windows: []string{"daily 12:00-13:00"},
+			tz:      "Shimokitazawa/STARRY",
+			err:     true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q, err := channel.ParseQuietHours(c.windows, c.tz, false)
+			if (err != nil) != c.err {
+				t.Fatalf("wrong error: want error %t, got %v", c.err, err)
+			}
+			if c.err {
+				return
+			}
+			if c.want == nil {
+				if q != nil {
+					t.Errorf("want nil schedule, got %+v", q)
+				}
+				return
+			}
+			if len(q.Windows) != len(c.want) {
+				t.Fatalf("wrong windows: want %+v, got %+v", c.want, q.Windows)
+			}
+			for i, w := range c.want {
+				if q.Windows[i] != w {
+					t.Errorf("wrong window %d: want %+v, got %+v", i, w, q.Windows[i])
+				}
+			}
+		})
+	}
+}
+
+func TestQuietHoursNext(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2024-06-02 is a Sunday.
+	at := func(d, h, m int) time.Time { return time.Date(2024, 6, d, h, m, 0, 0, ny) }
+	cases := []struct {
+		name    string
+		windows []string
+		now     time.Time
+		start   time.Time
+		end     time.Time
+		ok      bool
+	}{
+		{
+			name:    "none",
+			windows: nil,
+			now:     at(2, 12, 0),
+			ok:      false,
+		},
+		{
+			name:    "before",
+			windows: []string{"sun 14:00-18:00"},
+			now:     at(2, 12, 0),
+			start:   at(2, 14, 0),
+			end:     at(2, 18, 0),
+			ok:      true,
+		},
+		{
+			name:    "during",
+			windows: []string{"sun 14:00-18:00"},
+			now:     at(2, 15, 0),
+			start:   at(2, 14, 0),
+			end:     at(2, 18, 0),
+			ok:      true,
+		},
+		{
+			name:    "at-end",
+			windows: []string{"sun 14:00-18:00"},
+			now:     at(2, 18, 0),
+			start:   at(9, 14, 0),
+			end:     at(9, 18, 0),
+			ok:      true,
+		},
+		{
+			name:    "overnight",
+			windows: []string{"sat 22:00-02:00"},
+			now:     at(2, 1, 0),
+			start:   at(1, 22, 0),
+			end:     at(2, 2, 0),
+			ok:      true,
+		},
+		{
+			name:    "merge",
+			windows: []string{"sun 14:00-16:00", "sun 16:00-18:00", "sun 17:00-19:00"},
+			now:     at(2, 15, 0),
+			start:   at(2, 14, 0),
+			end:     at(2, 19, 0),
+			ok:      true,
+		},
+		{
+			name:    "merge-days",
+			windows: []string{"daily 00:00-24:00"},
+			now:     at(2, 15, 0),
+			start:   at(2, 0, 0),
+			end:     at(12, 0, 0),
+			ok:      true,
+		},
+		{
+			name:    "later-day",
+			windows: []string{"wed 09:00-10:00"},
+			now:     at(2, 15, 0),
+			start:   at(5, 9, 0),
+			end:     at(5, 10, 0),
+			ok:      true,
+		},
+		{
+			name:    "other-zone",
+			windows: []string{"sun 14:00-18:00"},
+			now:     at(2, 13, 30).UTC(),
+			start:   at(2, 14, 0),
+			end:     at(2, 18, 0),
+			ok:      true,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q, err := channel.ParseQuietHours(c.windows, "America/New_York", false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			start, end, ok := q.Next(c.now)
+			if ok != c.ok {
+				t.Fatalf("wrong ok: want %t, got %t", c.ok, ok)
+			}
+			if !start.Equal(c.start) || !end.Equal(c.end) {
+				t.Errorf("wrong window: want %v to %v, got %v to %v", c.start, c.end, start, end)
+			}
+		})
+	}
+}
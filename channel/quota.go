@@ -0,0 +1,89 @@
+package channel
+
+import (
+	"sync"
+	"time"
+
+	"github.com/zephyrtronium/robot/userhash"
+)
+
+// Quota limits the number of messages learned from each user within a
+// sliding window.
+//
+// Users are identified only by userhash, which changes every
+// [userhash.TimeQuantum]. To recognize a user across the window, Quota
+// computes the user's hash for each quantum that the window overlaps.
+type Quota struct {
+	// mu guards times and swept.
+	mu sync.Mutex
+	// times records the times of messages allowed from each userhash,
+	// oldest first, in Unix nanoseconds.
+	times map[userhash.Hash][]int64
+	// swept is the time of the last sweep for expired users.
+	swept int64
+
+	// limit is the number of messages allowed per user in the window.
+	limit int
+	// window is the duration of the sliding window.
+	window time.Duration
+}
+
+// NewQuota creates a quota allowing limit messages per user within window.
+// If limit or window is not positive, the quota allows everything.
+func NewQuota(limit int, window time.Duration) *Quota {
+	return &Quota{
+		times:  make(map[userhash.Hash][]int64),
+		limit:  limit,
+		window: window,
+	}
+}
+
+// Allow reports whether a message from a user at t is within the quota.
+// If it is, it counts against the user's quota.
+// Times passed to Allow should be monotonic, as messages outside the window
+// are removed.
+// A nil Quota allows everything.
+func (q *Quota) Allow(hasher userhash.Hasher, uid, where string, t time.Time) bool {
+	if q == nil || q.limit <= 0 || q.window <= 0 {
+		return true
+	}
+	now := t.UnixNano()
+	exp := now - q.window.Nanoseconds()
+	qt := userhash.TimeQuantum.Nanoseconds()
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if now-q.swept >= qt {
+		q.sweepLocked(exp)
+		q.swept = now
+	}
+	n := 0
+	var cur userhash.Hash
+	for w := exp / qt * qt; w <= now; w += qt {
+		u := hasher.Hash(uid, where, time.Unix(0, w))
+		l := q.times[u]
+		for len(l) > 0 && l[0] <= exp {
+			l = l[1:]
+		}
+		if len(l) == 0 {
+			delete(q.times, u)
+		} else {
+			q.times[u] = l
+		}
+		n += len(l)
+		cur = u
+	}
+	if n >= q.limit {
+		return false
+	}
+	q.times[cur] = append(q.times[cur], now)
+	return true
+}
+
+// sweepLocked removes users with no messages newer than exp.
+func (q *Quota) sweepLocked(exp int64) {
+	for u, l := range q.times {
+		if len(l) == 0 || l[len(l)-1] <= exp {
+			delete(q.times, u)
+		}
+	}
+}
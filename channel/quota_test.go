@@ -0,0 +1,91 @@
+package channel_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zephyrtronium/robot/channel"
+	"github.com/zephyrtronium/robot/userhash"
+)
+
+func TestQuota(t *testing.T) {
+	type msg struct {
+		when time.Duration
+		who  string
+		want bool
+	}
+	cases := []struct {
+		name   string
+		limit  int
+		window time.Duration
+		msgs   []msg
+	}{
+		{
+			name:   "unlimited",
+			limit:  0,
+			window: time.Hour,
+			msgs: []msg{
+				{0, "bocchi", true},
+				{1, "bocchi", true},
+				{2, "bocchi", true},
+			},
+		},
+		{
+			name:   "limit",
+			limit:  2,
+			window: time.Hour,
+			msgs: []msg{
+				{0, "bocchi", true},
+				{time.Second, "bocchi", true},
+				{2 * time.Second, "bocchi", false},
+				{3 * time.Second, "ryo", true},
+			},
+		},
+		{
+			name:   "slide",
+			limit:  2,
+			window: time.Minute,
+			msgs: []msg{
+				{0, "bocchi", true},
+				{30 * time.Second, "bocchi", true},
+				{45 * time.Second, "bocchi", false},
+				{61 * time.Second, "bocchi", true},
+				{62 * time.Second, "bocchi", false},
+			},
+		},
+		{
+			name:   "across-quanta",
+			limit:  2,
+			window: time.Hour,
+			msgs: []msg{
+				{0, "bocchi", true},
+				{userhash.TimeQuantum, "bocchi", true},
+				{2 * userhash.TimeQuantum, "bocchi", false},
+				{time.Hour + time.Second, "bocchi", true},
+			},
+		},
+		{
+			name:   "dropped-dont-count",
+			limit:  1,
+			window: time.Minute,
+			msgs: []msg{
+				{0, "bocchi", true},
+				{50 * time.Second, "bocchi", false},
+				{61 * time.Second, "bocchi", true},
+			},
+		},
+	}
+	h := userhash.New([]byte("kessoku"))
+	base := time.Unix(1e9, 0)
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q := channel.NewQuota(c.limit, c.window)
+			for _, m := range c.msgs {
+				got := q.Allow(h, m.who, "#kessoku", base.Add(m.when))
+				if got != m.want {
+					t.Errorf("wrong result for %+v: want %t, got %t", m, m.want, got)
+				}
+			}
+		})
+	}
+}
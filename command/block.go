@@ -0,0 +1,108 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/zephyrtronium/robot/message"
+)
+
+// BlockTerm adds a term to the channel's block list.
+// If recent messages contain the term, it offers to forget them.
+//   - term: Term to block.
+func BlockTerm(ctx context.Context, robo *Robot, call *Invocation) {
+	term := blockTerm(call.Args["term"])
+	if term == "" {
+		return
+	}
+	if err := robo.Blocklist.Add(ctx, call.Channel.Name, term); err != nil {
+		robo.Log.ErrorContext(ctx, "block term failed", slog.Any("err", err))
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "block.error")})
+		return
+	}
+	refreshTerms(ctx, robo, call)
+	robo.Log.InfoContext(ctx, "block term", slog.String("term", term))
+	n := 0
+	for range matching(call.Channel, term) {
+		n++
+	}
+	var s string
+	switch n {
+	case 0:
+		s = Text(call.Channel.Lang, "block.none", term)
+	case 1:
+		s = Text(call.Channel.Lang, "block.one", term)
+	default:
+		s = Text(call.Channel.Lang, "block.many", term, n)
+	}
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: s})
+}
+
+// UnblockTerm removes a term from the channel's block list.
+//   - term: Term to unblock.
+func UnblockTerm(ctx context.Context, robo *Robot, call *Invocation) {
+	term := blockTerm(call.Args["term"])
+	if term == "" {
+		return
+	}
+	ok, err := robo.Blocklist.Remove(ctx, call.Channel.Name, term)
+	if err != nil {
+		robo.Log.ErrorContext(ctx, "unblock term failed", slog.Any("err", err))
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "unblock.error")})
+		return
+	}
+	if !ok {
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "unblock.missing", term)})
+		return
+	}
+	refreshTerms(ctx, robo, call)
+	robo.Log.InfoContext(ctx, "unblock term", slog.String("term", term))
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "unblock.done", term)})
+}
+
+// BlockedTerms lists the terms in the channel's block list.
+func BlockedTerms(ctx context.Context, robo *Robot, call *Invocation) {
+	terms := call.Channel.Block.Terms()
+	if len(terms) == 0 {
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "blocked.none")})
+		return
+	}
+	var b strings.Builder
+	b.WriteString(Text(call.Channel.Lang, "blocked.list"))
+	b.WriteByte(' ')
+	for i, t := range terms {
+		s := fmt.Sprintf("%q", t)
+		if i > 0 {
+			s = ", " + s
+		}
+		// Leave room in the 500 character message limit to say there's more.
+		if b.Len()+len(s) > 480 {
+			b.WriteByte(' ')
+			b.WriteString(Text(call.Channel.Lang, "blocked.more", len(terms)-i))
+			break
+		}
+		b.WriteString(s)
+	}
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: b.String()})
+}
+
+// refreshTerms updates the channel's blocked terms from the block list.
+func refreshTerms(ctx context.Context, robo *Robot, call *Invocation) {
+	terms, err := robo.Blocklist.Terms(ctx, call.Channel.Name)
+	if err != nil {
+		robo.Log.ErrorContext(ctx, "couldn't refresh blocked terms", slog.Any("err", err))
+		return
+	}
+	call.Channel.Block.SetTerms(terms)
+}
+
+// blockTerm normalizes a term for the block list.
+func blockTerm(s string) string {
+	s = strings.TrimSpace(s)
+	if len(s) >= 2 && (s[0] == '"' && s[len(s)-1] == '"' || s[0] == '\'' && s[len(s)-1] == '\'') {
+		s = s[1 : len(s)-1]
+	}
+	return strings.ToLower(strings.TrimSpace(s))
+}
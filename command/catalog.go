@@ -0,0 +1,518 @@
+package command
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"gitlab.com/zephyrtronium/pick"
+
+	"github.com/zephyrtronium/robot/locale"
+)
+
+// Text formats a response for a key in a language. Languages fall back to
+// English for keys they lack.
+func Text(lang, key string, args ...any) string {
+	s := responses.Pick(lang, key, rand.Uint32())
+	return strings.TrimSpace(fmt.Sprintf(s, args...))
+}
+
+// one is a response set with a single response.
+func one(s string) *pick.Dist[string] {
+	return pick.New([]pick.Case[string]{{E: s, W: 1}})
+}
+
+// responses is the catalog of localized command responses.
+// Responses for the same key take the same arguments in every language.
+var responses = locale.Catalog{
+	"en": {
+		"need.fed": pick.New([]pick.Case[string]{
+			{E: "I'm hungry 🥺👉👈 tell me to eat?", W: 20},
+			{E: "hungery 🥺👉👈 tell me to eat?", W: 5},
+			{E: "hungy 🥺👉👈 tell me to eat?", W: 5},
+			{E: "tumy grumblin 🥺👉👈 tell me to eat?", W: 5},
+		}),
+		"need.clean": pick.New([]pick.Case[string]{
+			{E: "need to clean up 🥺👉👈 help me clean?", W: 15},
+			{E: "kinda messy around here 🥺👉👈 help me clean?", W: 15},
+			{E: "lil stinky 🥺👉👈 help me clean?", W: 5},
+		}),
+		"need.pats": pick.New([]pick.Case[string]{
+			{E: "need affection 🥺👉👈 give pats?", W: 20},
+			{E: "social meter looks like [=______] 🥺👉👈 give pats?", W: 10},
+			{E: "have I been a good pet? 🥺👉👈 give pats?", W: 1},
+		}),
+		"happy": pick.New([]pick.Case[string]{
+			{E: "All my needs are met!", W: 20},
+			{E: "I'm a happy bot!", W: 20},
+			{E: "Tummy filled, home cleaned, head patted!", W: 20},
+			{E: "food ☑️ bedroom ☑️ kitchen ☑️ living room ☑️ bathroom ☑️ laundry ☑️ sleep ☑️ play ☑️ pats ☑️", W: 20},
+			{E: "I'm a happy pet!", W: 3},
+			{E: "Unbothered. Moisturized. Happy. In My Lane. Focused. Flourishing.", W: 3},
+		}),
+		"conn.need":  one(", but"),
+		"conn.happy": one("."),
+		"eat": pick.New([]pick.Case[string]{
+			{E: "I'll have %s", W: 5},
+			{E: "%s sounds tasty", W: 5},
+			{E: "%s mmmm", W: 5},
+			{E: "mmmm %s", W: 5},
+			{E: "gona chew some %s ya know what I mean", W: 5},
+			{E: "🤤 %s 👅👅🫦😳", W: 1},
+		}),
+		"full": pick.New([]pick.Case[string]{
+			{E: "I'm seriously full.", W: 10},
+			{E: "I'm really not hungry right now.", W: 10},
+			{E: "I've already eaten way too much…", W: 10},
+			{E: "I've eaten so much tasty food already!", W: 10},
+			{E: "Give me some time to digest first…", W: 10},
+			{E: "please no do not make me eat any more my digital belly will literally explode please i do not have the same physiology as a human it is not safe please", W: 1},
+		}),
+		"clean.none": one("Everything's already clean! %[1]s"),
+		"clean.some": pick.New([]pick.Case[string]{
+			{E: "Thank you for cleaning my %[1]s! Now %[2]s", W: 1},
+			{E: "Thanks for helping clean my %[1]s! Now %[2]s", W: 1},
+			{E: "My %[1]s is clean now. Thank you so much! Now %[2]s", W: 1},
+		}),
+		"clean.all": pick.New([]pick.Case[string]{
+			{E: "Thank you for cleaning my home! Now %[1]s", W: 1},
+			{E: "Thanks for helping clean my home! Now %[1]s", W: 1},
+			{E: "My home is clean now. Thank you so much! Now %[1]s", W: 1},
+		}),
+		"room.bedroom":  one("bedroom"),
+		"room.kitchen":  one("kitchen"),
+		"room.living":   one("living room"),
+		"room.bathroom": one("bathroom"),
+		"need.play": pick.New([]pick.Case[string]{
+			{E: "so bored 🥺👉👈 play with me?", W: 20},
+			{E: "wanna play 🥺👉👈 play with me?", W: 10},
+		}),
+		"need.rest": pick.New([]pick.Case[string]{
+			{E: "so sleepy 🥺👉👈 tell me to go to bed?", W: 20},
+			{E: "*yawn* 🥺👉👈 tell me to go to bed?", W: 10},
+		}),
+		"need.laundry": pick.New([]pick.Case[string]{
+			{E: "out of clean clothes 🥺👉👈 help with laundry?", W: 20},
+			{E: "the laundry pile is getting tall 🥺👉👈 help with laundry?", W: 10},
+		}),
+		"play": pick.New([]pick.Case[string]{
+			{E: "Let's play with %s", W: 5},
+			{E: "%s is so fun", W: 5},
+			{E: "%s yay", W: 5},
+		}),
+		"play.asleep": one("zzz… 💤"),
+		"sleep": pick.New([]pick.Case[string]{
+			{E: "Good night! 💤", W: 10},
+			{E: "Time for bed… zzz 💤", W: 10},
+		}),
+		"sleep.awake":  one("I'm not sleepy yet! %[1]s"),
+		"laundry.none": one("My laundry is already clean! %[1]s"),
+		"laundry.done": pick.New([]pick.Case[string]{
+			{E: "Thanks for helping with my laundry! Now %[1]s", W: 1},
+			{E: "All my clothes are clean now. Thank you so much! Now %[1]s", W: 1},
+		}),
+		"list.two":   one("%s and %s"),
+		"list.three": one("%s, %s, and %s"),
+		"affection": pick.New([]pick.Case[string]{
+			{E: "about %[1]f %[2]s", W: 5},
+			{E: "roughly %[1]f %[2]s", W: 5},
+			{E: "%[1]f or so %[2]s", W: 5},
+			{E: "approximately %[1]f %[2]s", W: 5},
+			{E: "I have calculated my affection for you to be exactly %[1]f %[2]s", W: 1},
+			{E: "Right now, I'd say %[1]f. But who knows what the future may hold? %[2]s", W: 1},
+			{E: "%[1]f, and yes, that is a threat. %[2]s", W: 1},
+			{E: "%[1]f, given score = c²/(f+1) + (c+1)f + √l, f=%[4]d from your messages sent, l=%[5]d from length of your longest message, and c=%[3]d from memes, across %[6]d messages in the last fifteen minutes %[2]s", W: 1},
+		}),
+		"private":       one(`Sure, I won't learn from your messages. Most of my functionality will still work for you. If you'd like to have me learn from you again, just tell me, "learn from me again." %s`),
+		"private.error": one("Something went wrong while trying to add you to the privacy list. Try again. Sorry!"),
+		"unprivate":     one(`Sure, I'll learn from you again! %s`),
+		"privacy":       one(`See here for a description of what information I collect, and how to opt out of all collection: https://github.com/zephyrtronium/robot#what-data-does-robot-store`),
+		"source":        one(`My source code is at https://github.com/zephyrtronium/robot – I'm written in Go, and I'm free, open-source software licensed under the GNU General Public License, Version 3.`),
+		"who":           one(`I'm a Markov chain bot! I learn from things people say in chat, then spew vaguely intelligible memes back. More info at: https://github.com/zephyrtronium/robot#how-robot-works %s`),
+		"contact":       one("My operator is %[1]s. %[2]s is the best way to contact %[1]s. %[3]s"),
+		"help.list":     one("Commands you can use:"),
+		"help.more":     one(`Say "help <command>" for more about one.`),
+		"help.unknown":  one(`I don't know a command called %s. Say "help" to see the ones you can use.`),
+		"help.try":      one("Try:"),
+		"help.or":       one("or"),
+		"cooldown":      one("Try again in %d s."),
+		"quote.saved":   one("Saved as quote #%d."),
+		"quote.unknown": one("I don't remember saying that, so I can't quote it."),
+		"quote.none":    one("There are no quotes here yet."),
+		"quote.missing": one("There's no quote #%s."),
+		"quote.show":    one("Quote #%d: %s"),
+		"quote.deleted": one("Deleted quote #%d."),
+		"feedback.good": pick.New([]pick.Case[string]{
+			{E: "thank you!! %s", W: 5},
+			{E: "yay, I'll say more things like that %s", W: 5},
+			{E: "🥺👉👈 %s", W: 2},
+		}),
+		"feedback.bad": pick.New([]pick.Case[string]{
+			{E: "sorry, I'll try to say less stuff like that %s", W: 5},
+			{E: "noted. I'll do better %s", W: 5},
+			{E: "😔 %s", W: 2},
+		}),
+		"feedback.unknown": one("I don't remember saying that."),
+		"game.running":     one("We're already playing! Vote on the last one first."),
+		"game.nothing":     one("I can't think of anything to play with right now."),
+		"game.unavailable": one("My brain can't pick out real messages, so we can't play real or robot here."),
+		"game.start":       one(`Real or robot? "%s" Say "real" or "robot" in the next %d seconds!`),
+		"game.real":        one("It was real! %d of %d got it right."),
+		"game.robot":       one("It was me! %d of %d got it right."),
+		"game.noscores":    one("Nobody has played real or robot here yet."),
+		"game.leaderboard": one("Real or robot leaderboard: %s"),
+		"why.unknown":      one("I don't remember saying that."),
+		"why.count":        one("I put that together from %d messages people sent in chat."),
+		"why.count.prompt": one("I put that together from %d messages people sent in chat, starting from a prompt."),
+		"why.ages":         one("I put that together from %d messages people sent in chat between %s and %s ago."),
+		"why.ages.prompt":  one("I put that together from %d messages people sent in chat between %s and %s ago, starting from a prompt."),
+
+		"sorry":                 one("sorry? (%v)"),
+		"affection.repeat":      one("Don't make me repeat myself, it's embarrassing! %s"),
+		"affection.broadcaster": one("It's a bit awkward to think of you like that, streamer... But, well, it's so fun to be here, and I have you to thank for that! So I'd say a whole bunch! %s"),
+		"affection.zero":        one("literally zero %s"),
+		"marry.no":              one("no %s"),
+		"marry.disliked":        one("Absolutely not. At least not for the next %v. %s"),
+		"marry.first":           one("sure why not %s"),
+		"marry.forgot":          one("How could you forget we're already together? I hate you! Unsubbed, unfollowed, unloved! %s"),
+		"marry.already":         one("We're already together, silly! You're so funny and cute haha. %s"),
+		"marry.taken":           one("My heart yet belongs to %s... %s"),
+		"marry.decline":         one("I'm touched, but I must decline. I'm in love with %s. %s"),
+		"marry.yes":             one("Yes! I'll marry you! %s"),
+		"marry.yes.kind":        one("Yes! I'll be your %s! %s"),
+		"love.broadcaster":      one("I'm so happy you feel that way about me! I love you and your community, too! %s"),
+		"love.weird":            one("Ok... Kinda weird... %s"),
+		"love.cheat":            one("Yeah? Why don't you tell my sweetheart %s about that. Weirdo. %s"),
+		"love":                  one("I love you! %s"),
+		"love.kind":             one("I'm so happy to be your %s! %s"),
+		"marriage":              one("I am looking for a long series of short-term relationships and am holding a ranked competitive how-much-I-like-you tournament to decide my suitors! Politely ask me to marry you (or become your partner) and I'll evaluate your score. I like copypasta, memes, and long walks in the chat."),
+		"marriage.quiet":        one("I'm being quiet for the next %v, so the marriage system is disabled until then."),
+		"rawr":                  one("rawr %s"),
+		"birthday.before":       one("No no no my birthday is next month. %s"),
+		"birthday.month":        one("Oh, but my birthday is later this month. %s"),
+		"birthday.week":         one("My birthday is just a week away! I am so excited about this information. %s"),
+		"birthday.days":         one("My birthday is still less than a week away. %s"),
+		"birthday.two":          one("Two days away...! %s"),
+		"birthday.tomorrow":     one("My birthday is tomorrow! At least in my timezone. %s"),
+		"birthday.today":        one("Thank you! Happy my birthday to you, too! %s"),
+		"birthday.yesterday":    one("You missed it. My birthday was yesterday. You are disqualified from being my valentine. %s"),
+		"birthday.recent":       one("My birthday was the other day, actually, but I appreciate the sentiment. %s"),
+		"birthday.earlier":      one("My birthday was earlier this month, actually, but I appreciate the sentiment. %s"),
+		"birthday.after":        one("No no no my birthday was last month. %s"),
+		"birthday.other":        one("My birthday is in February, silly %s"),
+		"seiso.partner": pick.New([]pick.Case[string]{
+			{E: "Of course you're seiso, sweetie! %s", W: 10},
+			// TODO(zeph): more
+		}),
+		"seiso": pick.New([]pick.Case[string]{
+			{E: "You're like %.0f%% seiso %s", W: 10},
+			{E: "I think you're about %.0f%% seiso %s", W: 10},
+			{E: "Like %.0f%% seiso. Take that as you will. %s", W: 2},
+			{E: "You are exactly %.0f%% seiso %s", W: 10},
+		}),
+		"forget.none":             one("No messages contained %q."),
+		"forget.one":              one("Forgot 1 message."),
+		"forget.many":             one("Forgot %d messages."),
+		"forget.user.unsupported": one("My brain doesn't know who sent what, so I can't forget by user. Sorry!"),
+		"forget.user.error":       one("I couldn't forget messages from %s: %v"),
+		"forget.user.none":        one("I haven't learned anything from %s in the last %v."),
+		"forget.user.one":         one("Forgot 1 message from %s in the last %v."),
+		"forget.user.many":        one("Forgot %d messages from %s in the last %v."),
+		"undo.unsupported":        one("My brain can't bring back forgotten messages. Sorry!"),
+		"undo.error":              one("Something went wrong while I was trying to remember. Sorry!"),
+		"undo.none":               one("There's no recent forget to undo."),
+		"undo.all":                one("Restored %d of %d forgotten messages."),
+		"undo.some":               one("Restored %d of %d forgotten messages. The rest have to stay forgotten."),
+		"quiet.notlive":           one("sorry? the stream doesn't seem to be live"),
+		"quiet.spiel":             one("Some commands relating to moderation and privacy will still make me talk. I'll mention when quiet time is up."),
+		"quiet.offline":           one("I won't talk or learn until the stream ends. %s"),
+		"quiet.stream":            one("I won't talk or learn until the next stream. %s"),
+		"quiet.for":               one("I won't talk or learn for %v. %s"),
+		"quiet.left":              one("I'm being quiet for %v more."),
+		"quiet.not":               one("I'm not being quiet right now."),
+		"quiet.hours":             one("My next quiet hours are %s to %s."),
+		"block.error":             one("Something went wrong while trying to block that. Try again. Sorry!"),
+		"block.none":              one("I won't learn or copypasta messages containing %q."),
+		"block.one":               one(`I won't learn or copypasta messages containing %[1]q. 1 recent message contains it; tell me "forget %[1]s" to forget it.`),
+		"block.many":              one(`I won't learn or copypasta messages containing %[1]q. %[2]d recent messages contain it; tell me "forget %[1]s" to forget them.`),
+		"unblock.error":           one("Something went wrong while trying to unblock that. Try again. Sorry!"),
+		"unblock.missing":         one("%q isn't blocked."),
+		"unblock.done":            one("%q is no longer blocked."),
+		"blocked.none":            one("No terms are blocked here."),
+		"blocked.list":            one("Blocked terms:"),
+		"blocked.more":            one("and %d more"),
+		"quote.error":             one("Something went wrong while deleting quote #%d. Try again."),
+		"responses.bad":           one("The response rate needs to be a percentage from 0 to 100."),
+		"responses.set":           one("I'll respond to %s of messages."),
+		"responses.set.for":       one("I'll respond to %s of messages for %v."),
+		"responses.revert":        one("@%s I'm back to responding to %s of messages."),
+		"rate.bad.num":            one("The number of messages needs to be from 1 to 100."),
+		"rate.bad.every":          one("The time needs to be a positive number."),
+		"rate.set":                one("I'll send up to %d messages per %v."),
+		"rate.set.one":            one("I'll send up to %d message per %v."),
+		"rate.set.for":            one("I'll send up to %d messages per %v for %v."),
+		"rate.set.for.one":        one("I'll send up to %d message per %v for %v."),
+		"rate.revert":             one("@%s My rate limit is back to %s."),
+		"rate.unlimited":          one("unlimited"),
+		"rate.total":              one("%d messages in total"),
+		"rate.total.one":          one("%d message in total"),
+		"rate.per":                one("%d messages per %v"),
+		"rate.per.one":            one("%d message per %v"),
+		"settings":                one("I respond to %s of messages, and my rate limit is %s."),
+		"join.error":              one("I couldn't join %s: %v"),
+		"join.done":               one("Joining %s."),
+		"part.error":              one("I couldn't leave %s: %v"),
+		"part.done":               one("Left %s."),
+	},
+	"es": {
+		"need.fed": pick.New([]pick.Case[string]{
+			{E: "Tengo hambre 🥺👉👈 ¿me dices que coma?", W: 20},
+			{E: "hambre 🥺👉👈 ¿me dices que coma?", W: 5},
+			{E: "me ruge la pancita 🥺👉👈 ¿me dices que coma?", W: 5},
+		}),
+		"need.clean": pick.New([]pick.Case[string]{
+			{E: "hay que limpiar 🥺👉👈 ¿me ayudas a limpiar?", W: 15},
+			{E: "está un poco desordenado por aquí 🥺👉👈 ¿me ayudas a limpiar?", W: 15},
+			{E: "huele un poquito 🥺👉👈 ¿me ayudas a limpiar?", W: 5},
+		}),
+		"need.pats": pick.New([]pick.Case[string]{
+			{E: "necesito cariño 🥺👉👈 ¿me das caricias?", W: 20},
+			{E: "mi medidor social está así [=______] 🥺👉👈 ¿me das caricias?", W: 10},
+			{E: "¿he sido una buena mascota? 🥺👉👈 ¿me das caricias?", W: 1},
+		}),
+		"happy": pick.New([]pick.Case[string]{
+			{E: "¡Todas mis necesidades están cubiertas!", W: 20},
+			{E: "¡Soy un bot feliz!", W: 20},
+			{E: "¡Pancita llena, casa limpia, cabeza acariciada!", W: 20},
+			{E: "comida ☑️ dormitorio ☑️ cocina ☑️ sala ☑️ baño ☑️ ropa ☑️ sueño ☑️ juegos ☑️ caricias ☑️", W: 20},
+			{E: "¡Soy una mascota feliz!", W: 3},
+		}),
+		"conn.need":  one(", pero"),
+		"conn.happy": one("."),
+		"eat": pick.New([]pick.Case[string]{
+			{E: "Voy a comer %s", W: 5},
+			{E: "%s se ve rico", W: 5},
+			{E: "%s mmmm", W: 5},
+			{E: "mmmm %s", W: 5},
+			{E: "🤤 %s 👅👅🫦😳", W: 1},
+		}),
+		"full": pick.New([]pick.Case[string]{
+			{E: "Estoy llenísimo.", W: 10},
+			{E: "La verdad no tengo hambre ahora.", W: 10},
+			{E: "Ya comí demasiado…", W: 10},
+			{E: "¡Ya comí tanta comida rica!", W: 10},
+			{E: "Dame un rato para hacer la digestión…", W: 10},
+		}),
+		"clean.none": one("¡Todo ya está limpio! %[1]s"),
+		"clean.some": pick.New([]pick.Case[string]{
+			{E: "¡Gracias por limpiar mi %[1]s! Ahora %[2]s", W: 1},
+			{E: "¡Gracias por ayudarme a limpiar mi %[1]s! Ahora %[2]s", W: 1},
+		}),
+		"clean.all": pick.New([]pick.Case[string]{
+			{E: "¡Gracias por limpiar mi casa! Ahora %[1]s", W: 1},
+			{E: "¡Gracias por ayudarme a limpiar mi casa! Ahora %[1]s", W: 1},
+		}),
+		"room.bedroom":  one("dormitorio"),
+		"room.kitchen":  one("cocina"),
+		"room.living":   one("sala"),
+		"room.bathroom": one("baño"),
+		"need.play": pick.New([]pick.Case[string]{
+			{E: "qué aburrimiento 🥺👉👈 ¿juegas conmigo?", W: 20},
+			{E: "quiero jugar 🥺👉👈 ¿juegas conmigo?", W: 10},
+		}),
+		"need.rest": pick.New([]pick.Case[string]{
+			{E: "tengo mucho sueño 🥺👉👈 ¿me mandas a dormir?", W: 20},
+			{E: "*bostezo* 🥺👉👈 ¿me mandas a dormir?", W: 10},
+		}),
+		"need.laundry": pick.New([]pick.Case[string]{
+			{E: "no me queda ropa limpia 🥺👉👈 ¿me ayudas a lavar la ropa?", W: 20},
+			{E: "la pila de ropa sucia está creciendo 🥺👉👈 ¿me ayudas a lavar la ropa?", W: 10},
+		}),
+		"play": pick.New([]pick.Case[string]{
+			{E: "Juguemos con %s", W: 5},
+			{E: "%s es muy divertido", W: 5},
+			{E: "%s yay", W: 5},
+		}),
+		"play.asleep": one("zzz… 💤"),
+		"sleep": pick.New([]pick.Case[string]{
+			{E: "¡Buenas noches! 💤", W: 10},
+			{E: "Hora de dormir… zzz 💤", W: 10},
+		}),
+		"sleep.awake":  one("¡Todavía no tengo sueño! %[1]s"),
+		"laundry.none": one("¡Mi ropa ya está limpia! %[1]s"),
+		"laundry.done": pick.New([]pick.Case[string]{
+			{E: "¡Gracias por ayudarme a lavar la ropa! Ahora %[1]s", W: 1},
+			{E: "Toda mi ropa está limpia. ¡Muchas gracias! Ahora %[1]s", W: 1},
+		}),
+		"list.two":   one("%s y %s"),
+		"list.three": one("%s, %s y %s"),
+		"affection": pick.New([]pick.Case[string]{
+			{E: "alrededor de %[1]f %[2]s", W: 5},
+			{E: "más o menos %[1]f %[2]s", W: 5},
+			{E: "aproximadamente %[1]f %[2]s", W: 5},
+			{E: "He calculado que mi cariño por ti es exactamente %[1]f %[2]s", W: 1},
+		}),
+		"private":       one(`Claro, no aprenderé de tus mensajes. La mayoría de mis funciones seguirán funcionando para ti. Si quieres que vuelva a aprender de ti, solo dime "aprende de mí otra vez." %s`),
+		"private.error": one("Algo salió mal al intentar agregarte a la lista de privacidad. Inténtalo de nuevo. ¡Perdón!"),
+		"unprivate":     one(`¡Claro, volveré a aprender de ti! %s`),
+		"privacy":       one(`Aquí hay una descripción (en inglés) de la información que recopilo y cómo evitar toda recopilación: https://github.com/zephyrtronium/robot#what-data-does-robot-store`),
+		"who":           one(`¡Soy un bot de cadenas de Markov! Aprendo de lo que la gente dice en el chat y luego devuelvo memes vagamente inteligibles. Más información: https://github.com/zephyrtronium/robot#how-robot-works %s`),
+		"contact":       one("Mi operador es %[1]s. %[2]s es la mejor manera de contactar a %[1]s. %[3]s"),
+		"help.list":     one("Comandos que puedes usar:"),
+		"help.more":     one(`Di "ayuda <comando>" para saber más sobre uno.`),
+		"help.unknown":  one(`No conozco ningún comando llamado %s. Di "ayuda" para ver los que puedes usar.`),
+		"help.try":      one("Prueba:"),
+		"help.or":       one("o"),
+		"cooldown":      one("Inténtalo de nuevo en %d s."),
+		"quote.saved":   one("Guardado como cita #%d."),
+		"quote.unknown": one("No recuerdo haber dicho eso, así que no puedo citarlo."),
+		"quote.none":    one("Todavía no hay citas aquí."),
+		"quote.missing": one("No hay cita #%s."),
+		"quote.show":    one("Cita #%d: %s"),
+		"quote.deleted": one("Cita #%d eliminada."),
+		"feedback.good": pick.New([]pick.Case[string]{
+			{E: "¡¡gracias!! %s", W: 5},
+			{E: "¡bien! Diré más cosas así %s", W: 5},
+		}),
+		"feedback.bad": pick.New([]pick.Case[string]{
+			{E: "perdón, intentaré decir menos cosas así %s", W: 5},
+			{E: "entendido. Lo haré mejor %s", W: 5},
+		}),
+		"feedback.unknown": one("No recuerdo haber dicho eso."),
+		"game.running":     one("¡Ya estamos jugando! Vota en el último primero."),
+		"game.nothing":     one("No se me ocurre nada para jugar ahora."),
+		"game.unavailable": one("Mi cerebro no puede elegir mensajes reales, así que aquí no podemos jugar a real o robot."),
+		"game.start":       one(`¿Real o robot? "%s" ¡Di "real" o "robot" en los próximos %d segundos!`),
+		"game.real":        one("¡Era real! %d de %d acertaron."),
+		"game.robot":       one("¡Era yo! %d de %d acertaron."),
+		"game.noscores":    one("Nadie ha jugado a real o robot aquí todavía."),
+		"game.leaderboard": one("Clasificación de real o robot: %s"),
+		"why.unknown":      one("No recuerdo haber dicho eso."),
+		"why.count":        one("Lo armé a partir de %d mensajes que la gente envió al chat."),
+		"why.count.prompt": one("Lo armé a partir de %d mensajes que la gente envió al chat, empezando por un prompt."),
+		"why.ages":         one("Lo armé a partir de %d mensajes que la gente envió al chat hace entre %s y %s."),
+		"why.ages.prompt":  one("Lo armé a partir de %d mensajes que la gente envió al chat hace entre %s y %s, empezando por un prompt."),
+	},
+	"ja": {
+		"need.fed": pick.New([]pick.Case[string]{
+			{E: "おなかすいた 🥺👉👈 ごはんって言って？", W: 20},
+			{E: "おなかがぐーぐー鳴ってる 🥺👉👈 ごはんって言って？", W: 5},
+		}),
+		"need.clean": pick.New([]pick.Case[string]{
+			{E: "お掃除しなきゃ 🥺👉👈 手伝ってくれる？", W: 15},
+			{E: "ちょっと散らかってる 🥺👉👈 手伝ってくれる？", W: 15},
+		}),
+		"need.pats": pick.New([]pick.Case[string]{
+			{E: "かまってほしい 🥺👉👈 なでなでして？", W: 20},
+			{E: "ソーシャルメーターが [=______] 🥺👉👈 なでなでして？", W: 10},
+			{E: "いい子にしてた？ 🥺👉👈 なでなでして？", W: 1},
+		}),
+		"happy": pick.New([]pick.Case[string]{
+			{E: "満たされてる！", W: 20},
+			{E: "しあわせなボットです！", W: 20},
+			{E: "おなかいっぱい、お部屋きれい、なでなでしてもらった！", W: 20},
+			{E: "ごはん ☑️ 寝室 ☑️ キッチン ☑️ リビング ☑️ お風呂 ☑️ 洗濯 ☑️ 睡眠 ☑️ 遊び ☑️ なでなで ☑️", W: 20},
+		}),
+		"conn.need":  one("。でも"),
+		"conn.happy": one("。"),
+		"eat": pick.New([]pick.Case[string]{
+			{E: "%sいただきます", W: 5},
+			{E: "%sおいしそう", W: 5},
+			{E: "%s もぐもぐ", W: 5},
+			{E: "🤤 %s 👅👅🫦😳", W: 1},
+		}),
+		"full": pick.New([]pick.Case[string]{
+			{E: "もうおなかいっぱい。", W: 10},
+			{E: "今はおなかすいてないよ。", W: 10},
+			{E: "もう食べすぎちゃった…", W: 10},
+			{E: "ちょっと消化させて…", W: 10},
+		}),
+		"clean.none": one("もう全部きれいだよ！%[1]s"),
+		"clean.some": pick.New([]pick.Case[string]{
+			{E: "%[1]sをお掃除してくれてありがとう！%[2]s", W: 1},
+			{E: "%[1]sがきれいになった。本当にありがとう！%[2]s", W: 1},
+		}),
+		"clean.all": pick.New([]pick.Case[string]{
+			{E: "おうちをお掃除してくれてありがとう！%[1]s", W: 1},
+			{E: "おうちがきれいになった。本当にありがとう！%[1]s", W: 1},
+		}),
+		"room.bedroom":  one("寝室"),
+		"room.kitchen":  one("キッチン"),
+		"room.living":   one("リビング"),
+		"room.bathroom": one("お風呂"),
+		"need.play": pick.New([]pick.Case[string]{
+			{E: "ひまだよ 🥺👉👈 遊んでくれる？", W: 20},
+			{E: "遊びたい 🥺👉👈 遊んでくれる？", W: 10},
+		}),
+		"need.rest": pick.New([]pick.Case[string]{
+			{E: "ねむい 🥺👉👈 寝かせてくれる？", W: 20},
+			{E: "ふわぁ… 🥺👉👈 寝かせてくれる？", W: 10},
+		}),
+		"need.laundry": pick.New([]pick.Case[string]{
+			{E: "きれいな服がない 🥺👉👈 洗濯手伝ってくれる？", W: 20},
+			{E: "洗濯物がたまってる 🥺👉👈 洗濯手伝ってくれる？", W: 10},
+		}),
+		"play": pick.New([]pick.Case[string]{
+			{E: "%sで遊ぼう", W: 5},
+			{E: "%sたのしい", W: 5},
+		}),
+		"play.asleep": one("すやすや… 💤"),
+		"sleep": pick.New([]pick.Case[string]{
+			{E: "おやすみなさい！💤", W: 10},
+			{E: "もう寝る時間… zzz 💤", W: 10},
+		}),
+		"sleep.awake":  one("まだ眠くないよ！%[1]s"),
+		"laundry.none": one("洗濯物はもうきれいだよ！%[1]s"),
+		"laundry.done": pick.New([]pick.Case[string]{
+			{E: "洗濯を手伝ってくれてありがとう！%[1]s", W: 1},
+			{E: "服が全部きれいになった。本当にありがとう！%[1]s", W: 1},
+		}),
+		"list.two":   one("%sと%s"),
+		"list.three": one("%s、%s、%s"),
+		"affection": pick.New([]pick.Case[string]{
+			{E: "だいたい%[1]f %[2]s", W: 5},
+			{E: "%[1]fくらい %[2]s", W: 5},
+			{E: "あなたへの好感度はちょうど%[1]fと計算しました %[2]s", W: 1},
+		}),
+		"private":       one(`わかった、あなたのメッセージからは学習しないね。ほとんどの機能はそのまま使えるよ。また学習してほしくなったら「learn from me again」って言ってね。%s`),
+		"private.error": one("プライバシーリストへの追加中にエラーが起きました。もう一度試してください。ごめんね！"),
+		"unprivate":     one(`わかった、また学習するね！%s`),
+		"privacy":       one(`収集する情報と、すべての収集を止める方法はこちら（英語）：https://github.com/zephyrtronium/robot#what-data-does-robot-store`),
+		"who":           one(`マルコフ連鎖ボットです！チャットのみんなの言葉から学んで、なんとなく意味の通るミームを返すよ。詳しくは：https://github.com/zephyrtronium/robot#how-robot-works %s`),
+		"contact":       one("運営者は%[1]sです。%[1]sへの連絡は%[2]sがいちばんです。%[3]s"),
+		"help.list":     one("使えるコマンド："),
+		"help.more":     one(`「help <コマンド>」で詳しく説明するよ。`),
+		"help.unknown":  one(`%sというコマンドは知らないよ。「ヘルプ」で使えるコマンドを見てね。`),
+		"help.try":      one("例："),
+		"help.or":       one("、"),
+		"cooldown":      one("%d秒後にもう一度どうぞ。"),
+		"quote.saved":   one("名言#%dとして保存したよ。"),
+		"quote.unknown": one("それを言った覚えがないから、名言にできないよ。"),
+		"quote.none":    one("まだ名言はないよ。"),
+		"quote.missing": one("名言#%sはないよ。"),
+		"quote.show":    one("名言#%d：%s"),
+		"quote.deleted": one("名言#%dを削除したよ。"),
+		"feedback.good": pick.New([]pick.Case[string]{
+			{E: "ありがとう！！%s", W: 5},
+			{E: "やった、こういうのもっと言うね %s", W: 5},
+		}),
+		"feedback.bad": pick.New([]pick.Case[string]{
+			{E: "ごめんね、こういうのはあまり言わないようにするね %s", W: 5},
+			{E: "了解、次はがんばるね %s", W: 5},
+		}),
+		"feedback.unknown": one("それを言った覚えがないよ。"),
+		"game.running":     one("もう遊んでるよ！先に前のに投票してね。"),
+		"game.nothing":     one("今は遊べるものが思いつかないよ。"),
+		"game.unavailable": one("私の脳は本物のメッセージを選べないから、ここでは本物かロボットかで遊べないよ。"),
+		"game.start":       one(`本物かロボットか？「%s」%d秒以内に「本物」か「ロボット」と言ってね！`),
+		"game.real":        one("本物でした！%[2]d人中%[1]d人が正解。"),
+		"game.robot":       one("私でした！%[2]d人中%[1]d人が正解。"),
+		"game.noscores":    one("ここではまだ誰も本物かロボットかで遊んでいないよ。"),
+		"game.leaderboard": one("本物かロボットかのランキング：%s"),
+		"why.unknown":      one("それを言った覚えがないよ。"),
+		"why.count":        one("チャットのメッセージ%d件から作ったよ。"),
+		"why.count.prompt": one("チャットのメッセージ%d件から、プロンプトを元に作ったよ。"),
+		"why.ages":         one("%[2]s前から%[3]s前までのチャットのメッセージ%[1]d件から作ったよ。"),
+		"why.ages.prompt":  one("%[2]s前から%[3]s前までのチャットのメッセージ%[1]d件から、プロンプトを元に作ったよ。"),
+	},
+}
@@ -3,28 +3,71 @@ package command
 import (
 	"context"
 	"log/slog"
+	"time"
 
+	"github.com/zephyrtronium/robot/audit"
+	"github.com/zephyrtronium/robot/blocklist"
 	"github.com/zephyrtronium/robot/brain"
 	"github.com/zephyrtronium/robot/channel"
+	"github.com/zephyrtronium/robot/leaderboard"
 	"github.com/zephyrtronium/robot/message"
 	"github.com/zephyrtronium/robot/metrics"
 	"github.com/zephyrtronium/robot/pet"
 	"github.com/zephyrtronium/robot/privacy"
+	"github.com/zephyrtronium/robot/silence"
 	"github.com/zephyrtronium/robot/spoken"
 	"github.com/zephyrtronium/robot/syncmap"
 )
 
 // Robot is the bot state as is visible to commands.
 type Robot struct {
-	Log      *slog.Logger
-	Channels *syncmap.Map[string, *channel.Channel]
-	Brain    brain.Interface
-	Pet      *pet.Status
-	Privacy  *privacy.List
-	Spoken   *spoken.History
-	Owner    string
-	Contact  string
-	Metrics  *metrics.Metrics
+	Log       *slog.Logger
+	Channels  *syncmap.Map[string, *channel.Channel]
+	Brain     brain.Interface
+	Blocklist *blocklist.List
+	Audit     *audit.Log
+	Pets      *pet.Store
+	Privacy   *privacy.List
+	Spoken    *spoken.History
+	// Leaderboard is the scores for real or robot. It may be nil.
+	Leaderboard *leaderboard.Board
+	// Name is the bot's own username on the platform.
+	Name    string
+	Owner   string
+	Contact string
+	Metrics *metrics.Metrics
+	// Join joins a channel at runtime and returns its normalized name.
+	Join func(ctx context.Context, name string) (string, error)
+	// Part leaves a channel joined at runtime and returns its normalized name.
+	Part func(ctx context.Context, name string) (string, error)
+	// ForgetUser forgets messages from a user in a channel within a window
+	// ending now. The window may be shortened to a configured limit; zero
+	// means the whole limit. It returns the number of messages forgotten and
+	// the window used.
+	// The actor is recorded in the audit log as who requested it.
+	ForgetUser func(ctx context.Context, ch *channel.Channel, actor, login string, window time.Duration) (int, time.Duration, error)
+	// UndoForget restores the messages forgotten by the most recent forget
+	// command in a channel. It returns the number of messages restored and
+	// the number in the batch; both are zero if there is nothing to undo.
+	UndoForget func(ctx context.Context, ch *channel.Channel, actor string) (int, int, error)
+	// Weights gets the weights from chat feedback for generating messages in
+	// a tag. Errors are logged and give no weights.
+	Weights func(ctx context.Context, tag string) brain.Weights
+	// DropQuotes removes quotes whose traces include any of the given
+	// forgotten messages. It may be nil.
+	DropQuotes func(ctx context.Context, ids ...string)
+	// Quiet makes the bot quiet in a channel until a given time, remembering
+	// it across restarts. If event is not empty, that stream event also ends
+	// the quiet time. It blocks until the time passes, then mentions the actor
+	// unless the quiet time was changed in the meantime.
+	Quiet func(ctx context.Context, ch *channel.Channel, actor string, until time.Time, event silence.Event)
+	// Usage lists the commands available to the invoker.
+	Usage func() []Usage
+	// RealMessage chooses a real message learned in a channel for a round of
+	// real or robot, or returns the empty string if there is none suitable.
+	RealMessage func(ctx context.Context, ch *channel.Channel) (string, error)
+	// GameWindow is the time chat has to vote in a round of real or robot.
+	GameWindow time.Duration
 }
 
 // Invocation is a command invocation. An Invocation and its fields must not
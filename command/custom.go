@@ -0,0 +1,113 @@
+package command
+
+import (
+	"cmp"
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/zephyrtronium/robot/brain"
+	"github.com/zephyrtronium/robot/channel"
+	"github.com/zephyrtronium/robot/message"
+)
+
+// ParseTemplate parses a response template for a custom command.
+// Templates have access to the following:
+//   - .Sender: The display name of the user who invoked the command.
+//   - .Channel: The name of the channel.
+//   - .Args: The named captures of the command's regular expression.
+//   - .Emote: A random emote for the channel.
+//   - think: A function generating a message, optionally given a prompt.
+func ParseTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Funcs(template.FuncMap{"think": noThink}).Parse(text)
+}
+
+// noThink stands in for the think template function at parse time.
+func noThink(prompt ...string) (string, error) { return "", nil }
+
+// customData is the data available to custom command templates.
+type customData struct {
+	Sender  string
+	Channel string
+	Args    map[string]string
+	Emote   string
+}
+
+// Custom creates a command function that responds with a custom command's
+// template.
+func Custom(cmd *channel.Command) Func {
+	return func(ctx context.Context, robo *Robot, call *Invocation) {
+		if call.Message.Time().Before(call.Channel.SilentTime()) {
+			robo.Log.InfoContext(ctx, "silent", slog.Time("until", call.Channel.SilentTime()))
+			return
+		}
+		var trace []string
+		var cost time.Duration
+		var prompted string
+		w := robo.Weights(ctx, call.Channel.Send)
+		think := func(prompt ...string) (string, error) {
+			p := strings.Join(prompt, " ")
+			start := time.Now()
+			m, tr, err := brain.ThinkWeighted(ctx, robo.Brain, call.Channel.Send, p, w)
+			cost += time.Since(start)
+			trace = append(trace, tr...)
+			prompted = cmp.Or(p, prompted)
+			return m, err
+		}
+		tmpl, err := cmd.Response.Clone()
+		if err != nil {
+			robo.Log.ErrorContext(ctx, "couldn't clone template", slog.Any("err", err))
+			return
+		}
+		tmpl.Funcs(template.FuncMap{"think": think})
+		data := customData{
+			Sender:  call.Message.Sender.Name,
+			Channel: call.Channel.Name,
+			Args:    call.Args,
+			Emote:   call.Channel.Emotes.Pick(rand.Uint32()),
+		}
+		var b strings.Builder
+		if err := tmpl.Execute(&b, &data); err != nil {
+			robo.Log.ErrorContext(ctx, "couldn't execute template", slog.Any("err", err))
+			return
+		}
+		s := lenlimit(strings.TrimSpace(b.String()), 450)
+		if s == "" {
+			robo.Log.InfoContext(ctx, "custom command said nothing", slog.String("name", cmd.Name))
+			return
+		}
+		if call.Channel.Block.MatchString(s) {
+			robo.Log.WarnContext(ctx, "custom command generated blocked message", slog.String("text", s))
+			return
+		}
+		t := time.Now()
+		r := call.Channel.Rate.ReserveN(t, 1)
+		if d := r.DelayFrom(t); d > 0 {
+			robo.Log.InfoContext(ctx, "won't speak; rate limited",
+				slog.String("action", "custom command"),
+				slog.String("in", call.Channel.Name),
+				slog.String("delay", d.String()),
+			)
+			r.CancelAt(t)
+			return
+		}
+		// Only start the cooldown once the command is actually going to speak.
+		if !cmd.Use(call.Message.Time()) {
+			robo.Log.InfoContext(ctx, "custom command on cooldown", slog.String("name", cmd.Name))
+			r.CancelAt(t)
+			return
+		}
+		if len(trace) != 0 {
+			if err := robo.Spoken.Record(ctx, call.Channel.Send, s, trace, call.Message.Time(), cost, s, "", "cmd "+cmd.Name, prompted); err != nil {
+				robo.Log.ErrorContext(ctx, "couldn't record trace", slog.Any("err", err))
+				return
+			}
+		}
+		call.Channel.Memery.Block(call.Message.Time(), s)
+		robo.Log.InfoContext(ctx, "custom command", slog.String("name", cmd.Name), slog.String("text", s))
+		call.Channel.Message(ctx, message.Sent{Text: s})
+	}
+}
@@ -0,0 +1,60 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gitlab.com/zephyrtronium/pick"
+	"golang.org/x/time/rate"
+
+	"github.com/zephyrtronium/robot/brain"
+	"github.com/zephyrtronium/robot/channel"
+	"github.com/zephyrtronium/robot/message"
+)
+
+func TestCustomRateLimitedKeepsCooldown(t *testing.T) {
+	ctx := context.Background()
+	tmpl, err := ParseTemplate("band", "{{.Sender}} is in kessoku band")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd := &channel.Command{Name: "band", Cooldown: time.Hour, Response: tmpl}
+	robo := Robot{
+		Log:     slog.New(slog.DiscardHandler),
+		Weights: func(ctx context.Context, tag string) brain.Weights { return nil },
+	}
+	var sent []string
+	ch := &channel.Channel{
+		Name:    "#kessoku",
+		Message: func(ctx context.Context, msg message.Sent) { sent = append(sent, msg.Text) },
+		Block:   channel.NewBlocker(regexp.MustCompile(`$^`)),
+		Rate:    rate.NewLimiter(0, 0),
+		Memery:  channel.NewMemeDetector(3, time.Minute),
+		Emotes:  pick.New([]pick.Case[string]{{E: "", W: 1}}),
+		Silent:  new(atomic.Int64),
+	}
+	call := Invocation{
+		Channel: ch,
+		Message: &message.Received[message.User]{Sender: message.User{Name: "bocchi"}, Timestamp: time.Now().UnixMilli()},
+	}
+	f := Custom(cmd)
+	f(ctx, &robo, &call)
+	if len(sent) != 0 {
+		t.Fatalf("rate limited command spoke: %q", sent)
+	}
+	// Once the rate limit allows it, the command should still be usable.
+	ch.Rate = rate.NewLimiter(rate.Inf, 1)
+	f(ctx, &robo, &call)
+	if len(sent) != 1 || sent[0] != "bocchi is in kessoku band" {
+		t.Errorf("wrong messages after rate limit: want [%q], got %q", "bocchi is in kessoku band", sent)
+	}
+	// Now it is on cooldown.
+	f(ctx, &robo, &call)
+	if len(sent) != 1 {
+		t.Errorf("command spoke on cooldown: %q", sent)
+	}
+}
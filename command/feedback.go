@@ -0,0 +1,47 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+
+	"github.com/zephyrtronium/robot/message"
+)
+
+// Feedback records chat's opinion of one of the bot's messages. Feedback on
+// a message makes the messages it was generated from more or less likely to
+// be used in the future.
+// If the invocation replies to a message, that message is rated; otherwise
+// the bot's most recent message is.
+//   - bad: Nonempty if the feedback is negative.
+func Feedback(ctx context.Context, robo *Robot, call *Invocation) {
+	if call.Message.Time().Before(call.Channel.SilentTime()) {
+		robo.Log.InfoContext(ctx, "silent", slog.Time("until", call.Channel.SilentTime()))
+		return
+	}
+	text, err := repliedText(ctx, robo, call)
+	if err != nil {
+		robo.Log.ErrorContext(ctx, "couldn't find previous message to rate", slog.Any("err", err))
+		return
+	}
+	good := call.Args["bad"] == ""
+	var ok bool
+	if text != "" {
+		ok, err = robo.Spoken.Rate(ctx, call.Channel.Send, text, call.Message.Sender.ID, good)
+		if err != nil {
+			robo.Log.ErrorContext(ctx, "couldn't rate message", slog.Any("err", err))
+			return
+		}
+	}
+	if !ok {
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "feedback.unknown")})
+		return
+	}
+	robo.Log.InfoContext(ctx, "feedback", slog.String("in", call.Channel.Name), slog.String("text", text), slog.Bool("good", good))
+	key := "feedback.bad"
+	if good {
+		key = "feedback.good"
+	}
+	e := call.Channel.Emotes.Pick(rand.Uint32())
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, key, e)})
+}
@@ -0,0 +1,234 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zephyrtronium/robot/brain"
+	"github.com/zephyrtronium/robot/channel"
+	"github.com/zephyrtronium/robot/leaderboard"
+	"github.com/zephyrtronium/robot/message"
+)
+
+// roundKey is the channel extra key for the current round of real or robot.
+type roundKey struct{}
+
+// round is a round of real or robot.
+type round struct {
+	mu sync.Mutex
+	// end is the time voting closes.
+	end time.Time
+	// votes maps voters' user IDs to their votes.
+	// It is nil until voting opens.
+	votes map[string]vote
+	// trace and cost are the trace and time to think of the message when it
+	// is the robot's. Only the goroutine running the round uses them.
+	trace []string
+	cost  time.Duration
+}
+
+type vote struct {
+	name string
+	real bool
+}
+
+// votes maps the messages that count as votes to whether they vote real.
+var votes = map[string]bool{
+	"real":   true,
+	"human":  true,
+	"humano": true,
+	"本物":     true,
+	"robot":  false,
+	"bot":    false,
+	"fake":   false,
+	"ai":     false,
+	"falso":  false,
+	"ロボット":   false,
+}
+
+// Vote counts a chat message as a vote in the channel's current round of
+// real or robot, if there is one and the message is a vote.
+// It reports whether the message was counted.
+func Vote(ch *channel.Channel, m *message.Received[message.User]) bool {
+	v, ok := ch.Extra.Load(roundKey{})
+	if !ok {
+		return false
+	}
+	real, ok := votes[strings.ToLower(strings.Trim(m.Text, " !?.。！？"))]
+	if !ok {
+		return false
+	}
+	r := v.(*round)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.votes == nil || m.Time().After(r.end) {
+		return false
+	}
+	// Changing your mind is fine. Only the last vote counts.
+	r.votes[m.Sender.ID] = vote{name: m.Sender.Name, real: real}
+	return true
+}
+
+// RealOrRobot starts a round of real or robot. The bot posts either a real
+// message it has learned or one it generates, and chat votes on which it is.
+// No arguments.
+//
+// NOTE(zeph): RealOrRobot blocks until voting closes.
+func RealOrRobot(ctx context.Context, robo *Robot, call *Invocation) {
+	ch := call.Channel
+	if call.Message.Time().Before(ch.SilentTime()) {
+		robo.Log.InfoContext(ctx, "silent", slog.Time("until", ch.SilentTime()))
+		return
+	}
+	r := new(round)
+	if _, loaded := ch.Extra.LoadOrStore(roundKey{}, r); loaded {
+		ch.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(ch.Lang, "game.running")})
+		return
+	}
+	defer ch.Extra.CompareAndDelete(roundKey{}, r)
+	text, real, err := gameMessage(ctx, robo, call, r, rand.IntN(2) == 0)
+	if errors.Is(err, errors.ErrUnsupported) {
+		ch.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(ch.Lang, "game.unavailable")})
+		return
+	}
+	if text == "" {
+		ch.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(ch.Lang, "game.nothing")})
+		return
+	}
+	t := time.Now()
+	rs := ch.Rate.ReserveN(t, 1)
+	if d := rs.DelayFrom(t); d > 0 {
+		robo.Log.InfoContext(ctx, "won't play; rate limited",
+			slog.String("action", "command"),
+			slog.String("in", ch.Name),
+			slog.String("delay", d.String()),
+		)
+		rs.CancelAt(t)
+		return
+	}
+	window := robo.GameWindow
+	r.mu.Lock()
+	r.end = time.Now().Add(window)
+	r.votes = make(map[string]vote)
+	r.mu.Unlock()
+	robo.Log.InfoContext(ctx, "real or robot", slog.String("in", ch.Name), slog.String("text", text), slog.Bool("real", real))
+	ch.Message(ctx, message.Sent{Text: lenlimit(Text(ch.Lang, "game.start", text, int(window.Seconds())), 450)})
+
+	tm := time.NewTimer(window)
+	select {
+	case <-ctx.Done():
+		tm.Stop()
+		return
+	case <-tm.C:
+	}
+	ch.Extra.CompareAndDelete(roundKey{}, r)
+	r.mu.Lock()
+	results := make([]leaderboard.Result, 0, len(r.votes))
+	right := 0
+	for id, v := range r.votes {
+		results = append(results, leaderboard.Result{ID: id, Name: v.name, Correct: v.real == real})
+		if v.real == real {
+			right++
+		}
+	}
+	r.mu.Unlock()
+	if robo.Leaderboard != nil && len(results) != 0 {
+		if err := robo.Leaderboard.Record(ctx, ch.Name, results); err != nil {
+			robo.Log.ErrorContext(ctx, "couldn't record game results", slog.Any("err", err))
+		}
+	}
+	key := "game.robot"
+	if real {
+		key = "game.real"
+	}
+	ch.Message(ctx, message.Sent{Text: Text(ch.Lang, key, right, len(results))})
+	if !real {
+		// Record the robot's message only now, so that commands which look
+		// at the bot's last message couldn't give away the answer.
+		if err := robo.Spoken.Record(ctx, ch.Send, text, r.trace, time.Now(), r.cost, text, "", "cmd real-or-robot", ""); err != nil {
+			robo.Log.ErrorContext(ctx, "couldn't record trace", slog.Any("err", err))
+		}
+	}
+}
+
+// gameMessage chooses the message for a round of real or robot.
+// It falls back to the other kind of message if it can't get the first.
+// The result is empty if neither works. If the brain can't provide real
+// messages at all, the error wraps [errors.ErrUnsupported]; every round would
+// be the robot, so the game can't be played.
+func gameMessage(ctx context.Context, robo *Robot, call *Invocation, r *round, real bool) (string, bool, error) {
+	for range 2 {
+		var (
+			text string
+			err  error
+		)
+		if real {
+			text, err = robo.RealMessage(ctx, call.Channel)
+			switch {
+			case errors.Is(err, errors.ErrUnsupported):
+				return "", false, err
+			case err != nil:
+				robo.Log.ErrorContext(ctx, "couldn't get real message", slog.Any("err", err))
+			}
+		} else {
+			text = gameThink(ctx, robo, call, r)
+		}
+		if text != "" {
+			return text, real, nil
+		}
+		real = !real
+	}
+	return "", false, nil
+}
+
+// gameThink generates a message for a round of real or robot.
+// It saves the trace in the round to record once the round is over.
+func gameThink(ctx context.Context, robo *Robot, call *Invocation, r *round) string {
+	ch := call.Channel
+	start := time.Now()
+	m, trace, err := brain.ThinkWeighted(ctx, robo.Brain, ch.Send, "", robo.Weights(ctx, ch.Send))
+	cost := time.Since(start)
+	if err != nil {
+		robo.Log.ErrorContext(ctx, "couldn't think", slog.Any("err", err))
+		return ""
+	}
+	if m == "" || ch.Block.MatchString(m) {
+		return ""
+	}
+	r.trace, r.cost = trace, cost
+	return m
+}
+
+// Leaderboard shows the best players of real or robot in the channel.
+// No arguments.
+func Leaderboard(ctx context.Context, robo *Robot, call *Invocation) {
+	ch := call.Channel
+	if call.Message.Time().Before(ch.SilentTime()) {
+		robo.Log.InfoContext(ctx, "silent", slog.Time("until", ch.SilentTime()))
+		return
+	}
+	if robo.Leaderboard == nil {
+		return
+	}
+	top, err := robo.Leaderboard.Top(ctx, ch.Name, 5)
+	if err != nil {
+		robo.Log.ErrorContext(ctx, "couldn't get leaderboard", slog.Any("err", err))
+		return
+	}
+	if len(top) == 0 {
+		ch.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(ch.Lang, "game.noscores")})
+		return
+	}
+	l := make([]string, len(top))
+	for i, e := range top {
+		l[i] = fmt.Sprintf("%d. %s %d/%d", i+1, e.Name, e.Correct, e.Played)
+	}
+	s := lenlimit(Text(ch.Lang, "game.leaderboard", strings.Join(l, ", ")), 450)
+	ch.Message(ctx, message.Sent{Reply: call.Message.ID, Text: s})
+}
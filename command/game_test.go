@@ -0,0 +1,94 @@
+package command
+
+import (
+	"context"
+	"iter"
+	"log/slog"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/zephyrtronium/robot/brain"
+	"github.com/zephyrtronium/robot/channel"
+	"github.com/zephyrtronium/robot/message"
+	"github.com/zephyrtronium/robot/spoken"
+)
+
+// parrot is a brain which always thinks of the same message.
+type parrot struct {
+	brain.Interface
+	text string
+}
+
+func (p *parrot) Think(ctx context.Context, tag string, prompt []string) iter.Seq[func(id, suf *[]byte) error] {
+	return func(yield func(func(id, suf *[]byte) error) bool) {
+		if len(prompt) != 0 {
+			return
+		}
+		yield(func(id, suf *[]byte) error {
+			*id = append(*id, "1"...)
+			*suf = append(*suf, p.text...)
+			return nil
+		})
+	}
+}
+
+func TestRealOrRobotHidden(t *testing.T) {
+	ctx := context.Background()
+	h, err := spoken.Open(ctx, testDB())
+	if err != nil {
+		t.Fatal(err)
+	}
+	robo := Robot{
+		Name:        "robot",
+		Log:         slog.New(slog.DiscardHandler),
+		Brain:       &parrot{text: "bocchi the rock"},
+		Spoken:      h,
+		RealMessage: func(ctx context.Context, ch *channel.Channel) (string, error) { return "", nil },
+		Weights:     func(ctx context.Context, tag string) brain.Weights { return nil },
+		GameWindow:  time.Millisecond,
+	}
+	var (
+		during string
+		sent   int
+	)
+	ch := &channel.Channel{
+		Send:   "kessoku",
+		Block:  channel.NewBlocker(regexp.MustCompile(`$^`)),
+		Rate:   rate.NewLimiter(rate.Inf, 1),
+		Silent: new(atomic.Int64),
+		Extra:  new(sync.Map),
+	}
+	ch.Message = func(ctx context.Context, msg message.Sent) {
+		sent++
+		if sent != 1 {
+			return
+		}
+		// While voting is open, the bot's last message must not be the
+		// round's message.
+		call := Invocation{Channel: ch, Message: &message.Received[message.User]{}}
+		during, err = repliedText(ctx, &robo, &call)
+		if err != nil {
+			t.Errorf("couldn't get last message during round: %v", err)
+		}
+	}
+	call := Invocation{Channel: ch, Message: &message.Received[message.User]{Timestamp: time.Now().UnixMilli()}}
+	RealOrRobot(ctx, &robo, &call)
+	if sent != 2 {
+		t.Fatalf("wrong number of messages: want 2, got %d", sent)
+	}
+	if during != "" {
+		t.Errorf("round's message %q was visible during voting", during)
+	}
+	after, err := repliedText(ctx, &robo, &Invocation{Channel: ch, Message: &message.Received[message.User]{}})
+	if err != nil {
+		t.Errorf("couldn't get last message after round: %v", err)
+	}
+	if after != "bocchi the rock" {
+		t.Errorf("wrong last message after round: want %q, got %q", "bocchi the rock", after)
+	}
+}
@@ -0,0 +1,94 @@
+package command
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/zephyrtronium/robot/message"
+)
+
+// Usage describes a command for the help command.
+type Usage struct {
+	// Name is the name of the command.
+	Name string
+	// Desc is a short description of what the command does.
+	Desc string
+	// Examples are example phrasings of the command.
+	Examples []string
+}
+
+// Help lists the commands available to the invoker, or describes one command.
+//   - command: Name of the command to describe. Optional.
+func Help(ctx context.Context, robo *Robot, call *Invocation) {
+	if robo.Usage == nil {
+		robo.Log.ErrorContext(ctx, "no usage for help")
+		return
+	}
+	cmds := robo.Usage()
+	if name := call.Args["command"]; name != "" {
+		k := slices.IndexFunc(cmds, func(u Usage) bool { return strings.EqualFold(u.Name, name) })
+		if k < 0 {
+			call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "help.unknown", name)})
+			return
+		}
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: lenlimit(describeUsage(call.Channel.Lang, cmds[k]), 450)})
+		return
+	}
+	names := make([]string, len(cmds))
+	for i, u := range cmds {
+		names[i] = u.Name
+	}
+	for _, s := range helpList(call.Channel.Lang, names, 450) {
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: s})
+	}
+}
+
+// describeUsage formats the description of a single command.
+func describeUsage(lang string, u Usage) string {
+	var b strings.Builder
+	b.WriteString(u.Name)
+	b.WriteString(": ")
+	b.WriteString(u.Desc)
+	for i, e := range u.Examples {
+		if i == 0 {
+			b.WriteString(" " + Text(lang, "help.try") + " ")
+		} else {
+			b.WriteString(" " + Text(lang, "help.or") + " ")
+		}
+		b.WriteByte('"')
+		b.WriteString(e)
+		b.WriteByte('"')
+	}
+	return b.String()
+}
+
+// helpList formats a list of command names into messages no longer than lim
+// bytes each.
+func helpList(lang string, names []string, lim int) []string {
+	head := Text(lang, "help.list")
+	tail := " " + Text(lang, "help.more")
+	var r []string
+	var b strings.Builder
+	b.WriteString(head)
+	for i, n := range names {
+		sep := ", "
+		if i == 0 {
+			sep = " "
+		}
+		if b.Len()+len(sep)+len(n) > lim {
+			r = append(r, b.String())
+			b.Reset()
+			sep = ""
+		}
+		b.WriteString(sep)
+		b.WriteString(n)
+	}
+	if b.Len()+len(tail) > lim {
+		r = append(r, b.String())
+		b.Reset()
+	}
+	b.WriteString(tail)
+	r = append(r, strings.TrimSpace(b.String()))
+	return r
+}
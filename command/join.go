@@ -0,0 +1,34 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/zephyrtronium/robot/message"
+)
+
+// Join joins a channel at runtime.
+//   - channel: Name of the channel to join.
+func Join(ctx context.Context, robo *Robot, call *Invocation) {
+	name, err := robo.Join(ctx, call.Args["channel"])
+	if err != nil {
+		robo.Log.ErrorContext(ctx, "join failed", slog.String("target", name), slog.Any("err", err))
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "join.error", name, err)})
+		return
+	}
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "join.done", name)})
+}
+
+// Part leaves a channel that was joined at runtime.
+//   - channel: Name of the channel to leave.
+func Part(ctx context.Context, robo *Robot, call *Invocation) {
+	name, err := robo.Part(ctx, call.Args["channel"])
+	if err != nil {
+		robo.Log.ErrorContext(ctx, "part failed", slog.String("target", name), slog.Any("err", err))
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "part.error", name, err)})
+		return
+	}
+	if name != call.Channel.Name {
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "part.done", name)})
+	}
+}
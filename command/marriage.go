@@ -2,7 +2,6 @@ package command
 
 import (
 	"context"
-	"fmt"
 	"log/slog"
 	"math"
 	"math/rand/v2"
@@ -10,8 +9,6 @@ import (
 	"sync"
 	"time"
 
-	"gitlab.com/zephyrtronium/pick"
-
 	"github.com/zephyrtronium/robot/channel"
 	"github.com/zephyrtronium/robot/message"
 )
@@ -64,17 +61,6 @@ func score(log *slog.Logger, h *channel.History[*message.Received[message.User]]
 
 type broadcasterAffectionKey struct{}
 
-var affections = pick.New([]pick.Case[string]{
-	{E: "about %[1]f %[2]s", W: 5},
-	{E: "roughly %[1]f %[2]s", W: 5},
-	{E: "%[1]f or so %[2]s", W: 5},
-	{E: "approximately %[1]f %[2]s", W: 5},
-	{E: "I have calculated my affection for you to be exactly %[1]f %[2]s", W: 1},
-	{E: "Right now, I'd say %[1]f. But who knows what the future may hold? %[2]s", W: 1},
-	{E: "%[1]f, and yes, that is a threat. %[2]s", W: 1},
-	{E: "%[1]f, given score = c²/(f+1) + (c+1)f + √l, f=%[4]d from your messages sent, l=%[5]d from length of your longest message, and c=%[3]d from memes, across %[6]d messages in the last fifteen minutes %[2]s", W: 1},
-})
-
 // Affection describes the caller's affection MMR.
 // No arguments.
 func Affection(ctx context.Context, robo *Robot, call *Invocation) {
@@ -82,28 +68,25 @@ func Affection(ctx context.Context, robo *Robot, call *Invocation) {
 		robo.Log.InfoContext(ctx, "silent", slog.Time("until", call.Channel.SilentTime()))
 		return
 	}
-	x, c, f, l, n := score(robo.Log, &call.Channel.History, call.Message.Sender.ID)
+	x, c, f, l, n := score(robo.Log, call.Channel.History, call.Message.Sender.ID)
 	// Anything we do will require an emote.
 	e := call.Channel.Emotes.Pick(rand.Uint32())
 	if x == 0 {
 		// Check for the broadcaster. They get special treatment.
 		if strings.EqualFold(call.Message.Sender.Name, strings.TrimPrefix(call.Channel.Name, "#")) {
 			if _, ok := call.Channel.Extra.LoadOrStore(broadcasterAffectionKey{}, struct{}{}); ok {
-				call.Channel.Message(ctx, message.Format("Don't make me repeat myself, it's embarrassing! %s", e).AsReply(call.Message.ID))
+				call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "affection.repeat", e)})
 				return
 			}
-			const funnyMessage = `It's a bit awkward to think of you like that, streamer... But, well, it's so fun to be here, and I have you to thank for that! So I'd say a whole bunch! %s`
-			call.Channel.Message(ctx, message.Format(funnyMessage, e).AsReply(call.Message.ID))
+			call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "affection.broadcaster", e)})
 			return
 		}
 		// possible!
-		call.Channel.Message(ctx, message.Format("literally zero %s", e).AsReply(call.Message.ID))
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "affection.zero", e)})
 		return
 	}
-	s := affections.Pick(rand.Uint32())
-	// The single scenario where message.Format requiring a constant formatting
-	// string is a drawback:
-	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: fmt.Sprintf(s, x, e, c, f, l, n)})
+	s := Text(call.Channel.Lang, "affection", x, e, c, f, l, n)
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: s})
 }
 
 type (
@@ -135,11 +118,11 @@ func Marry(ctx context.Context, robo *Robot, call *Invocation) {
 		robo.Log.InfoContext(ctx, "silent", slog.Time("until", call.Channel.SilentTime()))
 		return
 	}
-	x, _, _, _, _ := score(robo.Log, &call.Channel.History, call.Message.Sender.ID)
+	x, _, _, _, _ := score(robo.Log, call.Channel.History, call.Message.Sender.ID)
 	e := call.Channel.Emotes.Pick(rand.Uint32())
 	broadcaster := strings.EqualFold(call.Message.Sender.Name, strings.TrimPrefix(call.Channel.Name, "#")) && x == 0
 	if x < 10 && !broadcaster {
-		call.Channel.Message(ctx, message.Format("no %s", e).AsReply(call.Message.ID))
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "marry.no", e)})
 		return
 	}
 	me := &suitor{
@@ -153,14 +136,15 @@ func Marry(ctx context.Context, robo *Robot, call *Invocation) {
 		dislike := dislikemap(call.Channel)
 		u, _ := dislike.Load(me.who)
 		if u, _ := u.(*suitor); u != nil && call.Message.Time().Before(u.until) {
-			call.Channel.Message(ctx, message.Format("Absolutely not. At least not for the next %v. %s", u.until.Sub(call.Message.Time()).Truncate(time.Millisecond), e).AsReply(call.Message.ID))
+			wait := u.until.Sub(call.Message.Time()).Truncate(time.Millisecond)
+			call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "marry.disliked", wait, e)})
 			return
 		}
 		// Now we can compete.
 		l, ok := call.Channel.Extra.LoadOrStore(partnerKey{}, me)
 		if !ok {
 			// No competition. We're a shoo-in.
-			call.Channel.Message(ctx, message.Format("sure why not %s", e).AsReply(call.Message.ID))
+			call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "marry.first", e)})
 			return
 		}
 		cur := l.(*suitor)
@@ -173,19 +157,19 @@ func Marry(ctx context.Context, robo *Robot, call *Invocation) {
 					continue
 				}
 				dislike.Store(me.who, me)
-				call.Channel.Message(ctx, message.Format("How could you forget we're already together? I hate you! Unsubbed, unfollowed, unloved! %s", e).AsReply(call.Message.ID))
+				call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "marry.forgot", e)})
 				return
 			}
-			call.Channel.Message(ctx, message.Format("We're already together, silly! You're so funny and cute haha. %s", e).AsReply(call.Message.ID))
+			call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "marry.already", e)})
 			return
 		}
 		if call.Message.Time().Before(cur.until) {
-			call.Channel.Message(ctx, message.Format("My heart yet belongs to %s... %s", cur.name, e).AsReply(call.Message.ID))
+			call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "marry.taken", cur.name, e)})
 			return
 		}
-		y, _, _, _, _ := score(robo.Log, &call.Channel.History, cur.who)
+		y, _, _, _, _ := score(robo.Log, call.Channel.History, cur.who)
 		if x < y && !broadcaster {
-			call.Channel.Message(ctx, message.Format("I'm touched, but I must decline. I'm in love with %s. %s", cur.name, e).AsReply(call.Message.ID))
+			call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "marry.decline", cur.name, e)})
 			return
 		}
 		if !call.Channel.Extra.CompareAndSwap(partnerKey{}, cur, me) {
@@ -195,9 +179,9 @@ func Marry(ctx context.Context, robo *Robot, call *Invocation) {
 		// We win. Now just decide which message to send.
 		// TODO(zeph): since pick.Dist exists now, we could randomize
 		if call.Args["partnership"] != "" {
-			call.Channel.Message(ctx, message.Format("Yes! I'll be your %s! %s", call.Args["partnership"], e).AsReply(call.Message.ID))
+			call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "marry.yes.kind", call.Args["partnership"], e)})
 		} else {
-			call.Channel.Message(ctx, message.Format("Yes! I'll marry you! %s", e).AsReply(call.Message.ID))
+			call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "marry.yes", e)})
 		}
 		return
 	}
@@ -214,14 +198,14 @@ func ILoveMyWife(ctx context.Context, robo *Robot, call *Invocation) {
 	e := call.Channel.Emotes.Pick(rand.Uint32())
 	broadcaster := strings.EqualFold(call.Message.Sender.Name, strings.TrimPrefix(call.Channel.Name, "#"))
 	if broadcaster {
-		call.Channel.Message(ctx, message.Format(`I'm so happy you feel that way about me! I love you and your community, too! %s`, e).AsReply(call.Message.ID))
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "love.broadcaster", e)})
 		return
 	}
 	for {
 		l, _ := call.Channel.Extra.Load(partnerKey{})
 		cur, _ := l.(*suitor)
 		if cur == nil {
-			call.Channel.Message(ctx, message.Format("Ok... Kinda weird... %s", e).AsReply(call.Message.ID))
+			call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "love.weird", e)})
 			return
 		}
 		if cur.who != call.Message.Sender.ID {
@@ -232,7 +216,7 @@ func ILoveMyWife(ctx context.Context, robo *Robot, call *Invocation) {
 				until: call.Message.Time().Add(time.Hour),
 			}
 			dislikemap(call.Channel).Store(me.who, me)
-			call.Channel.Message(ctx, message.Format("Yeah? Why don't you tell my sweetheart %s about that. Weirdo. %s", cur.name, e).AsReply(call.Message.ID))
+			call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "love.cheat", cur.name, e)})
 			return
 		}
 		new := *cur
@@ -242,9 +226,9 @@ func ILoveMyWife(ctx context.Context, robo *Robot, call *Invocation) {
 			continue
 		}
 		if cur.kind != "" {
-			call.Channel.Message(ctx, message.Format("I'm so happy to be your %s! %s", cur.kind, e).AsReply(call.Message.ID))
+			call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "love.kind", cur.kind, e)})
 		} else {
-			call.Channel.Message(ctx, message.Format("I love you! %s", e).AsReply(call.Message.ID))
+			call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "love", e)})
 		}
 		return
 	}
@@ -254,9 +238,8 @@ func ILoveMyWife(ctx context.Context, robo *Robot, call *Invocation) {
 // No args.
 func DescribeMarriage(ctx context.Context, robo *Robot, call *Invocation) {
 	if t := call.Channel.SilentTime(); call.Message.Time().Before(t) {
-		call.Channel.Message(ctx, message.Format("I'm being quiet for the next %v, so the marriage system is disabled until then.", t.Sub(call.Message.Time())).AsReply(call.Message.ID))
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "marriage.quiet", t.Sub(call.Message.Time()))})
 		return
 	}
-	const s = `I am looking for a long series of short-term relationships and am holding a ranked competitive how-much-I-like-you tournament to decide my suitors! Politely ask me to marry you (or become your partner) and I'll evaluate your score. I like copypasta, memes, and long walks in the chat.`
-	call.Channel.Message(ctx, message.Sent{Text: s})
+	call.Channel.Message(ctx, message.Sent{Text: Text(call.Channel.Lang, "marriage")})
 }
@@ -1,123 +1,297 @@
 package command
 
 import (
+	"cmp"
 	"context"
+	"errors"
+	"iter"
 	"log/slog"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/zephyrtronium/robot/audit"
+	"github.com/zephyrtronium/robot/brain"
+	"github.com/zephyrtronium/robot/channel"
 	"github.com/zephyrtronium/robot/message"
+	"github.com/zephyrtronium/robot/silence"
 )
 
 // Forget makes the bot unlearn recent messages containing a term.
 //   - term: Substring to search. If empty, all messages are matched.
 func Forget(ctx context.Context, robo *Robot, call *Invocation) {
-	h := call.Channel.History.All()
 	term := strings.ToLower(call.Args["term"])
-	n := 0
-	for m := range h {
-		if !strings.Contains(strings.ToLower(m.Text), term) {
-			continue
+	ids := forgetMatching(ctx, robo, call.Channel, term)
+	if len(ids) != 0 {
+		// Only record forgets that did something, so that undo forget
+		// doesn't pick up an empty batch over an earlier real one.
+		record(ctx, robo, audit.Entry{
+			Time:     call.Message.Time(),
+			Actor:    call.Message.Sender.Name,
+			Channel:  call.Channel.Name,
+			Action:   "forget",
+			Reason:   term,
+			Tag:      call.Channel.Learn,
+			Messages: ids,
+		})
+	}
+	var r string
+	switch n := len(ids); n {
+	case 0:
+		r = Text(call.Channel.Lang, "forget.none", term)
+	case 1:
+		r = Text(call.Channel.Lang, "forget.one")
+	default:
+		r = Text(call.Channel.Lang, "forget.many", n)
+	}
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: r})
+}
+
+// ForgetUser makes the bot unlearn messages from a user over a window.
+//   - user: Login of the user to forget.
+//   - dur: Window over which to forget. Optional; the configured limit if empty.
+func ForgetUser(ctx context.Context, robo *Robot, call *Invocation) {
+	var window time.Duration
+	if call.Args["dur"] != "" {
+		var err error
+		window, err = parseDuration(call.Args["dur"])
+		if err != nil {
+			call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "sorry", err)})
+			return
 		}
-		n++
+	}
+	user := call.Args["user"]
+	n, window, err := robo.ForgetUser(ctx, call.Channel, call.Message.Sender.Name, user, window)
+	switch {
+	case errors.Is(err, errors.ErrUnsupported):
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "forget.user.unsupported")})
+		return
+	case err != nil && n == 0:
+		robo.Log.ErrorContext(ctx, "forget user failed", slog.String("user", user), slog.Any("err", err))
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "forget.user.error", user, err)})
+		return
+	case err != nil:
+		robo.Log.ErrorContext(ctx, "forget user partially failed", slog.String("user", user), slog.Any("err", err))
+	}
+	var r string
+	switch n {
+	case 0:
+		r = Text(call.Channel.Lang, "forget.user.none", user, window)
+	case 1:
+		r = Text(call.Channel.Lang, "forget.user.one", user, window)
+	default:
+		r = Text(call.Channel.Lang, "forget.user.many", n, user, window)
+	}
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: r})
+}
+
+// UndoForget restores the messages forgotten by the most recent forget command
+// in the channel, if it was recent enough.
+// No arguments.
+func UndoForget(ctx context.Context, robo *Robot, call *Invocation) {
+	n, total, err := robo.UndoForget(ctx, call.Channel, call.Message.Sender.Name)
+	switch {
+	case errors.Is(err, errors.ErrUnsupported):
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "undo.unsupported")})
+		return
+	case err != nil && n == 0:
+		robo.Log.ErrorContext(ctx, "undo forget failed", slog.Any("err", err))
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "undo.error")})
+		return
+	case err != nil:
+		robo.Log.ErrorContext(ctx, "undo forget partially failed", slog.Any("err", err))
+	}
+	var r string
+	switch {
+	case total == 0:
+		r = Text(call.Channel.Lang, "undo.none")
+	case n == total:
+		r = Text(call.Channel.Lang, "undo.all", n, total)
+	default:
+		// Some messages can't come back, e.g. because they were also
+		// deleted in chat or the sender is private.
+		r = Text(call.Channel.Lang, "undo.some", n, total)
+	}
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: r})
+}
+
+// forgetMatching forgets recent messages in a channel containing a term,
+// ignoring case. It returns the IDs of the messages forgotten.
+func forgetMatching(ctx context.Context, robo *Robot, ch *channel.Channel, term string) []string {
+	var ids []string
+	for m := range matching(ch, term) {
+		ids = append(ids, m.ID)
 		robo.Log.DebugContext(ctx, "forget",
-			slog.String("tag", call.Channel.Learn),
+			slog.String("tag", ch.Learn),
 			slog.String("id", m.ID),
 		)
 		robo.Metrics.ForgotCount.Observe(1)
-		err := robo.Brain.Forget(ctx, call.Channel.Learn, m.ID)
+		err := brain.ForgetFor(ctx, robo.Brain, ch.Learn, m.ID, brain.Moderated)
 		if err != nil {
 			robo.Log.ErrorContext(ctx, "failed to forget",
 				slog.Any("err", err),
-				slog.String("tag", call.Channel.Learn),
+				slog.String("tag", ch.Learn),
 				slog.String("id", m.ID),
 			)
 		}
 	}
-	var r message.Sent
-	switch n {
-	case 0:
-		r = message.Format("No messages contained %q.", term)
-	case 1:
-		r = message.Format("Forgot 1 message.")
-	default:
-		r = message.Format("Forgot %d messages.", n)
+	if robo.DropQuotes != nil {
+		robo.DropQuotes(ctx, ids...)
+	}
+	return ids
+}
+
+// record adds an entry to the audit log, if there is one.
+func record(ctx context.Context, robo *Robot, e audit.Entry) {
+	if robo.Audit == nil {
+		return
+	}
+	if err := robo.Audit.Record(ctx, e); err != nil {
+		robo.Log.ErrorContext(ctx, "failed to record audit entry", slog.String("action", e.Action), slog.Any("err", err))
+	}
+}
+
+// matching iterates over recent messages in a channel containing a term,
+// matched the same way as blocked terms. The term must already be lowercase.
+func matching(ch *channel.Channel, term string) iter.Seq[*message.Received[message.User]] {
+	return func(yield func(*message.Received[message.User]) bool) {
+		for m := range ch.History.All() {
+			if !channel.ContainsTerm(m.Text, term) {
+				continue
+			}
+			if !yield(m) {
+				return
+			}
+		}
 	}
-	call.Channel.Message(ctx, r.AsReply(call.Message.ID))
 }
 
 // Quiet makes the bot temporarily stop learning and speaking in the channel.
 //   - dur: Duration to stop learning and speaking. Optional.
-//   - until: Marker to stop "until tomrrow" if not empty. Optional.
+//   - until: "tomorrow", or a phrase ending quiet time when the stream goes
+//     offline or at the next stream. Optional.
 //
-// NOTE(zeph): Quiet waits for a timer which can be up to twelve hours.
+// NOTE(zeph): Quiet waits for a timer which can be up to twelve hours, or
+// up to two days for quiet time tied to the stream.
+// The quiet time itself survives restarts.
 func Quiet(ctx context.Context, robo *Robot, call *Invocation) {
 	var dur time.Duration
+	var event silence.Event
+	until := strings.ToLower(call.Args["until"])
 	switch {
-	case call.Args["dur"] == "" && call.Args["until"] == "":
+	case call.Args["dur"] == "" && until == "":
 		dur = 2 * time.Hour
-	case call.Args["until"] != "":
-		// The only "until" option right now is "tomorrow".
+	case until == "tomorrow":
 		dur = 12 * time.Hour
-	default:
-		if m := quietA.FindStringSubmatch(call.Args["dur"]); m != nil {
-			switch m[1][0] {
-			case 'h', 'H':
-				dur = time.Hour
-			default:
-				dur = time.Minute
-			}
-			break
-		}
-		if m := quietN.FindStringSubmatch(call.Args["dur"]); m != nil {
-			n, err := strconv.Atoi(m[1])
-			if err != nil {
-				// Should be impossible.
-				call.Channel.Message(ctx, message.Format(`sorry? (%v)`, err).AsReply(call.Message.ID))
-				return
-			}
-			switch m[2][0] {
-			case 'h', 'H':
-				dur = time.Hour * time.Duration(n)
-			default:
-				dur = time.Minute * time.Duration(n)
-			}
-			break
+	case strings.Contains(until, "next"):
+		dur, event = maxStreamQuiet, silence.NextStream
+	case until != "":
+		if !call.Channel.Enabled.Load() {
+			call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "quiet.notlive")})
+			return
 		}
+		dur, event = maxStreamQuiet, silence.Offline
+	default:
 		var err error
-		dur, err = time.ParseDuration(call.Args["dur"])
+		dur, err = parseDuration(call.Args["dur"])
 		if err != nil {
-			call.Channel.Message(ctx, message.Format(`sorry? (%v)`, err).AsReply(call.Message.ID))
+			call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "sorry", err)})
 			return
 		}
 	}
-	if dur > 12*time.Hour {
+	if dur > 12*time.Hour && event == "" {
 		dur = 12 * time.Hour
 	}
-	n := call.Message.Time().Add(dur).UnixNano()
-	call.Channel.Silent.Store(n)
-	robo.Log.InfoContext(ctx, "silent", slog.Duration("duration", dur), slog.Time("until", call.Channel.SilentTime()))
+	end := call.Message.Time().Add(dur)
+	robo.Log.InfoContext(ctx, "silent", slog.Duration("duration", dur), slog.Time("until", end), slog.String("event", string(event)))
+	record(ctx, robo, audit.Entry{
+		Time:    call.Message.Time(),
+		Actor:   call.Message.Sender.Name,
+		Channel: call.Channel.Name,
+		Action:  "quiet",
+		Reason:  cmp.Or(string(event), dur.String()),
+	})
 	// Only do the spiel if the timer isn't very short.
 	// Otherwise it's likely just clearing an existing silent time.
-	if dur > 5*time.Second {
-		call.Channel.Message(ctx, message.Format(`I won't talk or learn for %v. Some commands relating to moderation and privacy will still make me talk. I'll mention when quiet time is up.`, dur).AsReply(call.Message.ID))
+	spiel := Text(call.Channel.Lang, "quiet.spiel")
+	switch {
+	case event == silence.Offline:
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "quiet.offline", spiel)})
+	case event != "":
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "quiet.stream", spiel)})
+	case dur > 5*time.Second:
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "quiet.for", dur, spiel)})
 	}
-	t := time.NewTimer(dur)
-	defer t.Stop()
-	select {
-	case <-ctx.Done():
-		return
-	case <-t.C:
-		// If the actual quiet time is different from what we set it to, then
-		// another instance of this command ran and changed it to something else.
-		// Don't message in that case.
-		if call.Channel.Silent.Load() != n {
-			return
+	robo.Quiet(ctx, call.Channel, call.Message.Sender.Name, end, event)
+}
+
+// QuietTime reports how much longer the bot will be quiet in the channel.
+// No arguments.
+func QuietTime(ctx context.Context, robo *Robot, call *Invocation) {
+	var s string
+	if t := call.Channel.SilentTime(); call.Message.Time().Before(t) {
+		s = Text(call.Channel.Lang, "quiet.left", t.Sub(call.Message.Time()).Round(time.Second))
+	} else {
+		s = Text(call.Channel.Lang, "quiet.not")
+	}
+	if q := nextQuiet(call.Channel.Lang, call.Channel.Quiet, call.Message.Time()); q != "" {
+		s += " " + q
+	}
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: s})
+}
+
+// nextQuiet describes the next of a channel's recurring quiet hours that
+// starts after now, or returns the empty string if it has none.
+func nextQuiet(lang string, q *channel.QuietHours, now time.Time) string {
+	start, end, ok := q.Next(now)
+	if ok && !start.After(now) {
+		// Quiet hours were ended early. Describe the next ones instead.
+		start, end, ok = q.Next(end)
+	}
+	if !ok {
+		return ""
+	}
+	start, end = start.In(q.Loc), end.In(q.Loc)
+	e := end.Format("15:04 MST")
+	if end.Sub(start) >= 24*time.Hour || end.Day() != start.Day() {
+		e = end.Format("Monday 15:04 MST")
+	}
+	return Text(lang, "quiet.hours", start.Format("Monday 15:04"), e)
+}
+
+// maxStreamQuiet is the longest that quiet time tied to the stream lasts, in
+// case the stream's status can't be checked.
+const maxStreamQuiet = 48 * time.Hour
+
+// parseDuration parses a duration as matched by the duration patterns in
+// command regular expressions, e.g. "an hour", "5 min", or "1h30m".
+func parseDuration(s string) (time.Duration, error) {
+	if m := quietA.FindStringSubmatch(s); m != nil {
+		switch m[1][0] {
+		case 'h', 'H':
+			return time.Hour, nil
+		default:
+			return time.Minute, nil
+		}
+	}
+	if m := quietN.FindStringSubmatch(s); m != nil {
+		n, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			// Too many digits to fit in an int64.
+			return 0, err
+		}
+		unit := time.Minute
+		if c := m[2][0]; c == 'h' || c == 'H' {
+			unit = time.Hour
+		}
+		if n > math.MaxInt64/int64(unit) {
+			return 0, errors.New("duration is too long")
 		}
-		call.Channel.Message(ctx, message.Format(`@%s My quiet time has ended.`, call.Message.Sender.Name))
+		return unit * time.Duration(n), nil
 	}
+	return time.ParseDuration(s)
 }
 
 var (
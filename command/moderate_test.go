@@ -0,0 +1,45 @@
+package command
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	cases := []struct {
+		in   string
+		want time.Duration
+		err  bool
+	}{
+		{"an hour", time.Hour, false},
+		{"a min", time.Minute, false},
+		{"A HOUR", time.Hour, false},
+		{"an hr", time.Hour, false},
+		{"1 minute", time.Minute, false},
+		{"5 min", 5 * time.Minute, false},
+		{"2 hours", 2 * time.Hour, false},
+		{"0 min", 0, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"90s", 90 * time.Second, false},
+		{"-5m", -5 * time.Minute, false},
+		{"", 0, true},
+		{"an", 0, true},
+		{"5", 0, true},
+		{"ryo", 0, true},
+		{"99999999999999999999 min", 0, true},
+		{"9999999 hours", 0, true},
+		{"200000000 min", 0, true},
+		{"2562047 hours", 2562047 * time.Hour, false},
+	}
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			got, err := parseDuration(c.in)
+			if (err != nil) != c.err {
+				t.Errorf("wrong error: want error %t, got %v", c.err, err)
+			}
+			if !c.err && got != c.want {
+				t.Errorf("wrong duration: want %v, got %v", c.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zephyrtronium/robot/audit"
+	"github.com/zephyrtronium/robot/message"
+)
+
+// QuoteThat saves one of the bot's messages to the channel's quote book.
+// If the invocation replies to a message, that message is quoted; otherwise
+// the bot's most recent message is.
+// No arguments.
+func QuoteThat(ctx context.Context, robo *Robot, call *Invocation) {
+	if call.Message.Time().Before(call.Channel.SilentTime()) {
+		robo.Log.InfoContext(ctx, "silent", slog.Time("until", call.Channel.SilentTime()))
+		return
+	}
+	text, err := repliedText(ctx, robo, call)
+	if err != nil {
+		robo.Log.ErrorContext(ctx, "couldn't find previous message to quote", slog.Any("err", err))
+		return
+	}
+	var num int
+	if text != "" {
+		num, err = robo.Spoken.AddQuote(ctx, call.Channel.Send, text, call.Message.Sender.Name)
+		if err != nil {
+			robo.Log.ErrorContext(ctx, "couldn't add quote", slog.Any("err", err))
+			return
+		}
+	}
+	if num == 0 {
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "quote.unknown")})
+		return
+	}
+	robo.Log.InfoContext(ctx, "quote", slog.String("in", call.Channel.Name), slog.Int("num", num), slog.String("text", text))
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "quote.saved", num)})
+}
+
+// repliedText gets the text of the message to which an invocation replies,
+// or the bot's most recent message if it isn't a reply.
+// The result is empty if the bot hasn't said anything or the invocation
+// replies to someone else's message.
+func repliedText(ctx context.Context, robo *Robot, call *Invocation) (string, error) {
+	if p := call.Message.Parent; p != nil {
+		// Quotes are found by text, so make sure it's the bot's text and not
+		// someone else's that happens to match something the bot said.
+		if !strings.EqualFold(p.Sender, robo.Name) {
+			return "", nil
+		}
+		return p.Text, nil
+	}
+	var text string
+	it, errf := robo.Spoken.Previous(ctx, call.Channel.Send, 1)
+	for m := range it {
+		text = m.Text
+	}
+	return text, errf()
+}
+
+// ShowQuote shows a quote from the channel's quote book.
+//   - num: Number of the quote to show. Optional; a random quote if empty.
+func ShowQuote(ctx context.Context, robo *Robot, call *Invocation) {
+	if call.Message.Time().Before(call.Channel.SilentTime()) {
+		robo.Log.InfoContext(ctx, "silent", slog.Time("until", call.Channel.SilentTime()))
+		return
+	}
+	var num int
+	if s := call.Args["num"]; s != "" {
+		var err error
+		num, err = strconv.Atoi(s)
+		if err != nil || num <= 0 {
+			call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "quote.missing", s)})
+			return
+		}
+	}
+	q, err := robo.Spoken.Quote(ctx, call.Channel.Send, num)
+	if err != nil {
+		robo.Log.ErrorContext(ctx, "couldn't get quote", slog.Any("err", err))
+		return
+	}
+	switch {
+	case q.Num == 0 && num == 0:
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "quote.none")})
+		return
+	case q.Num == 0:
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "quote.missing", strconv.Itoa(num))})
+		return
+	}
+	// The block list may have changed since the quote was saved.
+	if call.Channel.Block.MatchString(q.Text) {
+		robo.Log.WarnContext(ctx, "quote is blocked",
+			slog.String("in", call.Channel.Name),
+			slog.Int("num", q.Num),
+			slog.String("text", q.Text),
+		)
+		return
+	}
+	t := time.Now()
+	r := call.Channel.Rate.ReserveN(t, 1)
+	if d := r.DelayFrom(t); d > 0 {
+		robo.Log.InfoContext(ctx, "won't quote; rate limited",
+			slog.String("action", "command"),
+			slog.String("in", call.Channel.Name),
+			slog.String("delay", d.String()),
+		)
+		r.CancelAt(t)
+		return
+	}
+	s := lenlimit(Text(call.Channel.Lang, "quote.show", q.Num, q.Text), 450)
+	call.Channel.Message(ctx, message.Sent{Text: s})
+}
+
+// DeleteQuote removes a quote from the channel's quote book.
+//   - num: Number of the quote to remove.
+func DeleteQuote(ctx context.Context, robo *Robot, call *Invocation) {
+	s := call.Args["num"]
+	num, err := strconv.Atoi(s)
+	if err != nil || num <= 0 {
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "quote.missing", s)})
+		return
+	}
+	ok, err := robo.Spoken.DeleteQuote(ctx, call.Channel.Send, num)
+	if err != nil {
+		robo.Log.ErrorContext(ctx, "couldn't delete quote", slog.Any("err", err))
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "quote.error", num)})
+		return
+	}
+	if !ok {
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "quote.missing", s)})
+		return
+	}
+	record(ctx, robo, audit.Entry{
+		Time:    call.Message.Time(),
+		Actor:   call.Message.Sender.Name,
+		Channel: call.Channel.Name,
+		Action:  "delete-quote",
+		Reason:  "#" + strconv.Itoa(num),
+		Tag:     call.Channel.Send,
+	})
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "quote.deleted", num)})
+}
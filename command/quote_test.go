@@ -0,0 +1,45 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/zephyrtronium/robot/message"
+)
+
+func TestRepliedText(t *testing.T) {
+	cases := []struct {
+		name   string
+		parent *message.Parent
+		want   string
+	}{
+		{
+			name:   "bot",
+			parent: &message.Parent{ID: "1", Sender: "robot", Text: "bocchi the rock"},
+			want:   "bocchi the rock",
+		},
+		{
+			name:   "case",
+			parent: &message.Parent{ID: "1", Sender: "Robot", Text: "bocchi the rock"},
+			want:   "bocchi the rock",
+		},
+		{
+			name:   "other",
+			parent: &message.Parent{ID: "1", Sender: "bocchi", Text: "bocchi the rock"},
+			want:   "",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			robo := Robot{Name: "robot"}
+			call := Invocation{Message: &message.Received[message.User]{Parent: c.parent}}
+			got, err := repliedText(context.Background(), &robo, &call)
+			if err != nil {
+				t.Errorf("couldn't get replied text: %v", err)
+			}
+			if got != c.want {
+				t.Errorf("wrong text: want %q, got %q", c.want, got)
+			}
+		})
+	}
+}
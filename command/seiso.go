@@ -7,8 +7,6 @@ import (
 	"math/rand/v2"
 	"time"
 
-	"gitlab.com/zephyrtronium/pick"
-
 	"github.com/zephyrtronium/robot/message"
 )
 
@@ -42,23 +40,9 @@ func Seiso(ctx context.Context, robo *Robot, call *Invocation) {
 	v, _ := call.Channel.Extra.Load(partnerKey{})
 	e := call.Channel.Emotes.Pick(rand.Uint32())
 	if v, _ := v.(*suitor); v != nil && v.who == call.Message.Sender.ID {
-		m := パートナーの清楚.Pick(rand.Uint32())
-		call.Channel.Message(ctx, message.Format("%s %s", m, e).AsReply(call.Message.ID))
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "seiso.partner", e)})
 		return
 	}
 	x := seisoScore(call.Channel.Name, call.Message.Sender.ID, call.Message.Time())
-	m := 清楚.Pick(rand.Uint32())
-	call.Channel.Message(ctx, message.Format("%s %.0f%s %s", m[0], x, m[1], e).AsReply(call.Message.ID))
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "seiso", x, e)})
 }
-
-var パートナーの清楚 = pick.New([]pick.Case[string]{
-	{E: "Of course you're seiso, sweetie!", W: 10},
-	// TODO(zeph): more
-})
-
-var 清楚 = pick.New([]pick.Case[[2]string]{
-	{E: [2]string{"You're like", "% seiso"}, W: 10},
-	{E: [2]string{"I think you're about", "% seiso"}, W: 10},
-	{E: [2]string{"Like", "% seiso. Take that as you will."}, W: 2},
-	{E: [2]string{"You are exactly", "% seiso"}, W: 10},
-})
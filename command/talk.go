@@ -28,7 +28,8 @@ func speakCmd(ctx context.Context, robo *Robot, call *Invocation, effect string)
 		return "no " + e
 	}
 	start := time.Now()
-	m, trace, err := brain.Think(ctx, robo.Brain, call.Channel.Send, call.Args["prompt"])
+	w := robo.Weights(ctx, call.Channel.Send)
+	m, trace, err := brain.ThinkWeighted(ctx, robo.Brain, call.Channel.Send, call.Args["prompt"], w)
 	cost := time.Since(start)
 	if err != nil {
 		robo.Log.ErrorContext(ctx, "couldn't think", "err", err.Error())
@@ -40,7 +41,7 @@ func speakCmd(ctx context.Context, robo *Robot, call *Invocation, effect string)
 	}
 	e := call.Channel.Emotes.Pick(rand.Uint32())
 	s := m + " " + e
-	if err := robo.Spoken.Record(ctx, call.Channel.Send, s, trace, call.Message.Time(), cost, m, e, effect); err != nil {
+	if err := robo.Spoken.Record(ctx, call.Channel.Send, s, trace, call.Message.Time(), cost, m, e, effect, call.Args["prompt"]); err != nil {
 		robo.Log.ErrorContext(ctx, "couldn't record trace", slog.Any("err", err))
 		return ""
 	}
@@ -142,7 +143,7 @@ func Rawr(ctx context.Context, robo *Robot, call *Invocation) {
 		r.CancelAt(t)
 		return
 	}
-	call.Channel.Message(ctx, message.Format("rawr %s", e).AsReply(call.Message.ID))
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "rawr", e)})
 }
 
 // HappyBirthdayToYou wishes the robot a happy birthday.
@@ -166,56 +167,52 @@ func HappyBirthdayToYou(ctx context.Context, robo *Robot, call *Invocation) {
 		r.CancelAt(t)
 		return
 	}
-	var m message.Sent
+	var key string
 	switch t.Month() {
 	case time.January:
-		m = message.Format("No no no my birthday is next month. %s", e)
+		key = "birthday.before"
 	case time.February:
 		switch t.Day() {
 		case 1, 2, 3, 4, 5:
-			m = message.Format("Oh, but my birthday is later this month. %s", e)
+			key = "birthday.month"
 		case 6:
-			m = message.Format("My birthday is just a week away! I am so excited about this information. %s", e)
+			key = "birthday.week"
 		case 7, 8, 9, 10:
-			m = message.Format("My birthday is still less than a week away. %s", e)
+			key = "birthday.days"
 		case 11:
-			m = message.Format("Two days away...! %s", e)
+			key = "birthday.two"
 		case 12:
-			m = message.Format("My birthday is tomorrow! At least in my timezone. %s", e)
+			key = "birthday.tomorrow"
 		case 13:
-			m = message.Format("Thank you! Happy my birthday to you, too! %s", e)
+			key = "birthday.today"
 		case 14:
-			m = message.Format("You missed it. My birthday was yesterday. You are disqualified from being my valentine. %s", e)
+			key = "birthday.yesterday"
 		case 15, 16, 17, 18, 19, 20:
-			m = message.Format("My birthday was the other day, actually, but I appreciate the sentiment. %s", e)
+			key = "birthday.recent"
 		default:
-			m = message.Format("My birthday was earlier this month, actually, but I appreciate the sentiment. %s", e)
+			key = "birthday.earlier"
 		}
 	case time.March:
-		m = message.Format("No no no my birthday was last month. %s", e)
+		key = "birthday.after"
 	default:
-		m = message.Format("My birthday is in February, silly %s", e)
+		key = "birthday.other"
 	}
-	call.Channel.Message(ctx, m.AsReply(call.Message.ID))
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, key, e)})
 }
 
 // Source gives a link to the source code.
 func Source(ctx context.Context, robo *Robot, call *Invocation) {
-	const srcMessage = `My source code is at https://github.com/zephyrtronium/robot – ` +
-		`I'm written in Go, and I'm free, open-source software licensed ` +
-		`under the GNU General Public License, Version 3.`
-	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: srcMessage})
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "source")})
 }
 
 // Who describes Robot.
 func Who(ctx context.Context, robo *Robot, call *Invocation) {
-	const whoMessage = `I'm a Markov chain bot! I learn from things people say in chat, then spew vaguely intelligible memes back. More info at: https://github.com/zephyrtronium/robot#how-robot-works %s`
 	e := call.Channel.Emotes.Pick(rand.Uint32())
-	call.Channel.Message(ctx, message.Format(whoMessage, e).AsReply(call.Message.ID))
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "who", e)})
 }
 
 // Contact gives information on how to contact the bot owner.
 func Contact(ctx context.Context, robo *Robot, call *Invocation) {
 	e := call.Channel.Emotes.Pick(rand.Uint32())
-	call.Channel.Message(ctx, message.Format("My operator is %[1]s. %[2]s is the best way to contact %[1]s. %[3]s", robo.Owner, robo.Contact, e).AsReply(call.Message.ID))
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "contact", robo.Owner, robo.Contact, e)})
 }
@@ -11,48 +11,49 @@ import (
 	"github.com/zephyrtronium/robot/pet"
 )
 
-var hungerys = pick.New([]pick.Case[string]{
-	{E: "I'm hungry", W: 20},
-	{E: "hungery", W: 5},
-	{E: "hungy", W: 5},
-	{E: "tumy grumblin", W: 5},
-})
-
-var cleanies = pick.New([]pick.Case[string]{
-	{E: "need to clean up", W: 15},
-	{E: "kinda messy around here", W: 15},
-	{E: "lil stinky", W: 5},
-})
-
-var socials = pick.New([]pick.Case[string]{
-	{E: "need affection", W: 20},
-	{E: "social meter looks like [=______]", W: 10},
-	{E: "have I been a good pet?", W: 1},
-})
-
-var happys = pick.New([]pick.Case[string]{
-	{E: "All my needs are met!", W: 20},
-	{E: "I'm a happy bot!", W: 20},
-	{E: "Tummy filled, home cleaned, head patted!", W: 20},
-	{E: "food ☑️ bedroom ☑️ kitchen ☑️ living room ☑️ bathroom ☑️ pats ☑️", W: 20},
-	{E: "I'm a happy pet!", W: 3},
-	{E: "Unbothered. Moisturized. Happy. In My Lane. Focused. Flourishing.", W: 3},
-})
+func satmsg(lang string, sat pet.Satisfaction) (connective, state string) {
+	k := needKey(sat)
+	if k == "" {
+		return Text(lang, "conn.happy"), Text(lang, "happy")
+	}
+	return Text(lang, "conn.need"), Text(lang, k)
+}
 
-func satmsg(sat pet.Satisfaction) (connective, state string) {
+// needKey gives the response key for the pet's most pressing need, or the
+// empty string if all its needs are met.
+func needKey(sat pet.Satisfaction) string {
 	switch false { // first time I've ever written this
 	case sat.Fed:
-		m := hungerys.Pick(rand.Uint32())
-		return ", but", m + " 🥺👉👈 tell me to eat?"
+		return "need.fed"
 	case sat.Bed, sat.Kitche, sat.Living, sat.Bath:
-		m := cleanies.Pick(rand.Uint32())
-		return ", but", m + " 🥺👉👈 help me clean?"
+		return "need.clean"
+	case sat.Laun:
+		return "need.laundry"
+	case sat.Rest:
+		return "need.rest"
+	case sat.Play:
+		return "need.play"
 	case sat.Pats:
-		m := socials.Pick(rand.Uint32())
-		return ", but", m + " 🥺👉👈 give pats?"
+		return "need.pats"
 	default:
-		m := happys.Pick(rand.Uint32())
-		return ".", m
+		return ""
+	}
+}
+
+// PetNeed describes the pet's most pressing need, for the bot to mention on
+// its own. The result is empty if all the pet's needs are met.
+func PetNeed(lang string, sat pet.Satisfaction) string {
+	k := needKey(sat)
+	if k == "" {
+		return ""
+	}
+	return Text(lang, k)
+}
+
+// savePet saves a pet's status after a change, logging any error.
+func savePet(ctx context.Context, robo *Robot, p *pet.Status) {
+	if err := robo.Pets.Save(ctx, p); err != nil {
+		robo.Log.ErrorContext(ctx, "couldn't save pet", slog.Any("err", err))
 	}
 }
 
@@ -64,8 +65,11 @@ func Tamagotchi(ctx context.Context, robo *Robot, call *Invocation) {
 		return
 	}
 	e := call.Channel.Emotes.Pick(rand.Uint32())
-	sat := robo.Pet.Satisfaction(call.Message.Time())
-	_, m := satmsg(sat)
+	sat := call.Channel.Pet.Satisfaction(call.Message.Time())
+	_, m := satmsg(call.Channel.Lang, sat)
+	if q := nextQuiet(call.Channel.Lang, call.Channel.Quiet, call.Message.Time()); q != "" {
+		m += " " + q
+	}
 	call.Channel.Message(ctx, message.Format("%s %s", m, e).AsReply(call.Message.ID))
 }
 
@@ -111,24 +115,6 @@ var sides = pick.New([]pick.Case[dinner]{
 	{E: dinner{name: "🌰🍆🌰", sate: 0}, W: 1},
 })
 
-var chewmsgs = pick.New([]pick.Case[[2]string]{
-	{E: [2]string{"I'll have", ""}, W: 5},
-	{E: [2]string{"", "sounds tasty"}, W: 5},
-	{E: [2]string{"", "mmmm"}, W: 5},
-	{E: [2]string{"mmmm", ""}, W: 5},
-	{E: [2]string{"gona chew some", "ya know what I mean"}, W: 5},
-	{E: [2]string{"🤤", "👅👅🫦😳"}, W: 1},
-})
-
-var fullmsgs = pick.New([]pick.Case[string]{
-	{E: "I'm seriously full.", W: 10},
-	{E: "I'm really not hungry right now.", W: 10},
-	{E: "I've already eaten way too much…", W: 10},
-	{E: "I've eaten so much tasty food already!", W: 10},
-	{E: "Give me some time to digest first…", W: 10},
-	{E: "please no do not make me eat any more my digital belly will literally explode please i do not have the same physiology as a human it is not safe please", W: 1},
-})
-
 // Eat directs the pet to eat.
 // No arguments.
 func Eat(ctx context.Context, robo *Robot, call *Invocation) {
@@ -147,27 +133,22 @@ func Eat(ctx context.Context, robo *Robot, call *Invocation) {
 	for _, v := range menu {
 		sate += v.sate
 	}
-	ok, sat := robo.Pet.Feed(call.Message.Time(), sate)
+	ok, sat := call.Channel.Pet.Feed(call.Message.Time(), sate)
 	slog.InfoContext(ctx, "feed",
 		slog.Bool("success", ok),
 		slog.Any("menu", menu),
 	)
 	if !ok {
-		s := fullmsgs.Pick(rand.Uint32())
+		s := Text(call.Channel.Lang, "full")
 		call.Channel.Message(ctx, message.Format("%s %s", s, e).AsReply(call.Message.ID))
 		return
 	}
-	c, m := satmsg(sat)
-	chew := chewmsgs.Pick(rand.Uint32())
-	call.Channel.Message(ctx, message.Format("%s %s %s %s %s%s %s %s", chew[0], menu[0].name, menu[1].name, menu[2].name, chew[1], c, m, e).AsReply(call.Message.ID))
+	savePet(ctx, robo, call.Channel.Pet)
+	c, m := satmsg(call.Channel.Lang, sat)
+	chew := Text(call.Channel.Lang, "eat", menu[0].name+" "+menu[1].name+" "+menu[2].name)
+	call.Channel.Message(ctx, message.Format("%s%s %s %s", chew, c, m, e).AsReply(call.Message.ID))
 }
 
-var cleans = pick.New([]pick.Case[[2]string]{
-	{E: [2]string{"Thank you for cleaning my", "!"}, W: 1},
-	{E: [2]string{"Thanks for helping clean my", "!"}, W: 1},
-	{E: [2]string{"My", " is clean now. Thank you so much!"}, W: 1},
-})
-
 // Clean directs the pet to clean a room.
 // See /pet/pet.go for a description of the pet's apartment.
 // No arguments.
@@ -182,7 +163,7 @@ func Clean(ctx context.Context, robo *Robot, call *Invocation) {
 	rooms := make([]pet.Room, 0, 4)
 	var sat pet.Satisfaction
 	for range n {
-		r, s := robo.Pet.Clean(call.Message.Time())
+		r, s := call.Channel.Pet.Clean(call.Message.Time())
 		sat = s
 		robo.Log.InfoContext(ctx, "clean",
 			slog.String("room", r.String()),
@@ -196,24 +177,48 @@ func Clean(ctx context.Context, robo *Robot, call *Invocation) {
 		}
 		rooms = append(rooms, r)
 	}
-	_, m := satmsg(sat)
-	clean := cleans.Pick(rand.Uint32())
-	var msg message.Sent
+	if len(rooms) != 0 {
+		savePet(ctx, robo, call.Channel.Pet)
+	}
+	_, m := satmsg(call.Channel.Lang, sat)
+	lang := call.Channel.Lang
+	names := make([]any, len(rooms))
+	for i, r := range rooms {
+		names[i] = roomName(lang, r)
+	}
+	var text string
 	switch len(rooms) {
 	case 0:
-		msg = message.Format("Everything's already clean! %s %s", m, e)
+		text = Text(lang, "clean.none", m)
 	case 1:
-		msg = message.Format("%s %s%s Now %s %s", clean[0], rooms[0], clean[1], m, e)
+		text = Text(lang, "clean.some", names[0], m)
 	case 2:
-		msg = message.Format("%s %s and %s%s Now %s %s", clean[0], rooms[0], rooms[1], clean[1], m, e)
+		text = Text(lang, "clean.some", Text(lang, "list.two", names...), m)
 	case 3:
-		msg = message.Format("%s %s, %s, and %s%s Now %s %s", clean[0], rooms[0], rooms[1], rooms[2], clean[1], m, e)
+		text = Text(lang, "clean.some", Text(lang, "list.three", names...), m)
 	case 4:
-		msg = message.Format("%s home%s Now %s %s", clean[0], clean[1], m, e)
+		text = Text(lang, "clean.all", m)
 	}
+	msg := message.Sent{Text: text + " " + e}
 	call.Channel.Message(ctx, msg.AsReply(call.Message.ID))
 }
 
+// roomName gives the localized name of a room.
+func roomName(lang string, r pet.Room) string {
+	switch r {
+	case pet.Bedroom:
+		return Text(lang, "room.bedroom")
+	case pet.Kitchen:
+		return Text(lang, "room.kitchen")
+	case pet.Living:
+		return Text(lang, "room.living")
+	case pet.Bathroom:
+		return Text(lang, "room.bathroom")
+	default:
+		return r.String()
+	}
+}
+
 type pat struct {
 	where string
 	love  int
@@ -271,7 +276,96 @@ func Pat(ctx context.Context, robo *Robot, call *Invocation) {
 		slog.Int("love", pat.love),
 		slog.Bool("partner", bonus),
 	)
-	sat := robo.Pet.Pat(call.Message.Time(), pat.love)
-	_, m := satmsg(sat)
+	sat := call.Channel.Pet.Pat(call.Message.Time(), pat.love)
+	savePet(ctx, robo, call.Channel.Pet)
+	_, m := satmsg(call.Channel.Lang, sat)
 	call.Channel.Message(ctx, message.Format("%s %s %s", pat.where, m, e).AsReply(call.Message.ID))
 }
+
+type toy struct {
+	name string
+	fun  int
+}
+
+var toys = pick.New([]pick.Case[toy]{
+	{E: toy{name: "🎲", fun: 30}, W: 10},
+	{E: toy{name: "🧩", fun: 45}, W: 10},
+	{E: toy{name: "🎮", fun: 60}, W: 10},
+	{E: toy{name: "🃏", fun: 30}, W: 10},
+	{E: toy{name: "🏓", fun: 40}, W: 8},
+	{E: toy{name: "⚽", fun: 40}, W: 8},
+	{E: toy{name: "🎨", fun: 50}, W: 8},
+	{E: toy{name: "🪀", fun: 10}, W: 5},
+	{E: toy{name: "🧶", fun: 90}, W: 2},
+})
+
+// Play plays with the pet.
+// No arguments.
+func Play(ctx context.Context, robo *Robot, call *Invocation) {
+	if call.Message.Time().Before(call.Channel.SilentTime()) {
+		robo.Log.InfoContext(ctx, "silent", slog.Time("until", call.Channel.SilentTime()))
+		return
+	}
+	e := call.Channel.Emotes.Pick(rand.Uint32())
+
+	toy := toys.Pick(rand.Uint32())
+	ok, sat := call.Channel.Pet.Play(call.Message.Time(), toy.fun)
+	robo.Log.InfoContext(ctx, "play",
+		slog.Bool("success", ok),
+		slog.String("toy", toy.name),
+		slog.Int("fun", toy.fun),
+	)
+	if !ok {
+		s := Text(call.Channel.Lang, "play.asleep")
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: s})
+		return
+	}
+	savePet(ctx, robo, call.Channel.Pet)
+	c, m := satmsg(call.Channel.Lang, sat)
+	fun := Text(call.Channel.Lang, "play", toy.name)
+	call.Channel.Message(ctx, message.Format("%s%s %s %s", fun, c, m, e).AsReply(call.Message.ID))
+}
+
+// Sleep puts the pet to bed.
+// No arguments.
+func Sleep(ctx context.Context, robo *Robot, call *Invocation) {
+	if call.Message.Time().Before(call.Channel.SilentTime()) {
+		robo.Log.InfoContext(ctx, "silent", slog.Time("until", call.Channel.SilentTime()))
+		return
+	}
+	e := call.Channel.Emotes.Pick(rand.Uint32())
+
+	ok, sat := call.Channel.Pet.Sleep(call.Message.Time())
+	robo.Log.InfoContext(ctx, "sleep", slog.Bool("success", ok))
+	if !ok {
+		_, m := satmsg(call.Channel.Lang, sat)
+		s := Text(call.Channel.Lang, "sleep.awake", m)
+		call.Channel.Message(ctx, message.Format("%s %s", s, e).AsReply(call.Message.ID))
+		return
+	}
+	savePet(ctx, robo, call.Channel.Pet)
+	s := Text(call.Channel.Lang, "sleep")
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: s})
+}
+
+// Laundry helps the pet do its laundry.
+// See /pet/pet.go for a description of the pet's apartment.
+// No arguments.
+func Laundry(ctx context.Context, robo *Robot, call *Invocation) {
+	if call.Message.Time().Before(call.Channel.SilentTime()) {
+		robo.Log.InfoContext(ctx, "silent", slog.Time("until", call.Channel.SilentTime()))
+		return
+	}
+	e := call.Channel.Emotes.Pick(rand.Uint32())
+
+	ok, sat := call.Channel.Pet.Launder(call.Message.Time())
+	robo.Log.InfoContext(ctx, "laundry", slog.Bool("success", ok))
+	_, m := satmsg(call.Channel.Lang, sat)
+	k := "laundry.none"
+	if ok {
+		savePet(ctx, robo, call.Channel.Pet)
+		k = "laundry.done"
+	}
+	s := Text(call.Channel.Lang, k, m)
+	call.Channel.Message(ctx, message.Format("%s %s", s, e).AsReply(call.Message.ID))
+}
@@ -0,0 +1,232 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/zephyrtronium/robot/channel"
+	"github.com/zephyrtronium/robot/message"
+)
+
+// TalkMore doubles or halves the channel's random response probability.
+//   - dir: "more" or "less".
+//   - dur: Duration after which to revert the change. Optional.
+func TalkMore(ctx context.Context, robo *Robot, call *Invocation) {
+	old := call.Channel.Responses()
+	var p float64
+	if strings.EqualFold(call.Args["dir"], "less") {
+		p = old / 2
+	} else {
+		p = min(max(old*2, 0.01), 1)
+	}
+	setResponses(ctx, robo, call, old, p)
+}
+
+// SetResponses sets the channel's random response probability.
+//   - pct: Percentage of messages to respond to.
+//   - dur: Duration after which to revert the change. Optional.
+func SetResponses(ctx context.Context, robo *Robot, call *Invocation) {
+	p, ok := parsePercent(call.Args["pct"])
+	if !ok {
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "responses.bad")})
+		return
+	}
+	setResponses(ctx, robo, call, call.Channel.Responses(), p)
+}
+
+// parsePercent parses a percentage from 0 to 100 as a probability.
+func parsePercent(s string) (float64, bool) {
+	p, err := strconv.ParseFloat(s, 64)
+	// Written so that NaN fails.
+	if err != nil || !(p >= 0 && p <= 100) {
+		return 0, false
+	}
+	return p / 100, true
+}
+
+func setResponses(ctx context.Context, robo *Robot, call *Invocation, old, p float64) {
+	dur, ok := revertDuration(ctx, call)
+	if !ok {
+		return
+	}
+	call.Channel.SetResponses(p)
+	robo.Log.InfoContext(ctx, "set responses", slog.Float64("old", old), slog.Float64("new", p), slog.Duration("revert", dur))
+	if dur == 0 {
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "responses.set", percent(p))})
+		return
+	}
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "responses.set.for", percent(p), dur)})
+	if !waitRevert(ctx, dur) {
+		return
+	}
+	ch := currentChannel(robo, call.Channel)
+	// If the setting is different from what we set it to, then someone else
+	// changed it since. Leave it alone in that case.
+	if ch == nil || ch.Responses() != p {
+		return
+	}
+	ch.SetResponses(old)
+	robo.Log.InfoContext(ctx, "revert responses", slog.Float64("old", p), slog.Float64("new", old))
+	ch.Message(ctx, message.Sent{Text: Text(ch.Lang, "responses.revert", call.Message.Sender.Name, percent(old))})
+}
+
+// SetRate sets the channel's rate limit.
+//   - num: Number of messages allowed at once.
+//   - every: Number of time units over which the messages are allowed.
+//   - unit: Time unit for every. Optional; seconds if empty.
+//   - dur: Duration after which to revert the change. Optional.
+func SetRate(ctx context.Context, robo *Robot, call *Invocation) {
+	num, err := strconv.Atoi(call.Args["num"])
+	if err != nil || num <= 0 || num > 100 {
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "rate.bad.num")})
+		return
+	}
+	every, err := strconv.ParseFloat(call.Args["every"], 64)
+	if err != nil || every <= 0 {
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "rate.bad.every")})
+		return
+	}
+	switch call.Args["unit"] {
+	case "", "s", "S":
+		every *= float64(time.Second)
+	case "m", "M":
+		every *= float64(time.Minute)
+	case "h", "H":
+		every *= float64(time.Hour)
+	}
+	per := time.Duration(every)
+	dur, ok := revertDuration(ctx, call)
+	if !ok {
+		return
+	}
+	rl := call.Channel.Rate
+	oldLimit, oldBurst := rl.Limit(), rl.Burst()
+	limit := rate.Every(per / time.Duration(num))
+	rl.SetLimit(limit)
+	rl.SetBurst(num)
+	robo.Log.InfoContext(ctx, "set rate", slog.Int("num", num), slog.Duration("every", per), slog.Duration("revert", dur))
+	if dur == 0 {
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, plural("rate.set", num), num, per)})
+		return
+	}
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, plural("rate.set.for", num), num, per, dur)})
+	if !waitRevert(ctx, dur) {
+		return
+	}
+	ch := currentChannel(robo, call.Channel)
+	if ch == nil {
+		return
+	}
+	rl = ch.Rate
+	if rl.Limit() != limit || rl.Burst() != num {
+		return
+	}
+	rl.SetLimit(oldLimit)
+	rl.SetBurst(oldBurst)
+	robo.Log.InfoContext(ctx, "revert rate", slog.Int("num", oldBurst), slog.Any("limit", oldLimit))
+	ch.Message(ctx, message.Sent{Text: Text(ch.Lang, "rate.revert", call.Message.Sender.Name, describeRate(ch.Lang, oldLimit, oldBurst))})
+}
+
+// Settings reports the channel's current response probability and rate limit.
+func Settings(ctx context.Context, robo *Robot, call *Invocation) {
+	p := call.Channel.Responses()
+	rl := call.Channel.Rate
+	s := Text(call.Channel.Lang, "settings", percent(p), describeRate(call.Channel.Lang, rl.Limit(), rl.Burst()))
+	if t := call.Channel.SilentTime(); call.Message.Time().Before(t) {
+		s += " " + Text(call.Channel.Lang, "quiet.left", t.Sub(call.Message.Time()).Round(time.Second))
+	}
+	if q := nextQuiet(call.Channel.Lang, call.Channel.Quiet, call.Message.Time()); q != "" {
+		s += " " + q
+	}
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: s})
+}
+
+// revertDuration gets the duration after which to revert a setting change.
+// A zero duration means not to revert. If the duration is invalid, it reports
+// the problem to the channel and returns false.
+func revertDuration(ctx context.Context, call *Invocation) (time.Duration, bool) {
+	dur, err := parseRevert(call.Args["dur"])
+	if err != nil {
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(call.Channel.Lang, "sorry", err)})
+		return 0, false
+	}
+	return dur, true
+}
+
+// maxRevert is the longest time after which a setting change can revert.
+const maxRevert = 12 * time.Hour
+
+// parseRevert parses the duration after which to revert a setting change.
+// An empty string means not to revert. Durations longer than [maxRevert] are
+// shortened to it.
+func parseRevert(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	dur, err := parseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if dur <= 0 {
+		return 0, fmt.Errorf("duration %v is not positive", dur)
+	}
+	return min(dur, maxRevert), nil
+}
+
+// waitRevert waits for dur or until ctx is done.
+// It reports whether the full duration passed.
+func waitRevert(ctx context.Context, dur time.Duration) bool {
+	t := time.NewTimer(dur)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+// currentChannel gets the channel now configured under the same name as ch.
+// A reload replaces channels, so a change reverted after waiting must apply to
+// whatever channel is current at that point. The result is nil if the channel
+// has since been removed.
+func currentChannel(robo *Robot, ch *channel.Channel) *channel.Channel {
+	if robo.Channels == nil {
+		return ch
+	}
+	cur, _ := robo.Channels.Load(ch.Name)
+	return cur
+}
+
+// percent formats a probability as a percentage.
+func percent(p float64) string {
+	return strconv.FormatFloat(math.Round(p*1e4)/1e2, 'f', -1, 64) + "%"
+}
+
+// describeRate describes a rate limit in words.
+func describeRate(lang string, limit rate.Limit, burst int) string {
+	if limit == rate.Inf {
+		return Text(lang, "rate.unlimited")
+	}
+	if limit <= 0 {
+		return Text(lang, plural("rate.total", burst), burst)
+	}
+	every := time.Duration(float64(time.Second) / float64(limit) * float64(burst)).Round(time.Millisecond)
+	return Text(lang, plural("rate.per", burst), burst, every)
+}
+
+// plural gets the catalog key for a count of messages, which is key with
+// ".one" appended when n is 1.
+func plural(key string, n int) string {
+	if n == 1 {
+		return key + ".one"
+	}
+	return key
+}
@@ -0,0 +1,147 @@
+package command
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/zephyrtronium/robot/channel"
+	"github.com/zephyrtronium/robot/message"
+	"github.com/zephyrtronium/robot/syncmap"
+)
+
+func TestParsePercent(t *testing.T) {
+	cases := []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"0", 0, true},
+		{"50", 0.5, true},
+		{"100", 1, true},
+		{"12.5", 0.125, true},
+		{"-1", 0, false},
+		{"100.01", 0, false},
+		{"NaN", 0, false},
+		{"Inf", 0, false},
+		{"bocchi", 0, false},
+		{"", 0, false},
+	}
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			got, ok := parsePercent(c.in)
+			if got != c.want || ok != c.ok {
+				t.Errorf("wrong result: want (%v, %t), got (%v, %t)", c.want, c.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	cases := []struct {
+		in   float64
+		want string
+	}{
+		{0, "0%"},
+		{1, "100%"},
+		{0.5, "50%"},
+		{0.123456, "12.35%"},
+		{0.00004, "0%"},
+		{0.00005, "0.01%"},
+	}
+	for _, c := range cases {
+		if got := percent(c.in); got != c.want {
+			t.Errorf("wrong percent for %v: want %q, got %q", c.in, c.want, got)
+		}
+	}
+}
+
+func TestDescribeRate(t *testing.T) {
+	cases := []struct {
+		name  string
+		limit rate.Limit
+		burst int
+		want  string
+	}{
+		{"inf", rate.Inf, 1, "unlimited"},
+		{"zero", 0, 5, "5 messages in total"},
+		{"negative", -1, 5, "5 messages in total"},
+		{"every", rate.Every(6 * time.Second), 5, "5 messages per 30s"},
+		{"one", rate.Every(time.Minute), 1, "1 message per 1m0s"},
+		{"one total", 0, 1, "1 message in total"},
+		{"fast", rate.Every(time.Second / 3), 2, "2 messages per 667ms"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := describeRate("en", c.limit, c.burst); got != c.want {
+				t.Errorf("wrong description: want %q, got %q", c.want, got)
+			}
+		})
+	}
+}
+
+func TestParseRevert(t *testing.T) {
+	cases := []struct {
+		in   string
+		want time.Duration
+		err  bool
+	}{
+		{"", 0, false},
+		{"5 min", 5 * time.Minute, false},
+		{"an hour", time.Hour, false},
+		{"12h", 12 * time.Hour, false},
+		{"12h1s", maxRevert, false},
+		{"100 hours", maxRevert, false},
+		{"0s", 0, true},
+		{"-5m", 0, true},
+		{"kita", 0, true},
+	}
+	for _, c := range cases {
+		t.Run(c.in, func(t *testing.T) {
+			got, err := parseRevert(c.in)
+			if (err != nil) != c.err {
+				t.Errorf("wrong error: want error %t, got %v", c.err, err)
+			}
+			if got != c.want {
+				t.Errorf("wrong duration: want %v, got %v", c.want, got)
+			}
+		})
+	}
+}
+
+func TestSetResponsesRevertReloaded(t *testing.T) {
+	ctx := context.Background()
+	var sent []string
+	ch := &channel.Channel{Name: "#kessoku"}
+	ch.SetResponses(0.5)
+	// The replacement a reload stores, with the tuned setting carried over.
+	next := &channel.Channel{
+		Name:    "#kessoku",
+		Message: func(ctx context.Context, msg message.Sent) { sent = append(sent, msg.Text) },
+	}
+	robo := Robot{
+		Log:      slog.New(slog.DiscardHandler),
+		Channels: syncmap.New[string, *channel.Channel](),
+	}
+	robo.Channels.Store(ch.Name, ch)
+	ch.Message = func(ctx context.Context, msg message.Sent) {
+		sent = append(sent, msg.Text)
+		next.SetResponses(ch.Responses())
+		robo.Channels.Store(next.Name, next)
+	}
+	call := Invocation{
+		Channel: ch,
+		Message: &message.Received[message.User]{Sender: message.User{Name: "bocchi"}},
+		Args:    map[string]string{"pct": "100", "dur": "1ms"},
+	}
+	SetResponses(ctx, &robo, &call)
+	if got := next.Responses(); got != 0.5 {
+		t.Errorf("current channel not reverted: want 0.5, got %v", got)
+	}
+	if len(sent) != 2 {
+		t.Errorf("wrong number of messages: want 2, got %q", sent)
+	}
+}
@@ -0,0 +1,91 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zephyrtronium/robot/brain"
+	"github.com/zephyrtronium/robot/message"
+	"github.com/zephyrtronium/robot/spoken"
+)
+
+// minWhyAges is the fewest source messages for which Why tells how long ago
+// they were sent.
+const minWhyAges = 3
+
+// Why explains where one of the bot's messages came from without revealing
+// who said what: how many messages it was built from, how long ago they were
+// sent if there were enough of them, and whether it started from a prompt.
+// If the invocation replies to a message, that message is explained;
+// otherwise the bot's most recent message is.
+// No arguments.
+func Why(ctx context.Context, robo *Robot, call *Invocation) {
+	if call.Message.Time().Before(call.Channel.SilentTime()) {
+		robo.Log.InfoContext(ctx, "silent", slog.Time("until", call.Channel.SilentTime()))
+		return
+	}
+	lang := call.Channel.Lang
+	text, err := repliedText(ctx, robo, call)
+	if err != nil {
+		robo.Log.ErrorContext(ctx, "couldn't find previous message to explain", slog.Any("err", err))
+		return
+	}
+	var m spoken.Message
+	if text != "" {
+		m, err = robo.Spoken.Find(ctx, call.Channel.Send, text)
+		if err != nil {
+			robo.Log.ErrorContext(ctx, "couldn't find message to explain", slog.Any("err", err))
+			return
+		}
+	}
+	if m.Text == "" {
+		call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: Text(lang, "why.unknown")})
+		return
+	}
+	times, err := brain.Dates(ctx, robo.Brain, call.Channel.Send, m.Trace...)
+	if err != nil && !errors.Is(err, errors.ErrUnsupported) {
+		robo.Log.ErrorContext(ctx, "couldn't date trace", slog.Any("err", err))
+	}
+	if len(times) < minWhyAges {
+		// With only a message or two, the ages would point straight at the
+		// lines in chat they came from.
+		times = nil
+	}
+	var r string
+	switch {
+	case len(times) == 0 && m.Prompt == "":
+		r = Text(lang, "why.count", len(m.Trace))
+	case len(times) == 0:
+		r = Text(lang, "why.count.prompt", len(m.Trace))
+	default:
+		now := call.Message.Time()
+		newest := roughDuration(now.Sub(slices.MaxFunc(times, time.Time.Compare)))
+		oldest := roughDuration(now.Sub(slices.MinFunc(times, time.Time.Compare)))
+		if m.Prompt == "" {
+			r = Text(lang, "why.ages", len(m.Trace), newest, oldest)
+		} else {
+			r = Text(lang, "why.ages.prompt", len(m.Trace), newest, oldest)
+		}
+	}
+	call.Channel.Message(ctx, message.Sent{Reply: call.Message.ID, Text: r})
+}
+
+// roughDuration formats a duration to the precision of its largest unit.
+func roughDuration(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return "0s"
+	case d < time.Minute:
+		d = d.Round(time.Second)
+	case d < time.Hour:
+		d = d.Round(time.Minute)
+	default:
+		d = d.Round(time.Hour)
+	}
+	s := strings.TrimSuffix(d.String(), "0s")
+	return strings.TrimSuffix(s, "0m")
+}
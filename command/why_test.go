@@ -0,0 +1,90 @@
+package command
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+
+	"github.com/zephyrtronium/robot/brain"
+	"github.com/zephyrtronium/robot/channel"
+	"github.com/zephyrtronium/robot/message"
+	"github.com/zephyrtronium/robot/spoken"
+)
+
+var dbcount atomic.Uint64
+
+func testDB() *sqlitex.Pool {
+	k := dbcount.Add(1)
+	pool, err := sqlitex.NewPool(fmt.Sprintf("file:command-%d.db?mode=memory&cache=shared", k), sqlitex.PoolOptions{Flags: sqlite.OpenReadWrite | sqlite.OpenCreate | sqlite.OpenMemory | sqlite.OpenSharedCache | sqlite.OpenURI})
+	if err != nil {
+		panic(err)
+	}
+	return pool
+}
+
+// dater is a brain which dates every message to the same time.
+type dater struct {
+	brain.Interface
+	at time.Time
+}
+
+func (d *dater) Dates(ctx context.Context, tag string, ids ...string) ([]time.Time, error) {
+	r := make([]time.Time, len(ids))
+	for i := range r {
+		r[i] = d.at
+	}
+	return r, nil
+}
+
+func TestWhyAges(t *testing.T) {
+	now := time.Unix(1e9, 0)
+	cases := []struct {
+		name  string
+		trace []string
+		ages  bool
+	}{
+		{"one", []string{"1"}, false},
+		{"two", []string{"1", "2"}, false},
+		{"three", []string{"1", "2", "3"}, true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.Background()
+			h, err := spoken.Open(ctx, testDB())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := h.Record(ctx, "kessoku", "bocchi the rock", c.trace, now, 0, "bocchi the rock", "", "", ""); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			robo := Robot{
+				Log:    slog.New(slog.DiscardHandler),
+				Brain:  &dater{at: now.Add(-time.Hour)},
+				Spoken: h,
+			}
+			call := Invocation{
+				Channel: &channel.Channel{
+					Send:    "kessoku",
+					Silent:  new(atomic.Int64),
+					Message: func(ctx context.Context, msg message.Sent) { got = append(got, msg.Text) },
+				},
+				Message: &message.Received[message.User]{Timestamp: now.UnixMilli()},
+			}
+			Why(ctx, &robo, &call)
+			if len(got) != 1 {
+				t.Fatalf("wrong number of responses: want 1, got %q", got)
+			}
+			if ages := strings.Contains(got[0], "ago"); ages != c.ages {
+				t.Errorf("wrong ages in %q: want %t", got[0], c.ages)
+			}
+		})
+	}
+}
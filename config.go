@@ -11,8 +11,11 @@ import (
 	"net/http"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"text/template"
 	"time"
 
 	"github.com/BurntSushi/toml"
@@ -27,12 +30,20 @@ import (
 	"golang.org/x/time/rate"
 	"zombiezen.com/go/sqlite/sqlitex"
 
+	"github.com/zephyrtronium/robot/audit"
 	"github.com/zephyrtronium/robot/auth"
+	"github.com/zephyrtronium/robot/blocklist"
 	"github.com/zephyrtronium/robot/brain/kvbrain"
 	"github.com/zephyrtronium/robot/brain/sqlbrain"
 	"github.com/zephyrtronium/robot/channel"
+	"github.com/zephyrtronium/robot/command"
+	"github.com/zephyrtronium/robot/leaderboard"
+	"github.com/zephyrtronium/robot/locale"
 	"github.com/zephyrtronium/robot/message"
+	"github.com/zephyrtronium/robot/pet"
 	"github.com/zephyrtronium/robot/privacy"
+	"github.com/zephyrtronium/robot/roster"
+	"github.com/zephyrtronium/robot/silence"
 	"github.com/zephyrtronium/robot/spoken"
 	"github.com/zephyrtronium/robot/twitch"
 )
@@ -84,10 +95,10 @@ func loadSecrets(file string) (*keys, error) {
 	return r, nil
 }
 
-// SetSources opens the brain and privacy list wrappers around the respective
-// databases. Use [loadDBs] to open the databases themselves from DSNs.
-// Panics if both kv and sql are nil.
-func (robo *Robot) SetSources(ctx context.Context, kv *badger.DB, sql, priv, spoke *sqlitex.Pool) error {
+// SetSources opens the brain, privacy list, and other wrappers around the
+// respective databases. Use [loadDBs] to open the databases themselves from
+// DSNs. Panics if both kv and sql are nil.
+func (robo *Robot) SetSources(ctx context.Context, kv *badger.DB, sql, priv, spoke, state *sqlitex.Pool) error {
 	var err error
 	if sql == nil {
 		if kv == nil {
@@ -108,6 +119,30 @@ func (robo *Robot) SetSources(ctx context.Context, kv *badger.DB, sql, priv, spo
 	if err != nil {
 		return fmt.Errorf("couldn't open spoken history: %w", err)
 	}
+	robo.roster, err = roster.Open(ctx, state)
+	if err != nil {
+		return fmt.Errorf("couldn't open channel roster: %w", err)
+	}
+	robo.blocklist, err = blocklist.Open(ctx, state)
+	if err != nil {
+		return fmt.Errorf("couldn't open block list: %w", err)
+	}
+	robo.audit, err = audit.Open(ctx, state)
+	if err != nil {
+		return fmt.Errorf("couldn't open audit log: %w", err)
+	}
+	robo.silence, err = silence.Open(ctx, state)
+	if err != nil {
+		return fmt.Errorf("couldn't open quiet times: %w", err)
+	}
+	robo.leaderboard, err = leaderboard.Open(ctx, state)
+	if err != nil {
+		return fmt.Errorf("couldn't open leaderboard: %w", err)
+	}
+	robo.pets, err = pet.Open(ctx, state)
+	if err != nil {
+		return fmt.Errorf("couldn't open pets: %w", err)
+	}
 	return nil
 }
 
@@ -202,7 +237,16 @@ func (robo *Robot) InitTwitchUsers(ctx context.Context, owner *Privilege, global
 	if owner.ID == "" {
 		slog.WarnContext(ctx, "no owner information; continuing with owner commands disabled")
 	}
+	return robo.twitchPrivileges(ctx, global, channels)
+}
 
+// twitchPrivileges resolves Twitch usernames in privilege configuration to
+// user IDs.
+func (robo *Robot) twitchPrivileges(ctx context.Context, global []Privilege, channels map[string]*ChannelCfg) error {
+	tok, err := robo.tmi.tokens.Token(ctx)
+	if err != nil {
+		return err
+	}
 	var in, out []twitch.User
 	id := make(map[string][]*Privilege)
 	login := make(map[string][]*Privilege)
@@ -279,18 +323,22 @@ func (robo *Robot) InitTwitchUsers(ctx context.Context, owner *Privilege, global
 }
 
 func mergere(global, ch string) (*regexp.Regexp, error) {
-	var re string
+	return regexp.Compile(mergedre(global, ch))
+}
+
+// mergedre gets the source of the expression that results from merging
+// global and channel regular expressions.
+func mergedre(global, ch string) string {
 	switch {
 	case global != "" && ch != "":
-		re = "(" + global + ")|(" + ch + ")"
+		return "(" + global + ")|(" + ch + ")"
 	case global != "":
-		re = global
+		return global
 	case ch != "":
-		re = ch
+		return ch
 	default:
-		re = "$^"
+		return "$^"
 	}
-	return regexp.Compile(re)
 }
 
 // SetTwitchChannels initializes Twitch channel configuration.
@@ -298,85 +346,185 @@ func mergere(global, ch string) (*regexp.Regexp, error) {
 func (robo *Robot) SetTwitchChannels(ctx context.Context, global Global, channels map[string]*ChannelCfg) error {
 	// TODO(zeph): we can convert this to a SetChannels, where it just adds the
 	// channels for any given service
+	applied := twitchApplied{
+		global:   global,
+		channels: make(map[string]*ChannelCfg),
+		joined:   make(map[string]string),
+	}
 	for nm, ch := range channels {
-		blk, err := mergere(global.Block, ch.Block)
+		vs, err := robo.twitchChannels(nm, global, ch)
 		if err != nil {
-			return fmt.Errorf("bad global or channel block expression for twitch.%s: %w", nm, err)
+			return err
+		}
+		for _, v := range vs {
+			robo.channels.Store(v.Name, v)
+			applied.channels[v.Name] = ch
 		}
-		meme, err := mergere(global.Meme, ch.Meme)
+	}
+	robo.cfgMu.Lock()
+	robo.twitchCfg = applied
+	robo.cfgMu.Unlock()
+	return nil
+}
+
+// twitchApplied is the Twitch channel configuration currently in use.
+type twitchApplied struct {
+	// global is the global configuration.
+	global Global
+	// channels maps each channel name to its configuration, including
+	// channels joined at runtime.
+	channels map[string]*ChannelCfg
+	// joined maps the names of channels joined at runtime to their owners'
+	// user IDs.
+	joined map[string]string
+}
+
+// twitchChannels creates the channels described by a Twitch channel
+// configuration. nm is the name of the configuration table, for error
+// messages. The resulting channels have new state.
+func (robo *Robot) twitchChannels(nm string, global Global, ch *ChannelCfg) ([]*channel.Channel, error) {
+	blk, err := mergere(global.Block, ch.Block)
+	if err != nil {
+		return nil, fmt.Errorf("bad global or channel block expression for twitch.%s: %w", nm, err)
+	}
+	meme, err := mergere(global.Meme, ch.Meme)
+	if err != nil {
+		return nil, fmt.Errorf("bad global or channel meme expression for twitch.%s: %w", nm, err)
+	}
+	lang, err := locale.Parse(ch.Lang)
+	if err != nil {
+		return nil, fmt.Errorf("bad lang for twitch.%s: %w", nm, err)
+	}
+	quiet, err := channel.ParseQuietHours(ch.Quiet.Windows, ch.Quiet.TZ, ch.Quiet.Announce)
+	if err != nil {
+		return nil, fmt.Errorf("bad quiet hours for twitch.%s: %w", nm, err)
+	}
+	bed, err := pet.ParseBedtime(cmp.Or(global.Pet.Bedtime, defaultBedtime), global.Pet.TZ)
+	if err != nil {
+		return nil, fmt.Errorf("bad global pet bedtime: %w", err)
+	}
+	type cmdsrc struct {
+		name, level string
+		help        string
+		re          *regexp.Regexp
+		tmpl        *template.Template
+		cooldown    time.Duration
+	}
+	cmds := make([]cmdsrc, 0, len(ch.Commands))
+	for i, c := range ch.Commands {
+		name := cmp.Or(c.Name, "custom-"+strconv.Itoa(i))
+		level := strings.ToLower(cmp.Or(c.Level, "any"))
+		switch level {
+		case "any", "moderator", "owner": // ok
+		default:
+			return nil, fmt.Errorf("bad level %q for command %s in twitch.%s", c.Level, name, nm)
+		}
+		re, err := regexp.Compile(c.Regex)
 		if err != nil {
-			return fmt.Errorf("bad global or channel meme expression for twitch.%s: %w", nm, err)
+			return nil, fmt.Errorf("bad regex for command %s in twitch.%s: %w", name, nm, err)
 		}
-		emotes := pick.New(pick.FromMap(mergemaps(global.Emotes, ch.Emotes)))
-		effects := pick.New(pick.FromMap(mergemaps(global.Effects, ch.Effects)))
-		perms := make(map[string]channel.UserPerms)
-		for _, p := range global.Privileges.Twitch {
-			switch {
-			case strings.EqualFold(p.Level, "ignore"):
-				perms[p.ID] = channel.UserPerms{
-					DisableCommands: true,
-					DisableLearn:    true,
-					DisableSpeak:    true,
-					DisableMemes:    true,
-				}
-			case strings.EqualFold(p.Level, "selfbot"):
-				perms[p.ID] = channel.UserPerms{DisableLearn: true}
-			case strings.EqualFold(p.Level, "moderator"):
-				perms[p.ID] = channel.UserPerms{Moderator: true}
-			}
+		tmpl, err := command.ParseTemplate(name, c.Response)
+		if err != nil {
+			return nil, fmt.Errorf("bad response for command %s in twitch.%s: %w", name, nm, err)
 		}
-		for _, p := range ch.Privileges {
-			switch {
-			case strings.EqualFold(p.Level, "ignore"):
-				perms[p.ID] = channel.UserPerms{
-					DisableCommands: true,
-					DisableLearn:    true,
-					DisableSpeak:    true,
-					DisableMemes:    true,
-				}
-			case strings.EqualFold(p.Level, "selfbot"):
-				perms[p.ID] = channel.UserPerms{DisableLearn: true}
-			case strings.EqualFold(p.Level, "moderator"):
-				perms[p.ID] = channel.UserPerms{Moderator: true}
+		cmds = append(cmds, cmdsrc{name: name, level: level, help: c.Help, re: re, tmpl: tmpl, cooldown: fseconds(c.Cooldown)})
+	}
+	emotes := pick.New(pick.FromMap(mergemaps(global.Emotes, ch.Emotes)))
+	effects := pick.New(pick.FromMap(mergemaps(global.Effects, ch.Effects)))
+	perms := make(map[string]channel.UserPerms)
+	for _, p := range global.Privileges.Twitch {
+		switch {
+		case strings.EqualFold(p.Level, "ignore"):
+			perms[p.ID] = channel.UserPerms{
+				DisableCommands: true,
+				DisableLearn:    true,
+				DisableSpeak:    true,
+				DisableMemes:    true,
 			}
+		case strings.EqualFold(p.Level, "selfbot"):
+			perms[p.ID] = channel.UserPerms{DisableLearn: true}
+		case strings.EqualFold(p.Level, "moderator"):
+			perms[p.ID] = channel.UserPerms{Moderator: true}
 		}
-		for _, p := range ch.Channels {
-			v := &channel.Channel{
-				Name:        p,
-				Learn:       ch.Learn,
-				Send:        ch.Send,
-				Links:       cmp.Or(ch.Links, global.Links, channel.Block),
-				BotCommands: cmp.Or(ch.BotCommands, global.BotCommands, channel.Block),
-				OneWord:     cmp.Or(ch.OneWord, global.OneWord, channel.Block),
-				Block:       blk,
-				Meme:        meme,
-				Responses:   ch.Responses,
-				Rate:        rate.NewLimiter(rate.Every(fseconds(ch.Rate.Every)), ch.Rate.Num),
-				Permissions: perms,
-				Memery:      channel.NewMemeDetector(ch.Copypasta.Need, fseconds(ch.Copypasta.Within)),
-				Emotes:      emotes,
-				Effects:     effects,
+	}
+	for _, p := range ch.Privileges {
+		switch {
+		case strings.EqualFold(p.Level, "ignore"):
+			perms[p.ID] = channel.UserPerms{
+				DisableCommands: true,
+				DisableLearn:    true,
+				DisableSpeak:    true,
+				DisableMemes:    true,
 			}
-			if robo.tmi != nil {
-				v.Message = func(ctx context.Context, msg message.Sent) {
-					if msg.To == "" {
-						msg.To = v.Name
-					}
-					robo.sendTMI(ctx, robo.tmi.send, msg)
+		case strings.EqualFold(p.Level, "selfbot"):
+			perms[p.ID] = channel.UserPerms{DisableLearn: true}
+		case strings.EqualFold(p.Level, "moderator"):
+			perms[p.ID] = channel.UserPerms{Moderator: true}
+		}
+	}
+	r := make([]*channel.Channel, 0, len(ch.Channels))
+	for _, p := range ch.Channels {
+		pt := robo.pets.Pet(cmp.Or(ch.Pet, strings.TrimPrefix(p, "#")))
+		pt.SetBedtime(bed)
+		v := &channel.Channel{
+			Name:        p,
+			Learn:       ch.Learn,
+			Send:        ch.Send,
+			Lang:        lang,
+			Pet:         pt,
+			Links:       cmp.Or(ch.Links, global.Links, channel.Block),
+			BotCommands: cmp.Or(ch.BotCommands, global.BotCommands, channel.Block),
+			OneWord:     cmp.Or(ch.OneWord, global.OneWord, channel.Block),
+			Caps:        cmp.Or(ch.Caps, global.Caps, channel.Learn),
+			EmoteOnly:   cmp.Or(ch.EmoteOnly, global.EmoteOnly, channel.Learn),
+			Long:        cmp.Or(ch.Long, global.Long, channel.Learn),
+			Repeated:    cmp.Or(ch.Repeated, global.Repeated, channel.Learn),
+			Mentions:    cmp.Or(ch.Mentions, global.Mentions, channel.Learn),
+			Block:       channel.NewBlocker(blk),
+			Meme:        meme,
+			Rate:        rate.NewLimiter(rate.Every(fseconds(ch.Rate.Every)), ch.Rate.Num),
+			Permissions: perms,
+			History:     new(channel.History[*message.Received[message.User]]),
+			Memery:      channel.NewFuzzyMemeDetector(ch.Copypasta.Need, fseconds(ch.Copypasta.Within), ch.Copypasta.Similarity),
+			Quota:       channel.NewQuota(ch.Quota.Num, fseconds(ch.Quota.Every)),
+			Emotes:      emotes,
+			Effects:     effects,
+			Silent:      new(atomic.Int64),
+			Quiet:       quiet,
+			Cooldowns:   new(channel.Cooldowns),
+			Extra:       new(sync.Map),
+			Enabled:     new(atomic.Bool),
+		}
+		v.SetResponses(ch.Responses)
+		for _, c := range cmds {
+			v.Commands = append(v.Commands, &channel.Command{
+				Name:     c.name,
+				Parse:    c.re,
+				Level:    c.level,
+				Cooldown: c.cooldown,
+				Response: c.tmpl,
+				Help:     c.help,
+			})
+		}
+		if robo.tmi != nil {
+			v.Message = func(ctx context.Context, msg message.Sent) {
+				if msg.To == "" {
+					msg.To = v.Name
 				}
+				robo.sendTMI(ctx, robo.tmi.send, msg)
 			}
-			robo.channels.Store(p, v)
 		}
+		r = append(r, v)
 	}
-	return nil
+	return r, nil
 }
 
-func loadDBs(ctx context.Context, cfg DBCfg) (kv *badger.DB, sql, priv, spoke *sqlitex.Pool, err error) {
+func loadDBs(ctx context.Context, cfg DBCfg) (kv *badger.DB, sql, priv, spoke, state *sqlitex.Pool, err error) {
 	if cfg.KVBrain != "" && cfg.SQLBrain != "" {
-		return nil, nil, nil, nil, fmt.Errorf("multiple brain backends requested; use exactly one")
+		return nil, nil, nil, nil, nil, fmt.Errorf("multiple brain backends requested; use exactly one")
 	}
 	if cfg.KVBrain == "" && cfg.SQLBrain == "" {
-		return nil, nil, nil, nil, fmt.Errorf("no brain backends requested; use exactly one")
+		return nil, nil, nil, nil, nil, fmt.Errorf("no brain backends requested; use exactly one")
 	}
 
 	if cfg.KVBrain != "" {
@@ -388,14 +536,14 @@ func loadDBs(ctx context.Context, cfg DBCfg) (kv *badger.DB, sql, priv, spoke *s
 		opts = opts.WithBloomFalsePositive(0)
 		kv, err = badger.Open(opts.FromSuperFlag(cfg.KVFlag))
 		if err != nil {
-			return nil, nil, nil, nil, fmt.Errorf("couldn't open kvbrain db: %w", err)
+			return nil, nil, nil, nil, nil, fmt.Errorf("couldn't open kvbrain db: %w", err)
 		}
 	}
 	if cfg.SQLBrain != "" {
 		slog.DebugContext(ctx, "using sqlbrain", slog.String("path", cfg.SQLBrain))
 		sql, err = sqlitex.NewPool(cfg.SQLBrain, sqlitex.PoolOptions{PrepareConn: sqlbrain.RecommendedPrep})
 		if err != nil {
-			return nil, nil, nil, nil, fmt.Errorf("couldn't open sqlbrain db: %w", err)
+			return nil, nil, nil, nil, nil, fmt.Errorf("couldn't open sqlbrain db: %w", err)
 		}
 	}
 
@@ -407,7 +555,7 @@ func loadDBs(ctx context.Context, cfg DBCfg) (kv *badger.DB, sql, priv, spoke *s
 		slog.DebugContext(ctx, "privacy db", slog.String("path", cfg.Privacy))
 		priv, err = sqlitex.NewPool(cfg.Privacy, sqlitex.PoolOptions{})
 		if err != nil {
-			return nil, nil, nil, nil, fmt.Errorf("couldn't open privacy db: %w", err)
+			return nil, nil, nil, nil, nil, fmt.Errorf("couldn't open privacy db: %w", err)
 		}
 	}
 
@@ -422,11 +570,29 @@ func loadDBs(ctx context.Context, cfg DBCfg) (kv *badger.DB, sql, priv, spoke *s
 		slog.DebugContext(ctx, "spoken history db", slog.String("path", cfg.Spoken))
 		spoke, err = sqlitex.NewPool(cfg.Spoken, sqlitex.PoolOptions{})
 		if err != nil {
-			return nil, nil, nil, nil, fmt.Errorf("couldn't open spoken history db: %w", err)
+			return nil, nil, nil, nil, nil, fmt.Errorf("couldn't open spoken history db: %w", err)
+		}
+	}
+
+	switch cfg.State {
+	case "", cfg.Privacy:
+		slog.DebugContext(ctx, "state db shared with privacy db")
+		state = priv
+	case cfg.SQLBrain:
+		slog.DebugContext(ctx, "state db shared with sqlbrain")
+		state = sql
+	case cfg.Spoken:
+		slog.DebugContext(ctx, "state db shared with spoken history db")
+		state = spoke
+	default:
+		slog.DebugContext(ctx, "state db", slog.String("path", cfg.State))
+		state, err = sqlitex.NewPool(cfg.State, sqlitex.PoolOptions{})
+		if err != nil {
+			return nil, nil, nil, nil, nil, fmt.Errorf("couldn't open state db: %w", err)
 		}
 	}
 
-	return kv, sql, priv, spoke, nil
+	return kv, sql, priv, spoke, state, nil
 }
 
 func mergemaps(ms ...map[string]int) map[string]int {
@@ -528,6 +694,12 @@ type ChannelCfg struct {
 	Learn string `toml:"learn"`
 	// Send is the tag used for generating messages for these channels.
 	Send string `toml:"send"`
+	// Lang is the language of the bot's responses in these channels as a
+	// BCP 47 tag. The default is English.
+	Lang string `toml:"lang"`
+	// Pet is the name of the pet in these channels. Channels with the same
+	// pet name share the pet. The default is a separate pet per channel.
+	Pet string `toml:"pet"`
 	// Links describes how messages containing links are handled in the channel.
 	Links channel.BlockOption `toml:"links"`
 	// BotCommands describes how messages that look like invocations for other
@@ -536,6 +708,20 @@ type ChannelCfg struct {
 	// OneWord describes how messages that aren't links and aren't bot commands
 	// but contain no whitespace are handled in the channel.
 	OneWord channel.BlockOption `toml:"oneword"`
+	// Caps describes how messages shouted in capital letters are handled in
+	// the channel.
+	Caps channel.BlockOption `toml:"caps"`
+	// EmoteOnly describes how messages containing only emotes are handled in
+	// the channel.
+	EmoteOnly channel.BlockOption `toml:"emoteonly"`
+	// Long describes how very long messages are handled in the channel.
+	Long channel.BlockOption `toml:"long"`
+	// Repeated describes how messages that repeat a character many times are
+	// handled in the channel.
+	Repeated channel.BlockOption `toml:"repeated"`
+	// Mentions describes how messages that mention other users are handled in
+	// the channel.
+	Mentions channel.BlockOption `toml:"mentions"`
 	// Block is a regular expression of messages to ignore.
 	Block string `toml:"block"`
 	// Responses is the probability of generating a random message when
@@ -545,6 +731,9 @@ type ChannelCfg struct {
 	Rate Rate `toml:"rate"`
 	// Copypasta is the configuration for copypasta.
 	Copypasta Copypasta `toml:"copypasta"`
+	// Quota is the limit on messages learned from each user.
+	// Zero values mean no limit.
+	Quota Rate `toml:"quota"`
 	// Meme is a regular expression of messages to allow to be copypasta even
 	// if matched by this channel's or the global Block.
 	Meme string `toml:"meme"`
@@ -554,6 +743,39 @@ type ChannelCfg struct {
 	Effects map[string]int `toml:"effects"`
 	// Privileges is the user access controls for the channel.
 	Privileges []Privilege `toml:"privileges"`
+	// Quiet is the channel's recurring quiet hours.
+	Quiet QuietCfg `toml:"quiet"`
+	// Commands is the list of custom commands for the channel.
+	Commands []CommandCfg `toml:"commands"`
+}
+
+// CommandCfg is the configuration for a custom command.
+type CommandCfg struct {
+	// Name is the name of the command.
+	Name string `toml:"name"`
+	// Regex is the regular expression matching invocations of the command.
+	// Named captures are available to the response template.
+	Regex string `toml:"regex"`
+	// Level is the access level needed to use the command: "any" (the
+	// default), "moderator", or "owner".
+	Level string `toml:"level"`
+	// Cooldown is the minimum time in seconds between uses of the command.
+	Cooldown float64 `toml:"cooldown"`
+	// Response is the text/template for the command's response.
+	Response string `toml:"response"`
+	// Help is a short description of the command for the help command.
+	Help string `toml:"help"`
+}
+
+// QuietCfg is the configuration for recurring quiet hours.
+type QuietCfg struct {
+	// Windows is the list of weekly quiet windows, e.g. "sun 12:00-18:00".
+	Windows []string `toml:"windows"`
+	// TZ is the time zone of the windows. Defaults to UTC.
+	TZ string `toml:"tz"`
+	// Announce indicates whether to send messages when quiet hours begin
+	// and end.
+	Announce bool `toml:"announce"`
 }
 
 // Global is the configuration for globally applied options.
@@ -566,6 +788,21 @@ type Global struct {
 	// OneWord describes how messages that aren't links and aren't bot commands
 	// but contain no whitespace are handled everywhere.
 	OneWord channel.BlockOption `toml:"oneword"`
+	// Caps describes how messages shouted in capital letters are handled
+	// everywhere. Unlike the above, it defaults to learn.
+	Caps channel.BlockOption `toml:"caps"`
+	// EmoteOnly describes how messages containing only emotes are handled
+	// everywhere. Defaults to learn.
+	EmoteOnly channel.BlockOption `toml:"emoteonly"`
+	// Long describes how very long messages are handled everywhere.
+	// Defaults to learn.
+	Long channel.BlockOption `toml:"long"`
+	// Repeated describes how messages that repeat a character many times are
+	// handled everywhere. Defaults to learn.
+	Repeated channel.BlockOption `toml:"repeated"`
+	// Mentions describes how messages that mention other users are handled
+	// everywhere. Defaults to learn.
+	Mentions channel.BlockOption `toml:"mentions"`
 	// Block is a regular expression of messages to ignore everywhere.
 	Block string `toml:"block"`
 	// Meme is a regular expression of messages to allow to be copypasta even
@@ -577,6 +814,67 @@ type Global struct {
 	Effects map[string]int `toml:"effects"`
 	// Privileges is the user access controls across entire services.
 	Privileges GlobalPrivs `toml:"privileges"`
+	// Join is the configuration for channels joined at runtime.
+	Join JoinCfg `toml:"join"`
+	// Forget is the configuration for forgetting messages by moderation.
+	Forget ForgetCfg `toml:"forget"`
+	// Audit is the configuration for the moderation audit log.
+	Audit AuditCfg `toml:"audit"`
+	// Game is the configuration for the real or robot game.
+	Game GameCfg `toml:"game"`
+	// Pet is the configuration for the pet.
+	Pet PetCfg `toml:"pet"`
+}
+
+// ForgetCfg is the configuration for forgetting messages by moderation.
+type ForgetCfg struct {
+	// Max is the longest window in seconds over which a moderator can have
+	// the bot forget messages from a user. Defaults to one day.
+	Max float64 `toml:"max"`
+	// Undo is the time in seconds after a forget command during which
+	// moderators can undo it. Defaults to five minutes; at most fifteen.
+	Undo float64 `toml:"undo"`
+}
+
+// AuditCfg is the configuration for the moderation audit log.
+type AuditCfg struct {
+	// Retain is the time in seconds to keep audit log entries.
+	// Defaults to 90 days. Negative values keep entries forever.
+	Retain float64 `toml:"retain"`
+}
+
+// GameCfg is the configuration for the real or robot game.
+type GameCfg struct {
+	// Age is the minimum age in seconds of learned messages to use as real
+	// messages. Defaults to one week.
+	Age float64 `toml:"age"`
+	// Window is the time in seconds chat has to vote. Defaults to one minute.
+	Window float64 `toml:"window"`
+}
+
+// PetCfg is the configuration for the pet.
+type PetCfg struct {
+	// Announce is the time in seconds between the pet mentioning its needs on
+	// its own in each channel while the stream is online. Defaults to one
+	// hour. Negative values disable announcements.
+	Announce float64 `toml:"announce"`
+	// Bedtime is the time of day as hh:mm when the pet gets tired.
+	// Defaults to 22:00.
+	Bedtime string `toml:"bedtime"`
+	// TZ is the time zone of the bedtime. Defaults to UTC.
+	TZ string `toml:"tz"`
+}
+
+// JoinCfg is the configuration for channels joined at runtime.
+// Other settings for those channels come from the global configuration.
+type JoinCfg struct {
+	// Responses is the probability of generating a random message when
+	// a non-command message is received.
+	Responses float64 `toml:"responses"`
+	// Rate is the rate limit for interactions.
+	Rate Rate `toml:"rate"`
+	// Copypasta is the configuration for copypasta.
+	Copypasta Copypasta `toml:"copypasta"`
 }
 
 // GlobalPrivs is the configuration for privileges across entire services.
@@ -636,6 +934,7 @@ type DBCfg struct {
 	KVFlag   string `toml:"kvflag"`
 	Privacy  string `toml:"privacy"`
 	Spoken   string `toml:"spoken"`
+	State    string `toml:"state"`
 }
 
 // APICfg is the configuration of the HTTP API.
@@ -654,6 +953,10 @@ type Rate struct {
 type Copypasta struct {
 	Need   int     `toml:"need"`
 	Within float64 `toml:"within"`
+	// Similarity enables fuzzy copypasta detection. Messages whose sets of
+	// words have at least this Jaccard similarity count as the same meme.
+	// Zero requires exact matches.
+	Similarity float64 `toml:"similarity"`
 }
 
 func expandcfg(cfg *Config, expand func(s string) string) {
@@ -666,6 +969,7 @@ func expandcfg(cfg *Config, expand func(s string) string) {
 		&cfg.DB.KVFlag,
 		&cfg.DB.Privacy,
 		&cfg.DB.Spoken,
+		&cfg.DB.State,
 		&cfg.HTTP.Listen,
 		&cfg.TMI.CID,
 		&cfg.TMI.SecretFile,
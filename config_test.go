@@ -36,6 +36,11 @@ func TestExampleConfig(t *testing.T) {
 	eqcase(t, "Global.Links", cfg.Global.Links, channel.Block)
 	eqcase(t, "Global.BotCommands", cfg.Global.BotCommands, channel.Meme)
 	eqcase(t, "Global.OneWord", cfg.Global.OneWord, channel.Meme)
+	eqcase(t, "Global.Caps", cfg.Global.Caps, channel.Meme)
+	eqcase(t, "Global.EmoteOnly", cfg.Global.EmoteOnly, channel.Meme)
+	eqcase(t, "Global.Long", cfg.Global.Long, channel.Block)
+	eqcase(t, "Global.Repeated", cfg.Global.Repeated, channel.Meme)
+	eqcase(t, "Global.Mentions", cfg.Global.Mentions, channel.Learn)
 	eqcase(t, "Global.Block", cfg.Global.Block, `(?i)bad\s+stuff[^$x]`)
 	eqcase(t, "Global.Emotes[``]", cfg.Global.Emotes[``], 4)
 	eqcase(t, "Global.Emotes[`;)`]", cfg.Global.Emotes[`;)`], 1)
@@ -45,6 +50,19 @@ func TestExampleConfig(t *testing.T) {
 	eqcase(t, "Global.Effects[`o`]", cfg.Global.Effects[`o`], 1)
 	eqcase(t, "Global.Privileges.Twitch[0].Name", cfg.Global.Privileges.Twitch[0].Name, "nightbot")
 	eqcase(t, "Global.Privileges.Twitch[0].Level", cfg.Global.Privileges.Twitch[0].Level, "ignore")
+	eqcase(t, "Global.Forget.Max", cfg.Global.Forget.Max, 86400)
+	eqcase(t, "Global.Forget.Undo", cfg.Global.Forget.Undo, 300)
+	eqcase(t, "Global.Audit.Retain", cfg.Global.Audit.Retain, 7776000)
+	eqcase(t, "Global.Game.Age", cfg.Global.Game.Age, 604800)
+	eqcase(t, "Global.Game.Window", cfg.Global.Game.Window, 60)
+	eqcase(t, "Global.Pet.Announce", cfg.Global.Pet.Announce, 3600)
+	eqcase(t, "Global.Pet.Bedtime", cfg.Global.Pet.Bedtime, "22:00")
+	eqcase(t, "Global.Pet.TZ", cfg.Global.Pet.TZ, "UTC")
+	eqcase(t, "Global.Join.Responses", cfg.Global.Join.Responses, 0.02)
+	eqcase(t, "Global.Join.Rate.Every", cfg.Global.Join.Rate.Every, 10.1)
+	eqcase(t, "Global.Join.Rate.Num", cfg.Global.Join.Rate.Num, 2)
+	eqcase(t, "Global.Join.Copypasta.Need", cfg.Global.Join.Copypasta.Need, 2)
+	eqcase(t, "Global.Join.Copypasta.Within", cfg.Global.Join.Copypasta.Within, 30)
 	eqcase(t, "TMI.CID", cfg.TMI.CID, `hof5gwx0su6owfnys0nyan9c87zr6t`)
 	eqcase(t, "TMI.RedirectURL", cfg.TMI.RedirectURL, `http://localhost`)
 	eqcase(t, "TMI.TokenFile", cfg.TMI.TokenFile, `/var/robot/tmi_refresh`)
@@ -55,15 +73,32 @@ func TestExampleConfig(t *testing.T) {
 	eqcase(t, "Twitch[`bocchi`].Channels[0]", cfg.Twitch[`bocchi`].Channels[0], `#bocchi`)
 	eqcase(t, "Twitch[`bocchi`].Learn", cfg.Twitch[`bocchi`].Learn, `bocchi`)
 	eqcase(t, "Twitch[`bocchi`].Send", cfg.Twitch[`bocchi`].Send, `bocchi`)
+	eqcase(t, "Twitch[`bocchi`].Lang", cfg.Twitch[`bocchi`].Lang, `ja`)
+	eqcase(t, "Twitch[`bocchi`].Pet", cfg.Twitch[`bocchi`].Pet, `kessoku`)
 	eqcase(t, "Twitch[`bocchi`].Links", cfg.Twitch[`bocchi`].Links, channel.DefaultBlock)
 	eqcase(t, "Twitch[`bocchi`].BotCommands", cfg.Twitch[`bocchi`].BotCommands, channel.Block)
 	eqcase(t, "Twitch[`bocchi`].OneWord", cfg.Twitch[`bocchi`].OneWord, channel.DefaultBlock)
+	eqcase(t, "Twitch[`bocchi`].Caps", cfg.Twitch[`bocchi`].Caps, channel.DefaultBlock)
+	eqcase(t, "Twitch[`bocchi`].Mentions", cfg.Twitch[`bocchi`].Mentions, channel.Meme)
 	eqcase(t, "Twitch[`bocchi`].Block", cfg.Twitch[`bocchi`].Block, `(?i)cucumber[^$x]`)
 	eqcase(t, "Twitch[`bocchi`].Responses", cfg.Twitch[`bocchi`].Responses, 0.02)
 	eqcase(t, "Twitch[`bocchi`].Rate.Every", cfg.Twitch[`bocchi`].Rate.Every, 10.1)
 	eqcase(t, "Twitch[`bocchi`].Rate.Num", cfg.Twitch[`bocchi`].Rate.Num, 2)
 	eqcase(t, "Twitch[`bocchi`].Copypasta.Need", cfg.Twitch[`bocchi`].Copypasta.Need, 2)
 	eqcase(t, "Twitch[`bocchi`].Copypasta.Within", cfg.Twitch[`bocchi`].Copypasta.Within, 30)
+	eqcase(t, "Twitch[`bocchi`].Copypasta.Similarity", cfg.Twitch[`bocchi`].Copypasta.Similarity, 0.75)
+	eqcase(t, "Twitch[`bocchi`].Quota.Every", cfg.Twitch[`bocchi`].Quota.Every, 600)
+	eqcase(t, "Twitch[`bocchi`].Quota.Num", cfg.Twitch[`bocchi`].Quota.Num, 20)
+	eqcase(t, "Twitch[`bocchi`].Quiet.Windows[0]", cfg.Twitch[`bocchi`].Quiet.Windows[0], `sun 12:00-18:00`)
+	eqcase(t, "Twitch[`bocchi`].Quiet.Windows[1]", cfg.Twitch[`bocchi`].Quiet.Windows[1], `mon-fri 01:00-07:30`)
+	eqcase(t, "Twitch[`bocchi`].Quiet.TZ", cfg.Twitch[`bocchi`].Quiet.TZ, `Asia/Tokyo`)
+	eqcase(t, "Twitch[`bocchi`].Quiet.Announce", cfg.Twitch[`bocchi`].Quiet.Announce, true)
+	eqcase(t, "Twitch[`bocchi`].Commands[0].Name", cfg.Twitch[`bocchi`].Commands[0].Name, `hug`)
+	eqcase(t, "Twitch[`bocchi`].Commands[0].Regex", cfg.Twitch[`bocchi`].Commands[0].Regex, `(?i)^hug\s+(?<target>\S+)`)
+	eqcase(t, "Twitch[`bocchi`].Commands[0].Level", cfg.Twitch[`bocchi`].Commands[0].Level, `any`)
+	eqcase(t, "Twitch[`bocchi`].Commands[0].Cooldown", cfg.Twitch[`bocchi`].Commands[0].Cooldown, 30)
+	eqcase(t, "Twitch[`bocchi`].Commands[0].Response", cfg.Twitch[`bocchi`].Commands[0].Response, `{{.Sender}} hugs {{.Args.target}}! {{think "hugs are"}} {{.Emote}}`)
+	eqcase(t, "Twitch[`bocchi`].Commands[0].Help", cfg.Twitch[`bocchi`].Commands[0].Help, `Hug someone.`)
 	eqcase(t, "Twitch[`bocchi`].Meme", cfg.Twitch[`bocchi`].Meme, `^\S*$`)
 	eqcase(t, "Twitch[`bocchi`].Privileges[0].Name", cfg.Twitch[`bocchi`].Privileges[0].Name, `zephyrtronium`)
 	eqcase(t, "Twitch[`bocchi`].Privileges[0].Level", cfg.Twitch[`bocchi`].Privileges[0].Level, `moderator`)
@@ -78,6 +113,7 @@ func TestExampleConfig(t *testing.T) {
 		{"DB.SQLBrain", cfg.DB.SQLBrain, "file:"},
 		{"DB.Privacy", cfg.DB.Privacy, "file:"},
 		{"DB.Spoken", cfg.DB.Spoken, "file:"},
+		{"DB.State", cfg.DB.State, "file:"},
 		{"TMI.SecretFile", cfg.TMI.SecretFile, "/twitch_client_secret"},
 	}
 	for _, c := range substrings {
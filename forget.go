@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/zephyrtronium/robot/audit"
+	"github.com/zephyrtronium/robot/brain"
+	"github.com/zephyrtronium/robot/channel"
+)
+
+// defaultForgetWindow is the maximum window for forgetting messages by user
+// when the config does not set one.
+const defaultForgetWindow = 24 * time.Hour
+
+// ForgetTwitchUser forgets everything learned from a Twitch user in a channel
+// within a window ending now. The window is limited to the configured
+// maximum. It returns the number of messages forgotten and the window used.
+// The actor is recorded in the audit log as who requested it.
+func (robo *Robot) ForgetTwitchUser(ctx context.Context, ch *channel.Channel, actor, login string, window time.Duration) (int, time.Duration, error) {
+	if robo.tmi == nil {
+		return 0, 0, errNoTwitch
+	}
+	robo.cfgMu.Lock()
+	limit := fseconds(robo.twitchCfg.global.Forget.Max)
+	robo.cfgMu.Unlock()
+	if limit <= 0 {
+		limit = defaultForgetWindow
+	}
+	if window <= 0 || window > limit {
+		window = limit
+	}
+	u, err := robo.twitchUser(ctx, strings.TrimPrefix(login, "@"))
+	if err != nil {
+		return 0, window, err
+	}
+	now := time.Now()
+	ids, err := brain.ForgetUser(ctx, robo.brain, robo.hashes(), ch.Learn, u.ID, ch.Name, now.Add(-window), now)
+	robo.metrics.ForgotCount.Observe(float64(len(ids)))
+	slog.InfoContext(ctx, "forget user",
+		slog.String("channel", ch.Name),
+		slog.String("tag", ch.Learn),
+		slog.String("login", u.Login),
+		slog.Duration("window", window),
+		slog.Int("count", len(ids)),
+		slog.Any("err", err),
+	)
+	robo.dropQuotes(ctx, ids...)
+	robo.record(ctx, audit.Entry{
+		Time:     now,
+		Actor:    actor,
+		Channel:  ch.Name,
+		Action:   "forget-user",
+		Reason:   fmt.Sprintf("%s over %v", u.Login, window),
+		Tag:      ch.Learn,
+		Messages: ids,
+	})
+	return len(ids), window, err
+}
+
+// dropQuotes removes quotes whose traces include any of the given forgotten
+// messages.
+func (robo *Robot) dropQuotes(ctx context.Context, ids ...string) {
+	if robo.spoken == nil || len(ids) == 0 {
+		return
+	}
+	n, err := robo.spoken.DropQuotes(ctx, ids...)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to drop quotes of forgotten messages", slog.Any("err", err))
+		return
+	}
+	if n != 0 {
+		slog.InfoContext(ctx, "dropped quotes of forgotten messages", slog.Int("count", n))
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/zephyrtronium/robot/brain"
+	"github.com/zephyrtronium/robot/channel"
+)
+
+const (
+	// defaultGameAge is the minimum age of real messages used in real or
+	// robot when the config does not set one.
+	defaultGameAge = 7 * 24 * time.Hour
+	// defaultGameWindow is the voting time in real or robot when the config
+	// does not set one.
+	defaultGameWindow = time.Minute
+	// gameSample is the number of learned messages examined to choose a real
+	// message for real or robot.
+	gameSample = 64
+)
+
+// gameWindow gets the voting time for real or robot.
+func (robo *Robot) gameWindow() time.Duration {
+	robo.cfgMu.Lock()
+	window := fseconds(robo.twitchCfg.global.Game.Window)
+	robo.cfgMu.Unlock()
+	if window <= 0 {
+		return defaultGameWindow
+	}
+	return window
+}
+
+// RealMessage chooses a real message learned in a channel to use in a round
+// of real or robot. Only messages older than the configured age from users
+// who are not currently private are candidates, and only a bounded sample of
+// them is examined. The result is empty if there are no candidates.
+// If the brain can't sample messages, the error wraps
+// [errors.ErrUnsupported].
+func (robo *Robot) RealMessage(ctx context.Context, ch *channel.Channel) (string, error) {
+	if ch.Learn == "" {
+		return "", nil
+	}
+	robo.cfgMu.Lock()
+	age := fseconds(robo.twitchCfg.global.Game.Age)
+	robo.cfgMu.Unlock()
+	if age <= 0 {
+		age = defaultGameAge
+	}
+	private, err := robo.privacy.All(ctx)
+	if err != nil {
+		return "", fmt.Errorf("couldn't get private users: %w", err)
+	}
+	// Messages don't record where they were sent, so a candidate could be
+	// from any channel that learns into the same tag.
+	var where []string
+	for name, c := range robo.channels.All() {
+		if c.Learn == ch.Learn {
+			where = append(where, name)
+		}
+	}
+	hasher := robo.hashes()
+	keep := func(m *brain.Message) bool {
+		if m.Text == "" || ch.Block.MatchString(m.Text) {
+			return false
+		}
+		// Userhashes are keyed by time and place, so we have to hash each
+		// private user for each candidate to know whether it's theirs.
+		for _, id := range private {
+			for _, w := range where {
+				if hasher.Hash(id, w, m.Time()) == m.Sender {
+					return false
+				}
+			}
+		}
+		return true
+	}
+	m, err := brain.Sample(ctx, robo.brain, ch.Learn, time.Now().Add(-age), gameSample, keep)
+	if err != nil {
+		return "", fmt.Errorf("couldn't sample learned messages: %w", err)
+	}
+	return m.Text, nil
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"gitlab.com/zephyrtronium/tmi"
+
+	"github.com/zephyrtronium/robot/roster"
+	"github.com/zephyrtronium/robot/twitch"
+)
+
+var (
+	errJoined     = errors.New("already in channel")
+	errNotJoined  = errors.New("channel was not joined at runtime")
+	errConfigured = errors.New("channel is in the config file")
+	errNoUser     = errors.New("no such user")
+	errNoTwitch   = errors.New("not connected to Twitch")
+)
+
+// twitchName normalizes a Twitch channel name.
+func twitchName(name string) string {
+	return "#" + strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "#"))
+}
+
+// joinedCfg creates the configuration for a channel joined at runtime.
+// It uses the channel name as its learn and send tags.
+func joinedCfg(global Global, name string) *ChannelCfg {
+	tag := strings.TrimPrefix(name, "#")
+	return &ChannelCfg{
+		Channels:  []string{name},
+		Learn:     tag,
+		Send:      tag,
+		Responses: global.Join.Responses,
+		Rate:      global.Join.Rate,
+		Copypasta: global.Join.Copypasta,
+	}
+}
+
+// twitchUser resolves a Twitch login to the user's information.
+func (robo *Robot) twitchUser(ctx context.Context, login string) (twitch.User, error) {
+	tok, err := robo.tmi.tokens.Token(ctx)
+	if err != nil {
+		return twitch.User{}, err
+	}
+	for {
+		r := []twitch.User{{Login: login}}
+		r, err := twitch.Users(ctx, robo.twitch, tok, r)
+		switch {
+		case err == nil: // do nothing
+		case errors.Is(err, twitch.ErrNeedRefresh):
+			tok, err = robo.tmi.tokens.Refresh(ctx, tok)
+			if err != nil {
+				return twitch.User{}, fmt.Errorf("couldn't refresh Twitch token: %w", err)
+			}
+			continue
+		default:
+			return twitch.User{}, fmt.Errorf("couldn't resolve user %s: %w", login, err)
+		}
+		if len(r) == 0 {
+			return twitch.User{}, fmt.Errorf("%w %s", errNoUser, login)
+		}
+		return r[0], nil
+	}
+}
+
+// JoinTwitch joins a Twitch channel at runtime using the global join
+// settings and records it so that it is joined again on restart.
+// It returns the normalized channel name.
+func (robo *Robot) JoinTwitch(ctx context.Context, name string) (string, error) {
+	if robo.tmi == nil {
+		return "", errNoTwitch
+	}
+	name = twitchName(name)
+	if _, ok := robo.channels.Load(name); ok {
+		return name, errJoined
+	}
+	// Look up the user before taking the config lock so that a slow API
+	// doesn't hold up reloads and other joins.
+	u, err := robo.twitchUser(ctx, strings.TrimPrefix(name, "#"))
+	if err != nil {
+		return name, err
+	}
+	name = twitchName(u.Login)
+	robo.cfgMu.Lock()
+	defer robo.cfgMu.Unlock()
+	if _, ok := robo.channels.Load(name); ok {
+		return name, errJoined
+	}
+	cfg := joinedCfg(robo.twitchCfg.global, name)
+	vs, err := robo.twitchChannels("join", robo.twitchCfg.global, cfg)
+	if err != nil {
+		return name, err
+	}
+	if err := robo.loadBlockTerms(ctx, vs[0]); err != nil {
+		return name, err
+	}
+	if err := robo.sendTwitch(ctx, "JOIN", name); err != nil {
+		return name, err
+	}
+	// Only record the channel once we've actually asked to join it.
+	if err := robo.roster.Add(ctx, roster.Channel{Name: name, ID: u.ID}); err != nil {
+		// Leave again so that we aren't in a channel we don't know about.
+		if err := robo.sendTwitch(ctx, "PART", name); err != nil {
+			slog.ErrorContext(ctx, "couldn't leave unrecorded channel", slog.String("channel", name), slog.Any("err", err))
+		}
+		return name, err
+	}
+	robo.channels.Store(name, vs[0])
+	robo.twitchCfg.channels[name] = cfg
+	robo.twitchCfg.joined[name] = u.ID
+	slog.InfoContext(ctx, "join channel", slog.String("channel", name), slog.String("id", u.ID))
+	return name, nil
+}
+
+// sendTwitch sends a TMI command with a single channel parameter.
+func (robo *Robot) sendTwitch(ctx context.Context, cmd, name string) error {
+	msg := tmi.Message{Command: cmd, Params: []string{name}}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case robo.tmi.send <- &msg:
+		return nil
+	}
+}
+
+// PartTwitch leaves a Twitch channel that was joined at runtime and removes
+// it from the record of joined channels.
+func (robo *Robot) PartTwitch(ctx context.Context, name string) (string, error) {
+	if robo.tmi == nil {
+		return "", errNoTwitch
+	}
+	name = twitchName(name)
+	robo.cfgMu.Lock()
+	defer robo.cfgMu.Unlock()
+	if _, ok := robo.twitchCfg.joined[name]; !ok {
+		if _, ok := robo.twitchCfg.channels[name]; ok {
+			return name, errConfigured
+		}
+		return name, errNotJoined
+	}
+	if err := robo.roster.Remove(ctx, name); err != nil {
+		return name, err
+	}
+	robo.channels.Delete(name)
+	delete(robo.twitchCfg.channels, name)
+	delete(robo.twitchCfg.joined, name)
+	slog.InfoContext(ctx, "part channel", slog.String("channel", name))
+	return name, robo.sendTwitch(ctx, "PART", name)
+}
+
+// RestoreTwitchChannels adds the channels recorded as joined at runtime.
+// It must be called after SetTwitchChannels.
+// Channels that are also in the config file use their configured settings.
+func (robo *Robot) RestoreTwitchChannels(ctx context.Context) error {
+	l, err := robo.roster.All(ctx)
+	if err != nil {
+		return err
+	}
+	robo.cfgMu.Lock()
+	defer robo.cfgMu.Unlock()
+	for _, c := range l {
+		if _, ok := robo.twitchCfg.channels[c.Name]; ok {
+			slog.WarnContext(ctx, "joined channel is in config; using config settings", slog.String("channel", c.Name))
+			continue
+		}
+		cfg := joinedCfg(robo.twitchCfg.global, c.Name)
+		vs, err := robo.twitchChannels("join", robo.twitchCfg.global, cfg)
+		if err != nil {
+			return err
+		}
+		robo.channels.Store(c.Name, vs[0])
+		robo.twitchCfg.channels[c.Name] = cfg
+		robo.twitchCfg.joined[c.Name] = c.ID
+		slog.InfoContext(ctx, "restore joined channel", slog.String("channel", c.Name), slog.String("id", c.ID))
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Package leaderboard records scores in chat games.
+package leaderboard
+
+import (
+	"context"
+	"fmt"
+
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+)
+
+// Board is a set of per-channel leaderboards backed by an SQL database.
+type Board struct {
+	db *sqlitex.Pool
+}
+
+// Result is one user's result in a round of a game.
+type Result struct {
+	// ID is the platform ID of the user.
+	ID string
+	// Name is the user's display name.
+	Name string
+	// Correct is whether the user won the round.
+	Correct bool
+}
+
+// Entry is a user's standing on a leaderboard.
+type Entry struct {
+	// Name is the user's most recent display name.
+	Name string
+	// Correct is the number of rounds the user has won.
+	Correct int
+	// Played is the number of rounds the user has played.
+	Played int
+}
+
+// Open opens an existing leaderboard in an SQL database.
+func Open(ctx context.Context, db *sqlitex.Pool) (*Board, error) {
+	conn, err := db.Take(ctx)
+	defer db.Put(conn)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get connection from pool: %w", err)
+	}
+	const schemaSQL = `CREATE TABLE IF NOT EXISTS leaderboard (
+	channel TEXT NOT NULL,
+	user TEXT NOT NULL,
+	name TEXT NOT NULL,
+	correct INTEGER NOT NULL,
+	played INTEGER NOT NULL,
+	PRIMARY KEY (channel, user)
+) STRICT, WITHOUT ROWID`
+	if err := sqlitex.ExecuteTransient(conn, schemaSQL, nil); err != nil {
+		return nil, fmt.Errorf("couldn't run migration: %w", err)
+	}
+	return &Board{db: db}, nil
+}
+
+// Record adds the results of a round to a channel's leaderboard.
+func (b *Board) Record(ctx context.Context, channel string, results []Result) (err error) {
+	conn, err := b.db.Take(ctx)
+	defer b.db.Put(conn)
+	if err != nil {
+		return fmt.Errorf("couldn't get connection to record results: %w", err)
+	}
+	defer sqlitex.Transaction(conn)(&err)
+	const upsert = `INSERT INTO leaderboard (channel, user, name, correct, played) VALUES (:channel, :user, :name, :correct, 1)
+ON CONFLICT DO UPDATE SET name=excluded.name, correct=correct+excluded.correct, played=played+1`
+	for _, r := range results {
+		c := 0
+		if r.Correct {
+			c = 1
+		}
+		opts := sqlitex.ExecOptions{
+			Named: map[string]any{":channel": channel, ":user": r.ID, ":name": r.Name, ":correct": c},
+		}
+		if err := sqlitex.Execute(conn, upsert, &opts); err != nil {
+			return fmt.Errorf("couldn't record result: %w", err)
+		}
+	}
+	return nil
+}
+
+// Top gets up to n of the best entries on a channel's leaderboard, best first.
+func (b *Board) Top(ctx context.Context, channel string, n int) ([]Entry, error) {
+	conn, err := b.db.Take(ctx)
+	defer b.db.Put(conn)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get connection to read leaderboard: %w", err)
+	}
+	var r []Entry
+	opts := sqlitex.ExecOptions{
+		Named: map[string]any{":channel": channel, ":n": n},
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			r = append(r, Entry{Name: stmt.ColumnText(0), Correct: stmt.ColumnInt(1), Played: stmt.ColumnInt(2)})
+			return nil
+		},
+	}
+	const sel = `SELECT name, correct, played FROM leaderboard WHERE channel=:channel ORDER BY correct DESC, played, name LIMIT :n`
+	if err := sqlitex.Execute(conn, sel, &opts); err != nil {
+		return nil, fmt.Errorf("couldn't read leaderboard: %w", err)
+	}
+	return r, nil
+}
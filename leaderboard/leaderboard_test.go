@@ -0,0 +1,94 @@
+package leaderboard_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+
+	"github.com/zephyrtronium/robot/leaderboard"
+)
+
+var dbcount atomic.Uint64
+
+func testConn() *sqlitex.Pool {
+	k := dbcount.Add(1)
+	pool, err := sqlitex.NewPool(fmt.Sprintf("file:leaderboard-%d.db?mode=memory&cache=shared", k), sqlitex.PoolOptions{Flags: sqlite.OpenReadWrite | sqlite.OpenCreate | sqlite.OpenMemory | sqlite.OpenSharedCache | sqlite.OpenURI})
+	if err != nil {
+		panic(err)
+	}
+	return pool
+}
+
+func TestBoard(t *testing.T) {
+	ctx := context.Background()
+	b, err := leaderboard.Open(ctx, testConn())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rounds := []struct {
+		channel string
+		results []leaderboard.Result
+	}{
+		{"#kessoku", []leaderboard.Result{{"1", "bocchi", true}, {"2", "ryo", false}, {"3", "nijika", true}}},
+		{"#kessoku", []leaderboard.Result{{"1", "Bocchi", true}, {"2", "ryo", true}}},
+		{"#kessoku", []leaderboard.Result{{"3", "nijika", true}, {"4", "kita", false}}},
+		{"#sickhack", []leaderboard.Result{{"5", "kikuri", true}}},
+	}
+	for _, r := range rounds {
+		if err := b.Record(ctx, r.channel, r.results); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cases := []struct {
+		name    string
+		channel string
+		n       int
+		want    []leaderboard.Entry
+	}{
+		{
+			name:    "all",
+			channel: "#kessoku",
+			n:       10,
+			want: []leaderboard.Entry{
+				{Name: "Bocchi", Correct: 2, Played: 2},
+				{Name: "nijika", Correct: 2, Played: 2},
+				{Name: "ryo", Correct: 1, Played: 2},
+				{Name: "kita", Correct: 0, Played: 1},
+			},
+		},
+		{
+			name:    "top",
+			channel: "#kessoku",
+			n:       1,
+			want:    []leaderboard.Entry{{Name: "Bocchi", Correct: 2, Played: 2}},
+		},
+		{
+			name:    "other",
+			channel: "#sickhack",
+			n:       10,
+			want:    []leaderboard.Entry{{Name: "kikuri", Correct: 1, Played: 1}},
+		},
+		{
+			name:    "none",
+			channel: "#starry",
+			n:       10,
+			want:    nil,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := b.Top(ctx, c.channel, c.n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, c.want) {
+				t.Errorf("wrong leaderboard: want %+v, got %+v", c.want, got)
+			}
+		})
+	}
+}
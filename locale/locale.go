@@ -0,0 +1,89 @@
+// Package locale provides catalogs of localized responses.
+package locale
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"gitlab.com/zephyrtronium/pick"
+	"golang.org/x/text/language"
+)
+
+// Fallback is the locale used for keys that other locales lack.
+// Every key in a catalog should have a response set in it.
+const Fallback = "en"
+
+// Catalog maps locales to keys to response sets.
+// Locales are BCP 47 language tags in canonical form, like "en" or "es-MX".
+type Catalog map[string]map[string]*pick.Dist[string]
+
+// Lookup finds the response set for a key in a locale. If the locale lacks
+// the key, Lookup tries each of its parents in turn, then [Fallback].
+// It returns nil if none of them has the key.
+func (c Catalog) Lookup(lang, key string) *pick.Dist[string] {
+	for l := range parents(lang) {
+		if d := c[l][key]; d != nil {
+			return d
+		}
+	}
+	return c[Fallback][key]
+}
+
+// Pick picks a response for a key in a locale using the random value r.
+// It returns the empty string if the key has no response set.
+func (c Catalog) Pick(lang, key string, r uint32) string {
+	d := c.Lookup(lang, key)
+	if d == nil {
+		return ""
+	}
+	return d.Pick(r)
+}
+
+// Missing returns the keys which the fallback locale has but lang lacks,
+// even considering its parents.
+func (c Catalog) Missing(lang string) []string {
+	var r []string
+	for k := range c[Fallback] {
+		found := false
+		for l := range parents(lang) {
+			if c[l][k] != nil {
+				found = true
+				break
+			}
+		}
+		if !found {
+			r = append(r, k)
+		}
+	}
+	return r
+}
+
+// parents iterates over a locale and its parents, most specific first.
+func parents(lang string) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for lang != "" {
+			if !yield(lang) {
+				return
+			}
+			k := strings.LastIndexByte(lang, '-')
+			if k < 0 {
+				return
+			}
+			lang = lang[:k]
+		}
+	}
+}
+
+// Parse parses and canonicalizes a language tag for use with catalogs.
+// The empty string gives [Fallback].
+func Parse(lang string) (string, error) {
+	if lang == "" {
+		return Fallback, nil
+	}
+	t, err := language.Parse(lang)
+	if err != nil {
+		return "", fmt.Errorf("couldn't parse language %q: %w", lang, err)
+	}
+	return t.String(), nil
+}
@@ -0,0 +1,112 @@
+package locale_test
+
+import (
+	"slices"
+	"testing"
+
+	"gitlab.com/zephyrtronium/pick"
+
+	"github.com/zephyrtronium/robot/locale"
+)
+
+func one(s string) *pick.Dist[string] {
+	return pick.New([]pick.Case[string]{{E: s, W: 1}})
+}
+
+func TestCatalogPick(t *testing.T) {
+	cat := locale.Catalog{
+		"en": {
+			"hello": one("hello"),
+			"bye":   one("bye"),
+			"rock":  one("rock"),
+		},
+		"es": {
+			"hello": one("hola"),
+			"bye":   one("adiós"),
+		},
+		"es-MX": {
+			"bye": one("bye bye"),
+		},
+		"ja": {
+			"hello": one("こんにちは"),
+		},
+	}
+	cases := []struct {
+		name string
+		lang string
+		key  string
+		want string
+	}{
+		{"en", "en", "hello", "hello"},
+		{"es", "es", "hello", "hola"},
+		{"es-fallback", "es", "rock", "rock"},
+		{"region", "es-MX", "bye", "bye bye"},
+		{"region-parent", "es-MX", "hello", "hola"},
+		{"region-fallback", "es-MX", "rock", "rock"},
+		{"ja", "ja", "hello", "こんにちは"},
+		{"ja-fallback", "ja", "bye", "bye"},
+		{"unknown-lang", "de", "hello", "hello"},
+		{"empty-lang", "", "hello", "hello"},
+		{"unknown-key", "ja", "kessoku", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := cat.Pick(c.lang, c.key, 0); got != c.want {
+				t.Errorf("wrong response: want %q, got %q", c.want, got)
+			}
+		})
+	}
+}
+
+func TestCatalogMissing(t *testing.T) {
+	cat := locale.Catalog{
+		"en":    {"hello": one("hello"), "bye": one("bye"), "rock": one("rock")},
+		"es":    {"hello": one("hola"), "bye": one("adiós")},
+		"es-MX": {"bye": one("bye bye")},
+	}
+	cases := []struct {
+		name string
+		lang string
+		want []string
+	}{
+		{"en", "en", nil},
+		{"es", "es", []string{"rock"}},
+		{"es-MX", "es-MX", []string{"rock"}},
+		{"ja", "ja", []string{"bye", "hello", "rock"}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := cat.Missing(c.lang)
+			slices.Sort(got)
+			if !slices.Equal(got, c.want) {
+				t.Errorf("wrong missing keys: want %q, got %q", c.want, got)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+		err  bool
+	}{
+		{"empty", "", "en", false},
+		{"en", "en", "en", false},
+		{"case", "ES-mx", "es-MX", false},
+		{"ja", "ja", "ja", false},
+		{"bad", "bocchi the rock", "", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := locale.Parse(c.in)
+			if (err != nil) != c.err {
+				t.Fatalf("wrong error: want error %t, got %v", c.err, err)
+			}
+			if got != c.want {
+				t.Errorf("wrong tag: want %q, got %q", c.want, got)
+			}
+		})
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"runtime"
 	"strings"
 	"time"
+	_ "time/tzdata" // for quiet hours time zones in minimal images
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/urfave/cli/v3"
@@ -109,11 +110,12 @@ func cliRun(ctx context.Context, cmd *cli.Command) error {
 	}
 	robo := New(secrets.userhash, runtime.GOMAXPROCS(0))
 	robo.SetOwner(cfg.Owner.Name, cfg.Owner.Contact)
-	kv, sql, priv, spoke, err := loadDBs(ctx, cfg.DB)
+	robo.configFile = cmd.String("config")
+	kv, sql, priv, spoke, state, err := loadDBs(ctx, cfg.DB)
 	if err != nil {
 		return err
 	}
-	if err := robo.SetSources(ctx, kv, sql, priv, spoke); err != nil {
+	if err := robo.SetSources(ctx, kv, sql, priv, spoke, state); err != nil {
 		return err
 	}
 
@@ -132,6 +134,15 @@ func cliRun(ctx context.Context, cmd *cli.Command) error {
 	if err := robo.SetTwitchChannels(ctx, cfg.Global, cfg.Twitch); err != nil {
 		return err
 	}
+	if err := robo.RestoreTwitchChannels(ctx); err != nil {
+		return err
+	}
+	if err := robo.LoadBlockTerms(ctx); err != nil {
+		return err
+	}
+	if err := robo.RestoreQuiet(ctx); err != nil {
+		return err
+	}
 
 	return robo.Run(ctx, cfg.HTTP.Listen)
 }
@@ -147,7 +158,7 @@ func cliSpeak(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("couldn't load config: %w", err)
 	}
 	r.Close()
-	kv, sql, _, _, err := loadDBs(ctx, cfg.DB)
+	kv, sql, _, _, _, err := loadDBs(ctx, cfg.DB)
 	if err != nil {
 		return err
 	}
@@ -198,7 +209,7 @@ func cliAncient(ctx context.Context, cmd *cli.Command) error {
 		return fmt.Errorf("couldn't load config: %w", err)
 	}
 	r.Close()
-	kv, sql, _, _, err := loadDBs(ctx, cfg.DB)
+	kv, sql, _, _, _, err := loadDBs(ctx, cfg.DB)
 	if err != nil {
 		return err
 	}
@@ -399,5 +410,16 @@ func newMetrics() *metrics.Metrics {
 				},
 			),
 		),
+		QuotaDroppedCount: metrics.NewPromCounterVec(
+			prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: "robot",
+					Subsystem: "brain",
+					Name:      "quota_dropped",
+					Help:      "Number of messages not learned because the sender exceeded the learning quota.",
+				},
+				[]string{"tag"},
+			),
+		),
 	}
 }
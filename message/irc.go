@@ -22,6 +22,11 @@ func FromTMI(m *tmi.Message) *Received[User] {
 		IsModerator: moderator(m),
 		IsElevated:  elevated(m),
 	}
+	if pid, ok := m.Tag("reply-parent-msg-id"); ok {
+		login, _ := m.Tag("reply-parent-user-login")
+		body, _ := m.Tag("reply-parent-msg-body")
+		r.Parent = &Parent{ID: pid, Sender: login, Text: body}
+	}
 	return &r
 }
 
@@ -29,6 +34,11 @@ func moderator(m *tmi.Message) bool {
 	// The mod tag is unreliable, as it is false for broadcasters and
 	// lead moderators. Badges are the only reliable source for this info.
 	badges, _ := m.Tag("badges")
+	// In shared chat, the sender's badges in the channel where they sent the
+	// message are what determine their permissions.
+	if src, ok := m.Tag("source-badges"); ok {
+		badges = src
+	}
 	for badges != "" {
 		b, rest, _ := strings.Cut(badges, ",")
 		b, _, _ = strings.Cut(b, "/")
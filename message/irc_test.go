@@ -23,6 +23,7 @@ func TestFromIRC(t *testing.T) {
 		time   time.Time
 		mod    bool
 		elev   bool
+		parent *message.Parent
 	}{
 		{
 			name:   "regular",
@@ -96,6 +97,47 @@ func TestFromIRC(t *testing.T) {
 			mod:    true,
 			elev:   true,
 		},
+		{
+			name:   "shared-source-moderator",
+			msg:    sharedSourceMod,
+			to:     "#barrycarlyon",
+			id:     "29fd8f1a-6f57-48d8-9bbf-abc05875edf3",
+			sender: "794780266",
+			disp:   "BarryCarIyon",
+			text:   "test",
+			time:   time.UnixMilli(1766005913352),
+			mod:    true,
+			elev:   false,
+		},
+		{
+			name:   "shared-host-moderator",
+			msg:    sharedHostMod,
+			to:     "#barrycarlyon",
+			id:     "7e0e3c1a-41b3-4d8e-9d3c-2a2d7f4a8c11",
+			sender: "794780266",
+			disp:   "BarryCarIyon",
+			text:   "test",
+			time:   time.UnixMilli(1766005923352),
+			mod:    false,
+			elev:   false,
+		},
+		{
+			name:   "reply",
+			msg:    reply,
+			to:     "#barrycarlyon",
+			id:     "5f1c9a3e-2b7d-4e8a-9c6f-1d2e3f4a5b6c",
+			sender: "123456789",
+			disp:   "Someone",
+			text:   "@Robot quote that",
+			time:   time.UnixMilli(1766006013352),
+			mod:    false,
+			elev:   false,
+			parent: &message.Parent{
+				ID:     "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d",
+				Sender: "robot",
+				Text:   "bocchi the rock; kessoku band",
+			},
+		},
 		// TODO(zeph): more cases
 	}
 	for _, c := range cases {
@@ -129,6 +171,22 @@ func TestFromIRC(t *testing.T) {
 			if got := msg.IsElevated; got != c.elev {
 				t.Errorf("wrong elev: want %t, got %t", c.elev, got)
 			}
+			switch got := msg.Parent; {
+			case got == nil && c.parent == nil: // do nothing
+			case got == nil || c.parent == nil:
+				t.Errorf("wrong parent: want %+v, got %+v", c.parent, got)
+			case *got != *c.parent:
+				t.Errorf("wrong parent: want %+v, got %+v", *c.parent, *got)
+			}
 		})
 	}
 }
+
+// Messages received in one room from shared chat with another.
+const (
+	sharedSourceMod = `@badge-info=;badges=;client-nonce=dbaa1398930a8d4d218b15bb3def3372;color=#008000;display-name=BarryCarIyon;emotes=;first-msg=0;flags=;id=29fd8f1a-6f57-48d8-9bbf-abc05875edf3;mod=0;returning-chatter=0;room-id=15185913;source-badge-info=;source-badges=moderator/1;source-id=2c66fbc3-ab73-46d1-8a33-266160ea8433;source-only=0;source-room-id=141981764;subscriber=0;tmi-sent-ts=1766005913352;turbo=0;user-id=794780266;user-type= :barrycariyon!barrycariyon@barrycariyon.tmi.twitch.tv PRIVMSG #barrycarlyon :test`
+	sharedHostMod   = `@badge-info=;badges=moderator/1;client-nonce=8ee80ebf87a9ee2b2ae48cd845ec0ba9;color=#008000;display-name=BarryCarIyon;emotes=;first-msg=0;flags=;id=7e0e3c1a-41b3-4d8e-9d3c-2a2d7f4a8c11;mod=1;returning-chatter=0;room-id=15185913;source-badge-info=;source-badges=;source-id=73099b41-cbc4-4dcb-be4a-26f6cea1c440;source-only=0;source-room-id=141981764;subscriber=0;tmi-sent-ts=1766005923352;turbo=0;user-id=794780266;user-type=mod :barrycariyon!barrycariyon@barrycariyon.tmi.twitch.tv PRIVMSG #barrycarlyon :test`
+)
+
+// A reply to a message from the bot.
+const reply = `@badge-info=;badges=;client-nonce=3c4d5e6f7a8b9c0d1e2f3a4b5c6d7e8f;color=#B22222;display-name=Someone;emotes=;first-msg=0;flags=;id=5f1c9a3e-2b7d-4e8a-9c6f-1d2e3f4a5b6c;mod=0;reply-parent-display-name=Robot;reply-parent-msg-body=bocchi\sthe\srock\:\skessoku\sband;reply-parent-msg-id=9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d;reply-parent-user-id=987654321;reply-parent-user-login=robot;reply-thread-parent-msg-id=9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d;reply-thread-parent-user-login=robot;returning-chatter=0;room-id=15185913;subscriber=0;tmi-sent-ts=1766006013352;turbo=0;user-id=123456789;user-type= :someone!someone@someone.tmi.twitch.tv PRIVMSG #barrycarlyon :@Robot quote that`
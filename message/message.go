@@ -29,6 +29,19 @@ type Received[U comparable] struct {
 	// elevated privileges with respect to the bot, for example a subscriber
 	// on Twitch. This may not implicitly include moderators.
 	IsElevated bool
+	// Parent is the message to which this one is a reply, or nil if it is
+	// not a reply.
+	Parent *Parent
+}
+
+// Parent describes the message to which a received message replies.
+type Parent struct {
+	// ID is the unique ID of the parent message.
+	ID string
+	// Sender is the login name of the parent message's sender.
+	Sender string
+	// Text is the text of the parent message.
+	Text string
 }
 
 func (m *Received[U]) Time() time.Time {
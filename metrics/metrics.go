@@ -19,6 +19,7 @@ type Metrics struct {
 	LearnLatency              Observer
 	UsedMessagesForGeneration Observer
 	TMISendWait               Observer
+	QuotaDroppedCount         Observer
 }
 
 func (m Metrics) Collectors() []prometheus.Collector {
@@ -31,5 +32,6 @@ func (m Metrics) Collectors() []prometheus.Collector {
 		m.LearnLatency,
 		m.UsedMessagesForGeneration,
 		m.TMISendWait,
+		m.QuotaDroppedCount,
 	}
 }
@@ -22,6 +22,15 @@ func NewPromGauge(m prometheus.Counter) Observer {
 	}
 }
 
+func NewPromCounterVec(m *prometheus.CounterVec) Observer {
+	return &PrometheusMetric{
+		observe: func(val float64, labels ...string) {
+			m.WithLabelValues(labels...).Add(val)
+		},
+		Collector: m,
+	}
+}
+
 // for histogram or summary vecs
 func NewPromObserverVec(m prometheus.ObserverVec) Observer {
 	return &PrometheusMetric{
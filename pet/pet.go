@@ -1,6 +1,7 @@
 package pet
 
 import (
+	"fmt"
 	"math/rand/v2"
 	"sync"
 	"time"
@@ -10,13 +11,38 @@ import (
 // Its methods are concurrent by way of mutual exclusion.
 type Status struct {
 	mu sync.Mutex
+	// name is the pet's name in its [Store].
+	name string
 
 	fed, bed, kitche, living, bath, pats time.Time
+	play, rest, laun                     time.Time
+
+	// bedtime is when the pet gets tired each day.
+	// If it is nil, the pet gets tired whenever it has been up for a day.
+	bedtime *Bedtime
+}
+
+// needs maps the names of the pet's needs as saved in its [Store] to the
+// times until which they are satisfied.
+// The pet's mutex must be held while using the result.
+func (s *Status) needs() map[string]*time.Time {
+	return map[string]*time.Time{
+		"fed":     &s.fed,
+		"bedroom": &s.bed,
+		"kitchen": &s.kitche,
+		"living":  &s.living,
+		"bath":    &s.bath,
+		"pats":    &s.pats,
+		"play":    &s.play,
+		"rest":    &s.rest,
+		"laundry": &s.laun,
+	}
 }
 
 // Satisfaction is an instantaneous view of which of a pet's needs are satisfied.
 type Satisfaction struct {
 	Fed, Bed, Kitche, Living, Bath, Pats bool
+	Play, Rest, Laun                     bool
 }
 
 // satLocked gets the pet's satisfaction.
@@ -29,6 +55,9 @@ func (s *Status) satLocked(asof time.Time) Satisfaction {
 		Living: asof.Before(s.living),
 		Bath:   asof.Before(s.bath),
 		Pats:   asof.Before(s.pats),
+		Play:   asof.Before(s.play),
+		Rest:   asof.Before(s.rest) || !s.tiredLocked(asof),
+		Laun:   asof.Before(s.laun),
 	}
 }
 
@@ -135,11 +164,15 @@ func (s *Status) Clean(asof time.Time) (Room, Satisfaction) {
 // love is interpreted as a number of minutes for which the pet will feel loved
 // with this pat. If the resulting time expires before its existing love, it
 // has no effect.
-// If all the pet's other needs are met, but not pat, the pat becomes stronger.
+// If the pet is fed and all its rooms are clean, but it hasn't been patted,
+// the pat becomes stronger.
 func (s *Status) Pat(asof time.Time, love int) Satisfaction {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	r := s.satLocked(asof)
+	// Only the original needs count toward the stronger pat, so that it's no
+	// harder to get than it was before the pet had more needs.
+	r.Play, r.Rest, r.Laun = false, false, false
 	if r == (Satisfaction{Fed: true, Bed: true, Kitche: true, Living: true, Bath: true, Pats: false}) {
 		love *= 2
 	}
@@ -149,3 +182,130 @@ func (s *Status) Pat(asof time.Time, love int) Satisfaction {
 	}
 	return s.satLocked(asof)
 }
+
+// Play plays with the pet, unless it's asleep.
+// fun is interpreted as a number of minutes for which the pet will feel
+// played with. If the resulting time expires before its existing fun, it has
+// no effect.
+//
+// The first return value is true when the pet plays.
+// The second is its satisfaction after playing.
+func (s *Status) Play(asof time.Time, fun int) (bool, Satisfaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.asleepLocked(asof) {
+		return false, s.satLocked(asof)
+	}
+	sat := asof.Add(time.Duration(fun) * time.Minute)
+	if s.play.Before(sat) {
+		s.play = sat
+	}
+	return true, s.satLocked(asof)
+}
+
+const (
+	// sleepTime is how long the pet sleeps once it goes to bed.
+	// It is also how long after its bedtime the pet is tired.
+	sleepTime = 8 * time.Hour
+	// restTime is how long the pet stays rested after its bedtime.
+	restTime = 24 * time.Hour
+)
+
+// Bedtime is the time of day at which a pet gets tired.
+type Bedtime struct {
+	// Hour and Minute are the time of day.
+	Hour, Minute int
+	// Loc is the time zone of the bedtime.
+	Loc *time.Location
+}
+
+// ParseBedtime parses a bedtime given as hh:mm in a time zone.
+// An empty time zone means UTC.
+func ParseBedtime(clock, tz string) (*Bedtime, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return nil, fmt.Errorf("bad bedtime %q; want hh:mm", clock)
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, fmt.Errorf("bad bedtime time zone: %w", err)
+	}
+	return &Bedtime{Hour: t.Hour(), Minute: t.Minute(), Loc: loc}, nil
+}
+
+// last gets the most recent bedtime at or before asof.
+func (b *Bedtime) last(asof time.Time) time.Time {
+	t := asof.In(b.Loc)
+	r := time.Date(t.Year(), t.Month(), t.Day(), b.Hour, b.Minute, 0, 0, b.Loc)
+	if r.After(t) {
+		r = time.Date(t.Year(), t.Month(), t.Day()-1, b.Hour, b.Minute, 0, 0, b.Loc)
+	}
+	return r
+}
+
+// SetBedtime sets the time of day at which the pet gets tired.
+// If b is nil, the pet gets tired whenever it has been up for a day.
+func (s *Status) SetBedtime(b *Bedtime) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.bedtime = b
+}
+
+// tiredLocked reports whether it's the pet's time to sleep, whether or not
+// it has already gone to bed.
+// The pet's mutex must be held during the call.
+func (s *Status) tiredLocked(asof time.Time) bool {
+	if s.bedtime == nil {
+		return true
+	}
+	return asof.Before(s.bedtime.last(asof).Add(sleepTime))
+}
+
+// Sleep puts the pet to bed, if it's tired.
+// With a bedtime, the pet is tired for eight hours from its bedtime each day
+// and sleeps until the end of that time. Without one, it is tired once a day
+// and sleeps for eight hours. It won't play while asleep.
+//
+// The first return value is true when the pet goes to bed.
+// The second is its satisfaction after going to bed.
+func (s *Status) Sleep(asof time.Time) (bool, Satisfaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if asof.Before(s.rest) || !s.tiredLocked(asof) {
+		return false, s.satLocked(asof)
+	}
+	if s.bedtime != nil {
+		s.rest = s.bedtime.last(asof).Add(restTime)
+	} else {
+		s.rest = asof.Add(restTime)
+	}
+	return true, s.satLocked(asof)
+}
+
+// Asleep reports whether the pet is asleep.
+func (s *Status) Asleep(asof time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.asleepLocked(asof)
+}
+
+// asleepLocked reports whether the pet is asleep.
+// The pet's mutex must be held during the call.
+func (s *Status) asleepLocked(asof time.Time) bool {
+	return asof.Before(s.rest.Add(sleepTime - restTime))
+}
+
+// Launder does the pet's laundry in the laundry room, if it needs to be done.
+// Clean laundry lasts three days.
+//
+// The first return value is true when the laundry was dirty.
+// The second is its satisfaction after doing laundry.
+func (s *Status) Launder(asof time.Time) (bool, Satisfaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if asof.Before(s.laun) {
+		return false, s.satLocked(asof)
+	}
+	s.laun = asof.Add(72 * time.Hour)
+	return true, s.satLocked(asof)
+}
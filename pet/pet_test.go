@@ -99,3 +99,158 @@ func TestPat(t *testing.T) {
 		t.Errorf("wrong satisfied 121 seconds after pat: got %+v, want none", sat)
 	}
 }
+
+func TestPlay(t *testing.T) {
+	var s pet.Status
+	now := time.Now()
+	ok, sat := s.Play(now, 30)
+	if !ok {
+		t.Errorf("failed to play")
+	}
+	if sat != (pet.Satisfaction{Play: true}) {
+		t.Errorf("wrong satisfied after play: got %+v, want only play", sat)
+	}
+	sat = s.Satisfaction(now.Add(31 * time.Minute))
+	if sat != (pet.Satisfaction{}) {
+		t.Errorf("play didn't expire: %+v", sat)
+	}
+	later := now.Add(time.Hour)
+	if ok, _ := s.Sleep(later); !ok {
+		t.Fatalf("failed to sleep")
+	}
+	ok, sat = s.Play(later.Add(time.Hour), 30)
+	if ok {
+		t.Errorf("played while asleep")
+	}
+	if sat != (pet.Satisfaction{Rest: true}) {
+		t.Errorf("wrong satisfied after play while asleep: got %+v, want only rest", sat)
+	}
+	ok, sat = s.Play(later.Add(9*time.Hour), 30)
+	if !ok {
+		t.Errorf("failed to play after waking")
+	}
+	if sat != (pet.Satisfaction{Play: true, Rest: true}) {
+		t.Errorf("wrong satisfied after play after waking: got %+v, want play and rest", sat)
+	}
+}
+
+func TestSleep(t *testing.T) {
+	var s pet.Status
+	now := time.Now()
+	if s.Asleep(now) {
+		t.Errorf("asleep before going to bed")
+	}
+	ok, sat := s.Sleep(now)
+	if !ok {
+		t.Errorf("failed to sleep first time")
+	}
+	if sat != (pet.Satisfaction{Rest: true}) {
+		t.Errorf("wrong satisfied after sleep: got %+v, want only rest", sat)
+	}
+	if !s.Asleep(now.Add(7 * time.Hour)) {
+		t.Errorf("awake during sleep")
+	}
+	if s.Asleep(now.Add(8*time.Hour + 1)) {
+		t.Errorf("asleep after sleep")
+	}
+	ok, _ = s.Sleep(now.Add(12 * time.Hour))
+	if ok {
+		t.Errorf("slept twice in one day")
+	}
+	sat = s.Satisfaction(now.Add(24*time.Hour + 1))
+	if sat != (pet.Satisfaction{}) {
+		t.Errorf("rest didn't expire: %+v", sat)
+	}
+	ok, _ = s.Sleep(now.Add(24*time.Hour + 1))
+	if !ok {
+		t.Errorf("failed to sleep the next day")
+	}
+}
+
+func TestBedtime(t *testing.T) {
+	bed, err := pet.ParseBedtime("22:00", "UTC")
+	if err != nil {
+		t.Fatalf("couldn't parse bedtime: %v", err)
+	}
+	at := func(d, h, m int) time.Time { return time.Date(2024, 6, d, h, m, 0, 0, time.UTC) }
+	var s pet.Status
+	s.SetBedtime(bed)
+	if sat := s.Satisfaction(at(1, 12, 0)); !sat.Rest {
+		t.Errorf("tired before bedtime")
+	}
+	if ok, _ := s.Sleep(at(1, 12, 0)); ok {
+		t.Errorf("went to bed before bedtime")
+	}
+	if sat := s.Satisfaction(at(1, 22, 30)); sat.Rest {
+		t.Errorf("not tired after bedtime")
+	}
+	ok, sat := s.Sleep(at(1, 22, 30))
+	if !ok {
+		t.Fatalf("didn't go to bed after bedtime")
+	}
+	if !sat.Rest {
+		t.Errorf("not rested after going to bed")
+	}
+	// The pet wakes up eight hours after its bedtime, not after going to bed.
+	if !s.Asleep(at(2, 5, 59)) {
+		t.Errorf("awake during the night")
+	}
+	if s.Asleep(at(2, 6, 0)) {
+		t.Errorf("asleep in the morning")
+	}
+	if ok, _ := s.Sleep(at(2, 1, 0)); ok {
+		t.Errorf("went to bed twice in one night")
+	}
+	if sat := s.Satisfaction(at(2, 21, 59)); !sat.Rest {
+		t.Errorf("tired before the next bedtime")
+	}
+	if sat := s.Satisfaction(at(2, 22, 0)); sat.Rest {
+		t.Errorf("not tired at the next bedtime")
+	}
+	// Past midnight still counts as the night before.
+	if ok, _ := s.Sleep(at(3, 2, 0)); !ok {
+		t.Errorf("didn't go to bed after midnight")
+	}
+	if s.Asleep(at(3, 6, 0)) {
+		t.Errorf("slept past the morning")
+	}
+	for _, c := range []struct{ clock, tz string }{{"25:00", ""}, {"10pm", ""}, {"22:00", "Nowhere/Special"}} {
+		if _, err := pet.ParseBedtime(c.clock, c.tz); err == nil {
+			t.Errorf("no error parsing bedtime %q in %q", c.clock, c.tz)
+		}
+	}
+}
+
+func TestPatBonus(t *testing.T) {
+	var s pet.Status
+	now := time.Now()
+	s.Feed(now, 480)
+	for range 4 {
+		s.Clean(now)
+	}
+	// Play, rest, and laundry don't matter for the stronger pat.
+	s.Pat(now, 2)
+	if sat := s.Satisfaction(now.Add(3 * time.Minute)); !sat.Pats {
+		t.Errorf("pat wasn't stronger with original needs met")
+	}
+}
+
+func TestLaunder(t *testing.T) {
+	var s pet.Status
+	now := time.Now()
+	ok, sat := s.Launder(now)
+	if !ok {
+		t.Errorf("didn't do dirty laundry")
+	}
+	if sat != (pet.Satisfaction{Laun: true}) {
+		t.Errorf("wrong satisfied after laundry: got %+v, want only laundry", sat)
+	}
+	ok, _ = s.Launder(now.Add(time.Hour))
+	if ok {
+		t.Errorf("did clean laundry")
+	}
+	sat = s.Satisfaction(now.Add(72*time.Hour + 1))
+	if sat != (pet.Satisfaction{}) {
+		t.Errorf("laundry didn't expire: %+v", sat)
+	}
+}
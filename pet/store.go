@@ -0,0 +1,113 @@
+package pet
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+)
+
+// Store is the collection of named pets, saved in an SQL database.
+// Each name identifies one pet; channels that share a pet use the same name.
+type Store struct {
+	db *sqlitex.Pool
+
+	mu   sync.Mutex
+	pets map[string]*Status
+}
+
+// Open opens the pets saved in an SQL database, creating the table if needed.
+// All saved pets are loaded immediately.
+func Open(ctx context.Context, db *sqlitex.Pool) (*Store, error) {
+	conn, err := db.Take(ctx)
+	defer db.Put(conn)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get connection from pool: %w", err)
+	}
+	const schemaSQL = `CREATE TABLE IF NOT EXISTS pet (name TEXT NOT NULL, need TEXT NOT NULL, until INTEGER NOT NULL, PRIMARY KEY (name, need)) STRICT, WITHOUT ROWID`
+	if err := sqlitex.ExecuteTransient(conn, schemaSQL, nil); err != nil {
+		return nil, fmt.Errorf("couldn't run migration: %w", err)
+	}
+	s := &Store{db: db, pets: make(map[string]*Status)}
+	opts := sqlitex.ExecOptions{
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			p := s.petLocked(stmt.ColumnText(0))
+			// Needs we don't know about are from some other version.
+			// Ignore them rather than failing to start.
+			if t := p.needs()[stmt.ColumnText(1)]; t != nil {
+				*t = time.Unix(0, stmt.ColumnInt64(2))
+			}
+			return nil
+		},
+	}
+	if err := sqlitex.Execute(conn, `SELECT name, need, until FROM pet`, &opts); err != nil {
+		return nil, fmt.Errorf("couldn't load pets: %w", err)
+	}
+	return s, nil
+}
+
+// Pet gets the pet with the given name, creating it if it doesn't exist.
+// If s is nil, the result is a new pet that is never saved.
+func (s *Store) Pet(name string) *Status {
+	if s == nil {
+		return &Status{name: name}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.petLocked(name)
+}
+
+// petLocked gets or creates a pet.
+// The store's mutex must be held during the call, or the store must not yet
+// be shared.
+func (s *Store) petLocked(name string) *Status {
+	p := s.pets[name]
+	if p == nil {
+		p = &Status{name: name}
+		s.pets[name] = p
+	}
+	return p
+}
+
+// Save records a pet's current status.
+// If s is nil, Save does nothing.
+func (s *Store) Save(ctx context.Context, p *Status) (err error) {
+	if s == nil {
+		return nil
+	}
+	p.mu.Lock()
+	name := p.name
+	needs := make(map[string]int64)
+	for k, t := range p.needs() {
+		if !t.IsZero() {
+			needs[k] = t.UnixNano()
+		}
+	}
+	p.mu.Unlock()
+
+	conn, err := s.db.Take(ctx)
+	defer s.db.Put(conn)
+	if err != nil {
+		return fmt.Errorf("couldn't get connection to save pet: %w", err)
+	}
+	defer sqlitex.Transaction(conn)(&err)
+	st, err := conn.Prepare(`INSERT OR REPLACE INTO pet (name, need, until) VALUES (:name, :need, :until)`)
+	if err != nil {
+		return fmt.Errorf("couldn't prepare statement to save pet: %w", err)
+	}
+	for k, t := range needs {
+		st.SetText(":name", name)
+		st.SetText(":need", k)
+		st.SetInt64(":until", t)
+		if _, err := st.Step(); err != nil {
+			return fmt.Errorf("couldn't save pet: %w", err)
+		}
+		if err := st.Reset(); err != nil {
+			return fmt.Errorf("couldn't reset statement to save pet: %w", err)
+		}
+	}
+	return nil
+}
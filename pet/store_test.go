@@ -0,0 +1,81 @@
+package pet_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+
+	"github.com/zephyrtronium/robot/pet"
+)
+
+var dbcount atomic.Uint64
+
+func testConn() *sqlitex.Pool {
+	k := dbcount.Add(1)
+	pool, err := sqlitex.NewPool(fmt.Sprintf("file:pet-%d.db?mode=memory&cache=shared", k), sqlitex.PoolOptions{Flags: sqlite.OpenReadWrite | sqlite.OpenCreate | sqlite.OpenMemory | sqlite.OpenSharedCache | sqlite.OpenURI})
+	if err != nil {
+		panic(err)
+	}
+	return pool
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	db := testConn()
+	s, err := pet.Open(ctx, db)
+	if err != nil {
+		t.Fatalf("couldn't open store: %v", err)
+	}
+	if s.Pet("bocchi") != s.Pet("bocchi") {
+		t.Errorf("same name gave different pets")
+	}
+	if s.Pet("bocchi") == s.Pet("kita") {
+		t.Errorf("different names gave the same pet")
+	}
+	now := time.Now()
+	p := s.Pet("bocchi")
+	p.Feed(now, 60)
+	p.Pat(now, 2)
+	if err := s.Save(ctx, p); err != nil {
+		t.Fatalf("couldn't save pet: %v", err)
+	}
+	if err := s.Save(ctx, s.Pet("kita")); err != nil {
+		t.Fatalf("couldn't save unsatisfied pet: %v", err)
+	}
+
+	r, err := pet.Open(ctx, db)
+	if err != nil {
+		t.Fatalf("couldn't reopen store: %v", err)
+	}
+	want := pet.Satisfaction{Fed: true, Pats: true}
+	if got := r.Pet("bocchi").Satisfaction(now); got != want {
+		t.Errorf("wrong satisfaction after reload: want %+v, got %+v", want, got)
+	}
+	want = pet.Satisfaction{Fed: true}
+	if got := r.Pet("bocchi").Satisfaction(now.Add(3 * time.Minute)); got != want {
+		t.Errorf("wrong satisfaction later after reload: want %+v, got %+v", want, got)
+	}
+	if got := r.Pet("kita").Satisfaction(now); got != (pet.Satisfaction{}) {
+		t.Errorf("unsatisfied pet became satisfied: %+v", got)
+	}
+	if got := r.Pet("ryo").Satisfaction(now); got != (pet.Satisfaction{}) {
+		t.Errorf("new pet is satisfied: %+v", got)
+	}
+}
+
+func TestNilStore(t *testing.T) {
+	var s *pet.Store
+	p := s.Pet("bocchi")
+	if p == nil {
+		t.Fatal("nil store gave nil pet")
+	}
+	p.Feed(time.Now(), 60)
+	if err := s.Save(context.Background(), p); err != nil {
+		t.Errorf("nil store failed to save: %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"github.com/zephyrtronium/robot/command"
+	"github.com/zephyrtronium/robot/message"
+)
+
+// defaultPetAnnounce is the time between the pet's announcements of its needs
+// in each channel when the config does not set one.
+const defaultPetAnnounce = time.Hour
+
+// defaultBedtime is the time of day when the pet gets tired when the config
+// does not set one.
+const defaultBedtime = "22:00"
+
+// petLoop has the pet occasionally mention its needs in channels that are
+// online, checking every few minutes.
+func (robo *Robot) petLoop(ctx context.Context) error {
+	// last maps channel names to the times the pet last spoke up in them,
+	// or the times the loop first saw them.
+	last := make(map[string]time.Time)
+	tick := time.NewTicker(5 * time.Minute)
+	defer tick.Stop()
+	for {
+		robo.cfgMu.Lock()
+		every := fseconds(robo.twitchCfg.global.Pet.Announce)
+		robo.cfgMu.Unlock()
+		if every == 0 {
+			every = defaultPetAnnounce
+		}
+		if every > 0 {
+			robo.announcePets(ctx, last, time.Now(), every)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tick.C: // continue on
+		}
+	}
+}
+
+// announcePets has the pet mention its most pressing need in each online
+// channel where it hasn't spoken up for at least every.
+func (robo *Robot) announcePets(ctx context.Context, last map[string]time.Time, now time.Time, every time.Duration) {
+	for name, ch := range robo.channels.All() {
+		if ch.Pet == nil || ch.Message == nil {
+			continue
+		}
+		if !ch.Enabled.Load() {
+			// Offline. Start over when the stream comes back so that the pet
+			// doesn't greet it with a complaint.
+			delete(last, name)
+			continue
+		}
+		prev, ok := last[name]
+		if !ok {
+			last[name] = now
+			continue
+		}
+		if now.Sub(prev) < every {
+			continue
+		}
+		if now.Before(ch.SilentTime()) || ch.Pet.Asleep(now) {
+			continue
+		}
+		need := command.PetNeed(ch.Lang, ch.Pet.Satisfaction(now))
+		if need == "" {
+			continue
+		}
+		r := ch.Rate.ReserveN(now, 1)
+		if d := r.DelayFrom(now); d > 0 {
+			slog.InfoContext(ctx, "won't announce pet needs; rate limited",
+				slog.String("in", name),
+				slog.String("delay", d.String()),
+			)
+			r.CancelAt(now)
+			continue
+		}
+		last[name] = now
+		e := ch.Emotes.Pick(rand.Uint32())
+		slog.InfoContext(ctx, "pet needs", slog.String("in", name), slog.String("text", need))
+		ch.Message(ctx, message.Format("%s %s", need, e))
+	}
+}
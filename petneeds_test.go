@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gitlab.com/zephyrtronium/pick"
+	"golang.org/x/time/rate"
+
+	"github.com/zephyrtronium/robot/channel"
+	"github.com/zephyrtronium/robot/message"
+	"github.com/zephyrtronium/robot/pet"
+)
+
+func TestAnnouncePets(t *testing.T) {
+	at := func(m int) time.Time { return time.Date(2024, 6, 2, 12, m, 0, 0, time.UTC) }
+	type step struct {
+		now    time.Time
+		online bool
+		silent time.Time // silent time to set before announcing, if not zero
+		sent   int
+	}
+	cases := []struct {
+		name  string
+		happy bool
+		burst int
+		steps []step
+	}{
+		{
+			name:  "cycle",
+			burst: 10,
+			steps: []step{
+				{now: at(0), online: true},
+				{now: at(30), online: true},
+				{now: at(60), online: true, sent: 1},
+				{now: at(90), online: true, sent: 1},
+				{now: at(120), online: true, sent: 2},
+			},
+		},
+		{
+			name:  "offline",
+			burst: 10,
+			steps: []step{
+				{now: at(0), online: true},
+				{now: at(60), online: false},
+				{now: at(70), online: true},
+				{now: at(120), online: true},
+				{now: at(130), online: true, sent: 1},
+			},
+		},
+		{
+			name:  "silent",
+			burst: 10,
+			steps: []step{
+				{now: at(0), online: true},
+				{now: at(60), online: true, silent: at(90)},
+				{now: at(90), online: true, sent: 1},
+			},
+		},
+		{
+			name:  "rate-limited",
+			burst: 0,
+			steps: []step{
+				{now: at(0), online: true},
+				{now: at(60), online: true},
+				{now: at(120), online: true},
+			},
+		},
+		{
+			name:  "happy",
+			happy: true,
+			burst: 10,
+			steps: []step{
+				{now: at(0), online: true},
+				{now: at(60), online: true},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			robo := New(make([]byte, 64), 1)
+			var sent []string
+			ch := &channel.Channel{
+				Name:    "#bocchi",
+				Message: func(ctx context.Context, msg message.Sent) { sent = append(sent, msg.Text) },
+				Pet:     new(pet.Status),
+				Rate:    rate.NewLimiter(0, c.burst),
+				Emotes:  pick.New([]pick.Case[string]{{E: "", W: 1}}),
+				Silent:  new(atomic.Int64),
+				Enabled: new(atomic.Bool),
+			}
+			if c.happy {
+				ch.Pet.Feed(at(0), 480)
+				for range 4 {
+					ch.Pet.Clean(at(0))
+				}
+				ch.Pet.Launder(at(0))
+				ch.Pet.Play(at(0), 480)
+				ch.Pet.Sleep(at(0).Add(-12 * time.Hour))
+				ch.Pet.Pat(at(0), 480)
+			}
+			robo.channels.Store(ch.Name, ch)
+			last := make(map[string]time.Time)
+			for i, s := range c.steps {
+				ch.Enabled.Store(s.online)
+				if !s.silent.IsZero() {
+					ch.Silent.Store(s.silent.UnixNano())
+				}
+				robo.announcePets(t.Context(), last, s.now, time.Hour)
+				if len(sent) != s.sent {
+					t.Errorf("step %d: wrong number of announcements: want %d, got %d (%q)", i, s.sent, len(sent), sent)
+				}
+			}
+		})
+	}
+}
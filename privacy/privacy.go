@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"zombiezen.com/go/sqlite"
 	"zombiezen.com/go/sqlite/sqlitex"
 )
 
@@ -75,3 +76,23 @@ func (l *List) Check(ctx context.Context, user string) error {
 	}
 	return nil
 }
+
+// All lists the users in the database.
+func (l *List) All(ctx context.Context) ([]string, error) {
+	conn, err := l.db.Take(ctx)
+	defer l.db.Put(conn)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get connection to list private users: %w", err)
+	}
+	var r []string
+	opts := sqlitex.ExecOptions{
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			r = append(r, stmt.ColumnText(0))
+			return nil
+		},
+	}
+	if err := sqlitex.Execute(conn, `SELECT user FROM privacy ORDER BY user`, &opts); err != nil {
+		return nil, fmt.Errorf("couldn't list private users: %w", err)
+	}
+	return r, nil
+}
@@ -3,6 +3,7 @@ package privacy_test
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync/atomic"
 	"testing"
 
@@ -134,3 +135,27 @@ func TestList(t *testing.T) {
 		})
 	}
 }
+
+func TestAll(t *testing.T) {
+	ctx := context.Background()
+	l, err := privacy.Open(ctx, testConn())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, u := range []string{"ryou", "bocchi", "kita"} {
+		if err := l.Add(ctx, u); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := l.Remove(ctx, "kita"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := l.All(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"bocchi", "ryou"}
+	if !slices.Equal(got, want) {
+		t.Errorf("wrong users: want %q, got %q", want, got)
+	}
+}
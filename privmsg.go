@@ -1,10 +1,12 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"log/slog"
 	"math/rand/v2"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 	"unicode"
@@ -29,17 +31,17 @@ func (robo *Robot) tmiMessage(ctx context.Context, send chan<- *tmi.Message, msg
 		// channel that isn't configured. Ignore it.
 		return
 	}
-	room, _ := msg.Tag("room-id")
-	source, ok := msg.Tag("source-room-id")
-	// TODO(zeph): This prevents commands from any shared chat, even if the bot
-	// isn't in the source room. We should still allow commands from shared
-	// chats that we aren't in.
-	shared := ok && room != source
+	shared, joined := robo.sharedChat(msg)
 	m := message.FromTMI(msg)
 	log := slog.With(slog.String("trace", m.ID), slog.String("in", ch.Name))
 	log.InfoContext(ctx, "privmsg", slog.Duration("bias", time.Since(m.Time())))
 	defer log.InfoContext(ctx, "end")
 	perms := ch.Permissions[m.Sender.ID]
+	if shared {
+		// Moderator status comes from the source channel, which isn't the
+		// one whose configuration we have.
+		perms.Moderator = false
+	}
 	handling := channel.Learn
 	if ch.Links != channel.Learn && isLink(m.Text) {
 		handling = min(handling, ch.Links)
@@ -50,6 +52,26 @@ func (robo *Robot) tmiMessage(ctx context.Context, send chan<- *tmi.Message, msg
 	if ch.OneWord != channel.Learn && isOneWord(m.Text) {
 		handling = min(handling, ch.OneWord)
 	}
+	if ch.Caps != channel.Learn && isCaps(m.Text) {
+		handling = min(handling, ch.Caps)
+	}
+	if ch.EmoteOnly != channel.Learn {
+		if emotes, _ := msg.Tag("emotes"); isEmoteOnly(msg.Trailing, emotes) {
+			handling = min(handling, ch.EmoteOnly)
+		}
+	}
+	if ch.Long != channel.Learn && isLong(m.Text) {
+		handling = min(handling, ch.Long)
+	}
+	if ch.Repeated != channel.Learn && isRepeated(m.Text) {
+		handling = min(handling, ch.Repeated)
+	}
+	if ch.Mentions != channel.Learn {
+		_, reply := msg.Tag("reply-parent-msg-id")
+		if isMention(m.Text, reply) {
+			handling = min(handling, ch.Mentions)
+		}
+	}
 	if ch.Block.MatchString(m.Text) && !ch.Meme.MatchString(m.Text) {
 		handling = channel.Block
 	}
@@ -63,11 +85,18 @@ func (robo *Robot) tmiMessage(ctx context.Context, send chan<- *tmi.Message, msg
 			log.InfoContext(ctx, "commands disabled for user")
 			return
 		}
-		if shared {
+		if joined {
+			// We'll see the same message in the source room and handle the
+			// command there.
 			log.InfoContext(ctx, "ignore shared chat command")
 			return
 		}
-		robo.command(ctx, log, ch, m, cmd)
+		robo.command(ctx, log, ch, perms, m, cmd)
+		return
+	}
+	if !joined && command.Vote(ch, m) {
+		// Votes in real or robot aren't chat to learn from.
+		log.InfoContext(ctx, "vote", slog.String("text", m.Text))
 		return
 	}
 	ch.History.Add(m.Time(), m)
@@ -91,7 +120,7 @@ func (robo *Robot) tmiMessage(ctx context.Context, send chan<- *tmi.Message, msg
 		robo.learn(ctx, log, ch, robo.hashes(), m)
 	}
 	if !perms.DisableMemes {
-		switch err := ch.Memery.Check(m.Time(), m.Sender.ID, m.Text); err {
+		switch text, err := ch.Memery.Detect(m.Time(), m.Sender.ID, m.Text); err {
 		case channel.ErrNotCopypasta: // do nothing
 		case nil:
 			// Meme detected. Copypasta.
@@ -108,7 +137,7 @@ func (robo *Robot) tmiMessage(ctx context.Context, send chan<- *tmi.Message, msg
 				return
 			}
 			f := ch.Effects.Pick(rand.Uint32())
-			s := command.Effect(log, f, m.Text)
+			s := command.Effect(log, f, text)
 			if ch.Block.MatchString(s) && !ch.Meme.MatchString(s) {
 				// We would copypasta something that is blocked.
 				// Note that since we reached here at all, that implies the
@@ -134,11 +163,11 @@ func (robo *Robot) tmiMessage(ctx context.Context, send chan<- *tmi.Message, msg
 	if shared {
 		return
 	}
-	if perms.DisableSpeak || rand.Float64() > ch.Responses {
+	if perms.DisableSpeak || rand.Float64() > ch.Responses() {
 		return
 	}
 	start := time.Now()
-	s, trace, err := brain.Think(ctx, robo.brain, ch.Send, "")
+	s, trace, err := brain.ThinkWeighted(ctx, robo.brain, ch.Send, "", robo.thinkWeights(ctx, ch.Send))
 	cost := time.Since(start)
 	if err != nil {
 		log.ErrorContext(ctx, "wanted to think but failed", slog.Any("err", err), slog.Duration("cost", cost))
@@ -159,7 +188,7 @@ func (robo *Robot) tmiMessage(ctx context.Context, send chan<- *tmi.Message, msg
 	)
 	se := strings.TrimSpace(s + " " + e)
 	sef := command.Effect(log, f, se)
-	if err := robo.spoken.Record(ctx, ch.Send, sef, trace, time.Now(), cost, s, e, f); err != nil {
+	if err := robo.spoken.Record(ctx, ch.Send, sef, trace, time.Now(), cost, s, e, f, ""); err != nil {
 		log.ErrorContext(ctx, "record trace failed", slog.Any("err", err))
 		return
 	}
@@ -185,47 +214,123 @@ func (robo *Robot) tmiMessage(ctx context.Context, send chan<- *tmi.Message, msg
 	robo.sendTMI(ctx, send, out)
 }
 
-func (robo *Robot) command(ctx context.Context, log *slog.Logger, ch *channel.Channel, m *message.Received[message.User], cmd string) {
+// sharedChat reports whether a message was sent in another room through
+// shared chat and, if so, whether the bot is also in the source room.
+func (robo *Robot) sharedChat(msg *tmi.Message) (shared, joined bool) {
+	room, _ := msg.Tag("room-id")
+	source, ok := msg.Tag("source-room-id")
+	if !ok || room == source {
+		return false, false
+	}
+	name, ok := robo.rooms.Load(source)
+	if !ok {
+		return true, false
+	}
+	_, joined = robo.channels.Load(name)
+	return true, joined
+}
+
+// thinkWeights gets the weights from chat feedback for generating messages
+// in a tag. Errors are logged and give no weights.
+func (robo *Robot) thinkWeights(ctx context.Context, tag string) brain.Weights {
+	if robo.spoken == nil {
+		return nil
+	}
+	w, err := robo.spoken.Weights(ctx, tag)
+	if err != nil {
+		slog.ErrorContext(ctx, "couldn't get feedback weights", slog.Any("err", err))
+		return nil
+	}
+	return w
+}
+
+func (robo *Robot) command(ctx context.Context, log *slog.Logger, ch *channel.Channel, perms channel.UserPerms, m *message.Received[message.User], cmd string) {
 	robo.metrics.TMICommandCount.Observe(1)
 	var c *twitchCommand
 	var args map[string]string
 	level := "any"
 	switch {
 	case m.Sender.ID == robo.tmi.owner:
-		c, args = findTwitch(twitchOwner, cmd)
+		c, args = findTwitch(twitchOwner, ch.Lang, cmd)
+		if c == nil {
+			c, args = findCustom(ch.Commands, "owner", cmd)
+		}
 		if c != nil {
 			level = "owner"
 			break
 		}
 		fallthrough
-	case ch.Permissions[m.Sender.ID].Moderator, m.IsModerator:
-		c, args = findTwitch(twitchMod, cmd)
+	case perms.Moderator, m.IsModerator:
+		c, args = findTwitch(twitchMod, ch.Lang, cmd)
+		if c == nil {
+			c, args = findCustom(ch.Commands, "moderator", cmd)
+		}
 		if c != nil {
 			level = "mod"
 			break
 		}
 		fallthrough
 	default:
-		c, args = findTwitch(twitchAny, cmd)
+		// Custom commands go just before the last command, which swallows
+		// all invocations.
+		n := len(twitchAny) - 1
+		c, args = findTwitch(twitchAny[:n], ch.Lang, cmd)
+		if c == nil {
+			c, args = findCustom(ch.Commands, "any", cmd)
+		}
+		if c == nil {
+			c, args = findTwitch(twitchAny[n:], ch.Lang, cmd)
+		}
 	}
 	if c == nil {
 		return
 	}
+	caller := "any"
+	switch {
+	case m.Sender.ID == robo.tmi.owner:
+		caller = "owner"
+	case perms.Moderator, m.IsModerator:
+		caller = "mod"
+	}
 	log.InfoContext(ctx, "command",
 		slog.String("level", level),
 		slog.String("name", c.name),
 		slog.Any("args", args),
 	)
+	if caller == "any" {
+		wait, notice := ch.Cooldowns.Use(c.name, m.Sender.ID, m.Time(), c.cooldown, c.chanCooldown)
+		if wait > 0 {
+			log.InfoContext(ctx, "command on cooldown", slog.String("name", c.name), slog.Duration("wait", wait))
+			if notice && c.notify {
+				robo.cooldownNotice(ctx, log, ch, m, wait)
+			}
+			return
+		}
+	}
 	r := command.Robot{
-		Log:      log.With(slog.String("command", c.name), slog.Any("args", args)),
-		Channels: robo.channels,
-		Brain:    robo.brain,
-		Pet:      &robo.pet,
-		Privacy:  robo.privacy,
-		Spoken:   robo.spoken,
-		Owner:    robo.owner,
-		Contact:  robo.ownerContact,
-		Metrics:  robo.metrics,
+		Log:         log.With(slog.String("command", c.name), slog.Any("args", args)),
+		Channels:    robo.channels,
+		Brain:       robo.brain,
+		Blocklist:   robo.blocklist,
+		Audit:       robo.audit,
+		Pets:        robo.pets,
+		Privacy:     robo.privacy,
+		Spoken:      robo.spoken,
+		Leaderboard: robo.leaderboard,
+		Name:        robo.tmi.name,
+		Owner:       robo.owner,
+		Contact:     robo.ownerContact,
+		Metrics:     robo.metrics,
+		Join:        robo.JoinTwitch,
+		Part:        robo.PartTwitch,
+		ForgetUser:  robo.ForgetTwitchUser,
+		UndoForget:  robo.UndoForget,
+		Weights:     robo.thinkWeights,
+		DropQuotes:  robo.dropQuotes,
+		Quiet:       robo.Quiet,
+		Usage:       func() []command.Usage { return twitchUsage(ch.Commands, caller) },
+		RealMessage: robo.RealMessage,
+		GameWindow:  robo.gameWindow(),
 	}
 	inv := command.Invocation{
 		Channel: ch,
@@ -258,6 +363,11 @@ func (robo *Robot) learn(ctx context.Context, log *slog.Logger, ch *channel.Chan
 		log.DebugContext(ctx, "no learn tag")
 		return
 	}
+	if !ch.Quota.Allow(hasher, msg.Sender.ID, msg.To, msg.Time()) {
+		log.DebugContext(ctx, "learn quota exceeded")
+		robo.metrics.QuotaDroppedCount.Observe(1, ch.Learn)
+		return
+	}
 	user := hasher.Hash(msg.Sender.ID, msg.To, msg.Time())
 	m := brain.Message{
 		ID:          msg.ID,
@@ -374,159 +484,665 @@ func isOneWord(s string) bool {
 	return strings.IndexFunc(s, unicode.IsSpace) < 0
 }
 
+// isCaps reports whether a message is shouted: it has at least capsMin cased
+// letters, and no more than one in ten are lower case.
+func isCaps(s string) bool {
+	const capsMin = 8
+	var up, lo int
+	for _, r := range s {
+		switch {
+		case unicode.IsUpper(r):
+			up++
+		case unicode.IsLower(r):
+			lo++
+		}
+	}
+	return up+lo >= capsMin && lo*10 <= up+lo
+}
+
+// isEmoteOnly reports whether every non-space character in a message is part
+// of an emote according to the TMI emotes tag, which is a list of
+// id:start-end,start-end/id:start-end with inclusive rune offsets.
+func isEmoteOnly(s, emotes string) bool {
+	if emotes == "" {
+		return false
+	}
+	r := []rune(s)
+	covered := make([]bool, len(r))
+	for e := range strings.SplitSeq(emotes, "/") {
+		_, spans, ok := strings.Cut(e, ":")
+		if !ok {
+			return false
+		}
+		for sp := range strings.SplitSeq(spans, ",") {
+			a, b, ok := strings.Cut(sp, "-")
+			if !ok {
+				return false
+			}
+			i, err := strconv.Atoi(a)
+			if err != nil {
+				return false
+			}
+			j, err := strconv.Atoi(b)
+			if err != nil || i < 0 || j < i || j >= len(r) {
+				return false
+			}
+			for k := i; k <= j; k++ {
+				covered[k] = true
+			}
+		}
+	}
+	for k, c := range r {
+		if !covered[k] && !unicode.IsSpace(c) {
+			return false
+		}
+	}
+	return true
+}
+
+// isLong reports whether a message is very long.
+func isLong(s string) bool {
+	const longMin = 300
+	return utf8.RuneCountInString(s) > longMin
+}
+
+// isRepeated reports whether a message repeats a single non-space character
+// at least repeatMin times in a row.
+func isRepeated(s string) bool {
+	const repeatMin = 10
+	var last rune
+	n := 0
+	for _, r := range s {
+		if r != last || unicode.IsSpace(r) {
+			last, n = r, 0
+		}
+		n++
+		if n >= repeatMin && !unicode.IsSpace(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// isMention reports whether a message mentions another user with @name.
+// If the message is a reply, the leading mention that Twitch adds for the
+// replied user doesn't count.
+func isMention(s string, reply bool) bool {
+	f := strings.Fields(s)
+	if reply && len(f) > 0 && strings.HasPrefix(f[0], "@") {
+		f = f[1:]
+	}
+	for _, w := range f {
+		if len(w) > 1 && w[0] == '@' {
+			r, _ := utf8.DecodeRuneInString(w[1:])
+			if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 type twitchCommand struct {
 	parse *regexp.Regexp
 	fn    command.Func
 	name  string
+	// desc is a short description of the command for help.
+	// Commands without one are left out of help.
+	desc string
+	// examples are example phrasings of the command.
+	examples []string
+	// cooldown is the time each user must wait between uses of the command.
+	// chanCooldown is the time between uses of the command by anyone in a
+	// channel. Moderators bypass both.
+	cooldown, chanCooldown time.Duration
+	// notify indicates whether to tell users when the command is on cooldown.
+	notify bool
+	// alt holds alternative regexes for the command in other languages,
+	// keyed by language. They must have the same named captures as parse.
+	alt map[string]*regexp.Regexp
 }
 
-func findTwitch(cmds []twitchCommand, text string) (*twitchCommand, map[string]string) {
+// findTwitch finds the first command matching text, either with its own regex
+// or with its alternative for the language.
+func findTwitch(cmds []twitchCommand, lang, text string) (*twitchCommand, map[string]string) {
+	base, _, _ := strings.Cut(lang, "-")
 	for i := range cmds {
 		c := &cmds[i]
-		u := c.parse.FindStringSubmatch(text)
-		switch len(u) {
-		case 0:
+		if m, ok := matchCommand(c.parse, text); ok {
+			return c, m
+		}
+		re := c.alt[lang]
+		if re == nil {
+			re = c.alt[base]
+		}
+		if re == nil {
 			continue
-		case 1:
-			return c, nil
-		default:
-			m := make(map[string]string, len(u)-1)
-			s := c.parse.SubexpNames()
-			for k, v := range u[1:] {
-				m[s[k+1]] = v
-			}
+		}
+		if m, ok := matchCommand(re, text); ok {
 			return c, m
 		}
 	}
 	return nil, nil
 }
 
+// matchCommand matches text against a command regex and returns its named
+// captures, if there are any.
+func matchCommand(re *regexp.Regexp, text string) (map[string]string, bool) {
+	u := re.FindStringSubmatch(text)
+	switch len(u) {
+	case 0:
+		return nil, false
+	case 1:
+		return nil, true
+	default:
+		m := make(map[string]string, len(u)-1)
+		s := re.SubexpNames()
+		for k, v := range u[1:] {
+			m[s[k+1]] = v
+		}
+		return m, true
+	}
+}
+
+// findCustom finds the first custom command at the given level matching text.
+func findCustom(cmds []*channel.Command, level, text string) (*twitchCommand, map[string]string) {
+	for _, c := range cmds {
+		if c.Level != level {
+			continue
+		}
+		tc := []twitchCommand{{parse: c.Parse, fn: command.Custom(c), name: c.Name}}
+		if r, args := findTwitch(tc, "", text); r != nil {
+			return r, args
+		}
+	}
+	return nil, nil
+}
+
+// cooldownNotice tells a user how long until they can use a command again.
+func (robo *Robot) cooldownNotice(ctx context.Context, log *slog.Logger, ch *channel.Channel, m *message.Received[message.User], wait time.Duration) {
+	if m.Time().Before(ch.SilentTime()) {
+		return
+	}
+	t := time.Now()
+	r := ch.Rate.ReserveN(t, 1)
+	if d := r.DelayFrom(t); d > 0 {
+		log.InfoContext(ctx, "won't send cooldown notice; rate limited", slog.String("delay", d.String()))
+		r.CancelAt(t)
+		return
+	}
+	secs := int((wait + time.Second - 1) / time.Second)
+	ch.Message(ctx, message.Sent{Reply: m.ID, Text: command.Text(ch.Lang, "cooldown", secs)})
+}
+
+// twitchUsage lists the commands available at a level for help, including a
+// channel's custom commands.
+func twitchUsage(cmds []*channel.Command, level string) []command.Usage {
+	var r []command.Usage
+	seen := make(map[string]bool)
+	add := func(tc []twitchCommand, custom string) {
+		for _, c := range tc {
+			if c.desc == "" || seen[c.name] {
+				continue
+			}
+			seen[c.name] = true
+			r = append(r, command.Usage{Name: c.name, Desc: c.desc, Examples: c.examples})
+		}
+		for _, c := range cmds {
+			if c.Level != custom || seen[c.Name] {
+				continue
+			}
+			seen[c.Name] = true
+			r = append(r, command.Usage{Name: c.Name, Desc: cmp.Or(c.Help, "A custom command for this channel.")})
+		}
+	}
+	add(twitchAny, "any")
+	if level == "mod" || level == "owner" {
+		add(twitchMod, "moderator")
+	}
+	if level == "owner" {
+		add(twitchOwner, "owner")
+	}
+	return r
+}
+
 var twitchOwner = []twitchCommand{
 	{
-		parse: regexp.MustCompile(`^(?i:in\s+(?<in>#\S+)[,:]?\s+echo)\s+(?<msg>.*)`),
-		fn:    command.EchoIn,
-		name:  "echo-in",
+		parse:    regexp.MustCompile(`^(?i:in\s+(?<in>#\S+)[,:]?\s+echo)\s+(?<msg>.*)`),
+		fn:       command.EchoIn,
+		name:     "echo-in",
+		desc:     "Say a message in another channel.",
+		examples: []string{"in #bocchi echo hello"},
+	},
+	{
+		parse:    regexp.MustCompile(`^(?i:join)\s+(?<channel>#?\w+)$`),
+		fn:       command.Join,
+		name:     "join",
+		desc:     "Join a channel using the global settings.",
+		examples: []string{"join #bocchi"},
+	},
+	{
+		parse:    regexp.MustCompile(`^(?i:leave|part)\s+(?<channel>#?\w+)$`),
+		fn:       command.Part,
+		name:     "part",
+		desc:     "Leave a channel joined with join.",
+		examples: []string{"leave #bocchi"},
 	},
 }
 
 var twitchMod = []twitchCommand{
 	{
-		parse: regexp.MustCompile(`^(?i:echo)\s+(?<msg>.*)`),
-		fn:    command.Echo,
-		name:  "echo",
+		parse:    regexp.MustCompile(`^(?i:echo)\s+(?<msg>.*)`),
+		fn:       command.Echo,
+		name:     "echo",
+		desc:     "Say a message.",
+		examples: []string{"echo bocchi"},
+	},
+	{
+		parse:    regexp.MustCompile(`(?i)^(?:tell\s+me|talk)?\s*(?:about)?\s*(?:ranked)?\s*(?:competitive)?\s*marriage`),
+		fn:       command.DescribeMarriage,
+		name:     "describe-marriage",
+		desc:     "Describe ranked competitive marriage.",
+		examples: []string{"talk about ranked competitive marriage"},
+	},
+	{
+		parse:    regexp.MustCompile(`(?i)^forgr?[eo]?r?t\s+(?:(?:everything\s+|all\s+)?(?:from|by)\s+@?|@)(?<user>\w+)(?:\s+(?:in|from|over|for)\s+the\s+(?:last|past)\s+(?<dur>` + durationRE + `))?$`),
+		fn:       command.ForgetUser,
+		name:     "forget-user",
+		desc:     "Forget everything a user said recently.",
+		examples: []string{"forget everything from @bocchi in the last 24 hours"},
+	},
+	{
+		parse:    regexp.MustCompile(`(?i)^(?:undo\s+(?:(?:the|that|last)\s+)*forget|unforget)(?:\s+that)?$`),
+		fn:       command.UndoForget,
+		name:     "undo-forget",
+		desc:     "Bring back what the last forget removed.",
+		examples: []string{"undo forget"},
+	},
+	{
+		parse:    regexp.MustCompile(`(?i)^(?:delete|remove)\s+quote\s+#?(?<num>\d+)$`),
+		fn:       command.DeleteQuote,
+		name:     "delete-quote",
+		desc:     "Remove a quote from the quote book.",
+		examples: []string{"delete quote #12"},
+	},
+	{
+		parse:    regexp.MustCompile(`(?i)^forgr?[eo]?r?t\s+(?:everything$|(?<term>.+))`),
+		fn:       command.Forget,
+		name:     "forget",
+		desc:     "Forget recent messages containing a term.",
+		examples: []string{"forget bocchi", "forget everything"},
+	},
+	{
+		parse:    regexp.MustCompile(`(?i)^(?:how\s+(?:long|much\s+longer)\s+(?:are\s+you|will\s+you\s+be)\s+(?:being\s+)?quiet(?:\s+for)?|(?:how\s+much\s+)?quiet\s+time(?:\s+(?:is\s+)?left)?)\??$`),
+		fn:       command.QuietTime,
+		name:     "quiet-time",
+		desc:     "Report how much quiet time is left.",
+		examples: []string{"how long are you quiet?"},
 	},
 	{
-		parse: regexp.MustCompile(`(?i)^(?:tell\s+me|talk)?\s*(?:about)?\s*(?:ranked)?\s*(?:competitive)?\s*marriage`),
-		fn:    command.DescribeMarriage,
-		name:  "describe-marriage",
+		parse:    regexp.MustCompile(`(?i)^(?:be\s+quiet|shut\s*up|stfu)(?:\s+for\s+(?P<dur>` + durationRE + `)|\s+until\s+(?P<until>tomorrow|(?:the\s+)?(?:stream\s+)?(?:ends|is\s+over|goes\s+offline)|(?:you(?:'re|\s+are)\s+|we(?:'re|\s+are)\s+|it(?:'s|\s+is)\s+)?offline|(?:the\s+)?next\s+stream))?$`),
+		fn:       command.Quiet,
+		name:     "quiet",
+		desc:     "Stop talking and learning for a while.",
+		examples: []string{"be quiet for 8 hours", "be quiet until the stream ends", "be quiet until next stream"},
 	},
 	{
-		parse: regexp.MustCompile(`(?i)^forgr?[eo]?r?t\s+(?:everything$|(?<term>.+))`),
-		fn:    command.Forget,
-		name:  "forget",
+		parse:    regexp.MustCompile(`(?i)^(?:(?:list|show)\s+)?(?:blocked|block\s*list)(?:\s+(?:terms|words))?\??$`),
+		fn:       command.BlockedTerms,
+		name:     "blocked-terms",
+		desc:     "List the terms blocked in the channel.",
+		examples: []string{"blocked terms"},
 	},
 	{
-		parse: regexp.MustCompile(`(?i)^(?:be\s+quiet|shut\s*up|stfu)(?:\s+for\s+(?P<dur>(?:\d+[hms]){1,3}|an\s+h(?:ou)?r|\d+\s+h(?:ou)?rs?|a\s+min(?:ute)?|\d+\s+min(?:ute)?s?)|\s+until\s+(?P<until>tomorrow))?$`),
-		fn:    command.Quiet,
-		name:  "quiet",
+		parse:    regexp.MustCompile(`(?i)^block\s+(?:the\s+)?(?:(?:term|word|phrase)\s+)?(?<term>.+)`),
+		fn:       command.BlockTerm,
+		name:     "block-term",
+		desc:     "Stop learning or copying messages containing a term.",
+		examples: []string{"block cucumber"},
+	},
+	{
+		parse:    regexp.MustCompile(`(?i)^unblock\s+(?:the\s+)?(?:(?:term|word|phrase)\s+)?(?<term>.+)`),
+		fn:       command.UnblockTerm,
+		name:     "unblock-term",
+		desc:     "Undo block.",
+		examples: []string{"unblock cucumber"},
+	},
+	{
+		parse:    regexp.MustCompile(`(?i)^talk\s+(?<dir>more|less)(?:\s+for\s+(?<dur>` + durationRE + `))?$`),
+		fn:       command.TalkMore,
+		name:     "talk-more",
+		desc:     "Double or halve how often I respond at random.",
+		examples: []string{"talk more", "talk less for 2 hours"},
+	},
+	{
+		parse:    regexp.MustCompile(`(?i)^(?:set\s+)?(?:your\s+)?responses?(?:\s+rate)?\s+(?:to\s+)?(?<pct>\d+(?:\.\d*)?)\s*%(?:\s+for\s+(?<dur>` + durationRE + `))?$`),
+		fn:       command.SetResponses,
+		name:     "set-responses",
+		desc:     "Set how often I respond at random.",
+		examples: []string{"set responses to 5%"},
+	},
+	{
+		parse:    regexp.MustCompile(`(?i)^(?:set\s+)?(?:your\s+)?rate(?:\s+limit)?\s+(?:to\s+)?(?<num>\d+)\s*(?:per|every|/|in)\s*(?<every>\d+(?:\.\d*)?)\s*(?<unit>[smh])?[a-z]*(?:\s+for\s+(?<dur>` + durationRE + `))?$`),
+		fn:       command.SetRate,
+		name:     "set-rate",
+		desc:     "Set how many messages I can send in a time.",
+		examples: []string{"rate 1 per 30s"},
+	},
+	{
+		parse:    regexp.MustCompile(`(?i)^(?:what\s+are\s+your\s+|(?:show|report)\s+(?:your\s+)?)?settings\??$`),
+		fn:       command.Settings,
+		name:     "settings",
+		desc:     "Report my current settings.",
+		examples: []string{"settings"},
 	},
 }
 
+// durationRE is a regular expression matching durations for commands.
+const durationRE = `(?:\d+[hms]){1,3}|an\s+h(?:ou)?r|\d+\s+h(?:ou)?rs?|a\s+min(?:ute)?|\d+\s+min(?:ute)?s?`
+
 var twitchAny = []twitchCommand{
 	{
-		parse: regexp.MustCompile(`^(?i:give\s+me\s+privacy|ignore\s+me)`),
-		fn:    command.Private,
-		name:  "private",
+		parse:    regexp.MustCompile(`(?i)^(?:help|commands|what\s+(?:can|do)\s+y?o?u\s+do|what\s+commands\s+(?:are\s+there|do\s+y?o?u\s+(?:know|have)))(?:\s+(?:with\s+|for\s+|on\s+)?(?<command>[\w-]+))?\s*\??$`),
+		fn:       command.Help,
+		name:     "help",
+		desc:     "List the commands you can use, or describe one.",
+		examples: []string{"help", "what can you do?", "help marry"},
+		alt: map[string]*regexp.Regexp{
+			"es": regexp.MustCompile(`(?i)^(?:ayuda|comandos|[¿]?\s*qu[eé]\s+puedes\s+hacer)(?:\s+(?:con\s+|de\s+)?(?<command>[\w-]+))?\s*\??$`),
+			"ja": regexp.MustCompile(`^(?:ヘルプ|コマンド|何ができる|なにができる)(?:\s+(?<command>[\w-]+))?\s*[?？]?$`),
+		},
 	},
 	{
-		parse: regexp.MustCompile(`(?i)^(?:you\s+(?:can|may)\s+)?learn\s+from\s+me(?:\s+again)?|invade\s+my\s+privacy`),
-		fn:    command.Unprivate,
-		name:  "unprivate",
+		parse:    regexp.MustCompile(`^(?i:give\s+me\s+privacy|ignore\s+me)`),
+		fn:       command.Private,
+		name:     "private",
+		desc:     "Stop learning from your messages.",
+		examples: []string{"give me privacy"},
+		alt: map[string]*regexp.Regexp{
+			"es": regexp.MustCompile(`(?i)^(?:dame\s+privacidad|ign[oó]rame)`),
+			"ja": regexp.MustCompile(`^(?:私|わたし|俺|僕)?(?:から|の(?:メッセージ|発言)から)?学習しないで`),
+		},
 	},
 	{
-		parse: regexp.MustCompile(`(?i)^what\s+(?:info(?:rmation)?\s+)do\s+you\s+(?:collect|store)`),
-		fn:    command.DescribePrivacy,
-		name:  "describe-privacy",
+		parse:    regexp.MustCompile(`(?i)^(?:you\s+(?:can|may)\s+)?learn\s+from\s+me(?:\s+again)?|invade\s+my\s+privacy`),
+		fn:       command.Unprivate,
+		name:     "unprivate",
+		desc:     "Undo give me privacy.",
+		examples: []string{"learn from me again"},
+		alt: map[string]*regexp.Regexp{
+			"es": regexp.MustCompile(`(?i)^(?:puedes\s+)?aprende(?:r)?\s+de\s+m[ií](?:\s+otra\s+vez|\s+de\s+nuevo)?`),
+			"ja": regexp.MustCompile(`^(?:また|もう一度)?(?:私|わたし|俺|僕)?(?:から)?学習して`),
+		},
 	},
 	{
-		parse: regexp.MustCompile(`(?i)^[¿¡]*\s*(?:ple?a?se?\s+)?(?:will\s+y?o?u\s+)?(?:\s*ple?a?se?\s+)?(?:marry\s+me|be?\s+my\s+(?<partnership>wife|waifu|h[ua]su?bando?|partner|spouse|daddy|mommy))`),
-		fn:    command.Marry,
-		name:  "marry",
+		parse:    regexp.MustCompile(`(?i)^what\s+(?:info(?:rmation)?\s+)do\s+you\s+(?:collect|store)`),
+		fn:       command.DescribePrivacy,
+		name:     "describe-privacy",
+		desc:     "Link to what information I collect.",
+		examples: []string{"what information do you collect?"},
 	},
 	{
-		parse: regexp.MustCompile(`^(?i)how\s+much\s+do\s+you\s+(?:like|love|luv)\s+me`),
-		fn:    command.Affection,
-		name:  "affection",
+		parse:    regexp.MustCompile(`(?i)^[¿¡]*\s*(?:ple?a?se?\s+)?(?:will\s+y?o?u\s+)?(?:\s*ple?a?se?\s+)?(?:marry\s+me|be?\s+my\s+(?<partnership>wife|waifu|h[ua]su?bando?|partner|spouse|daddy|mommy))`),
+		fn:       command.Marry,
+		name:     "marry",
+		desc:     "Ask me to be your partner.",
+		examples: []string{"will you marry me?", "be my waifu"},
+		cooldown: 10 * time.Minute,
+		notify:   true,
+		alt: map[string]*regexp.Regexp{
+			"es": regexp.MustCompile(`(?i)^[¿¡]*\s*(?:te\s+)?(?:quieres\s+)?casar(?:te|se)?\s+conmigo|^[¿¡]*\s*(?:quieres\s+)?ser\s+mi\s+(?<partnership>esposa|esposo|pareja|waifu|husbando)`),
+			"ja": regexp.MustCompile(`^(?:結婚して|(?:私|わたし|俺|僕)?と結婚し|(?:私|わたし|俺|僕)の(?<partnership>嫁|旦那|パートナー)になって)`),
+		},
 	},
 	{
-		parse: regexp.MustCompile(`^(?i)i?\s*love\s+y?o?u(?:[^r]|\b)|^ILoveM?y?W`),
-		fn:    command.ILoveMyWife,
-		name:  "ILoveMyWife",
+		parse:    regexp.MustCompile(`^(?i)how\s+much\s+do\s+you\s+(?:like|love|luv)\s+me`),
+		fn:       command.Affection,
+		name:     "affection",
+		desc:     "Compute your affection score.",
+		examples: []string{"how much do you like me?"},
+		cooldown: time.Minute,
+		notify:   true,
+		alt: map[string]*regexp.Regexp{
+			"es": regexp.MustCompile(`(?i)^[¿]*\s*cu[aá]nto\s+me\s+(?:quieres|amas)`),
+			"ja": regexp.MustCompile(`^(?:私|わたし|俺|僕)?の(?:こと)?(?:どれくらい|どのくらい)好き`),
+		},
 	},
 	{
-		parse: regexp.MustCompile(`(?i:\bim?\b.+\bseiso\b|\bseiso\b.+\bi\b|^seiso$)|清楚`),
-		fn:    command.Seiso,
-		name:  "seiso",
+		parse:    regexp.MustCompile(`^(?i)i?\s*love\s+y?o?u(?:[^r]|\b)|^ILoveM?y?W`),
+		fn:       command.ILoveMyWife,
+		name:     "ILoveMyWife",
+		desc:     "Tell me you love me.",
+		examples: []string{"I love you"},
+		cooldown: time.Minute,
 	},
 	{
-		parse: regexp.MustCompile(`^(?i:OwO|uwu)`),
-		fn:    command.OwO,
-		name:  "OwO",
+		parse:    regexp.MustCompile(`(?i:\bim?\b.+\bseiso\b|\bseiso\b.+\bi\b|^seiso$)|清楚`),
+		fn:       command.Seiso,
+		name:     "seiso",
+		desc:     "Find out how seiso you are.",
+		examples: []string{"am I seiso?"},
+		cooldown: 5 * time.Minute,
+		notify:   true,
 	},
 	{
-		parse: regexp.MustCompile(`^(?i:how\s*[a']?re?\s+y?o?u?)|^A(?:A|\s)+$`),
-		fn:    command.AAAAA,
-		name:  "AAAAA",
+		parse:    regexp.MustCompile(`^(?i:OwO|uwu)`),
+		fn:       command.OwO,
+		name:     "OwO",
+		desc:     "Say something especiawwy uwu.",
+		examples: []string{"OwO"},
 	},
 	{
-		parse: regexp.MustCompile(`^(?i:r+o+a+r+|r+a+w+r+)`),
-		fn:    command.Rawr,
-		name:  "rawr",
+		parse:    regexp.MustCompile(`^(?i:how\s*[a']?re?\s+y?o?u?)|^A(?:A|\s)+$`),
+		fn:       command.AAAAA,
+		name:     "AAAAA",
+		desc:     "AAAAA AAA AAAA.",
+		examples: []string{"how are you?"},
 	},
 	{
-		parse: regexp.MustCompile(`^(?i:where(?:'?s|\s+is)?\s+y?o?u'?re?\s+so?u?rce?(?:\s*code)?)`),
-		fn:    command.Source,
-		name:  "source",
+		parse:    regexp.MustCompile(`^(?i:r+o+a+r+|r+a+w+r+)`),
+		fn:       command.Rawr,
+		name:     "rawr",
+		desc:     "Go rawr.",
+		examples: []string{"roar"},
 	},
 	{
-		parse: regexp.MustCompile(`(?i)^[¿¡]*\s*(?:who\s+a?re?\s+y?o?u|how\s+do\s+y?o?u\s+w[oe]?rk)`),
-		fn:    command.Who,
-		name:  "who",
+		parse:    regexp.MustCompile(`^(?i:where(?:'?s|\s+is)?\s+y?o?u'?re?\s+so?u?rce?(?:\s*code)?)`),
+		fn:       command.Source,
+		name:     "source",
+		desc:     "Link to my source code.",
+		examples: []string{"where is your source code?"},
 	},
 	{
-		parse: regexp.MustCompile(`(?i)^[¿¡.]*\s*(?:who'?s?e?\s+(?:is\s+)?(?:your\s+)?|(?:let?\s*m?me\s+|i\s+want\s+(?:to\s+))?(?:(?:speak|talk|complain)\s+(?:to|with)\s*)?your\s+)(?:manage[rs]?|op(?:erat[eo][rs]?)?|runs?|admin|administrator|administrates?|owns?|owner)`),
-		fn:    command.Contact,
-		name:  "contact",
+		parse:    regexp.MustCompile(`(?i)^[¿¡]*\s*(?:who\s+a?re?\s+y?o?u|how\s+do\s+y?o?u\s+w[oe]?rk)`),
+		fn:       command.Who,
+		name:     "who",
+		desc:     "Describe what I am.",
+		examples: []string{"who are you?"},
+		alt: map[string]*regexp.Regexp{
+			"es": regexp.MustCompile(`(?i)^[¿]*\s*(?:qui[eé]n\s+eres|c[oó]mo\s+funcionas)`),
+			"ja": regexp.MustCompile(`^(?:だれ|誰)(?:ですか)?[?？]?$`),
+		},
 	},
 	{
-		parse: regexp.MustCompile(`^(?i:(?:check)?\s*(?:current)?\s*status$)`),
-		fn:    command.Tamagotchi,
-		name:  "tamagotchi",
+		parse:    regexp.MustCompile(`(?i)^[¿¡.]*\s*(?:who'?s?e?\s+(?:is\s+)?(?:your\s+)?|(?:let?\s*m?me\s+|i\s+want\s+(?:to\s+))?(?:(?:speak|talk|complain)\s+(?:to|with)\s*)?your\s+)(?:manage[rs]?|op(?:erat[eo][rs]?)?|runs?|admin|administrator|administrates?|owns?|owner)`),
+		fn:       command.Contact,
+		name:     "contact",
+		desc:     "Say who runs me and how to reach them.",
+		examples: []string{"who is your owner?"},
 	},
 	{
-		parse: regexp.MustCompile(`^(?i:eat|(?:have|wh?at(?:'|\s*i)?s?)\s*(?:s[ou]me?|fo?r|4)?\s*(?:brea?kfa?st|lu?nch|din*e*r))`),
-		fn:    command.Eat,
-		name:  "eat",
+		parse:        regexp.MustCompile(`^(?i:(?:check)?\s*(?:current)?\s*status$)`),
+		fn:           command.Tamagotchi,
+		name:         "tamagotchi",
+		desc:         "Check on how I'm doing.",
+		examples:     []string{"status"},
+		chanCooldown: 30 * time.Second,
+		alt: map[string]*regexp.Regexp{
+			"es": regexp.MustCompile(`(?i)^(?:estado|c[oó]mo\s+te\s+sientes)\s*\??$`),
+			"ja": regexp.MustCompile(`^(?:ステータス|状態|調子どう)[?？]?$`),
+		},
 	},
 	{
-		parse: regexp.MustCompile(`^(?i:(?:let(?:'|\s*u)s|go)?\s*clean)`),
-		fn:    command.Clean,
-		name:  "clean",
+		parse:        regexp.MustCompile(`^(?i:eat|(?:have|wh?at(?:'|\s*i)?s?)\s*(?:s[ou]me?|fo?r|4)?\s*(?:brea?kfa?st|lu?nch|din*e*r))`),
+		fn:           command.Eat,
+		name:         "eat",
+		desc:         "Get me something to eat.",
+		examples:     []string{"eat", "what's for lunch?"},
+		cooldown:     5 * time.Minute,
+		chanCooldown: 30 * time.Second,
+		notify:       true,
+		alt: map[string]*regexp.Regexp{
+			"es": regexp.MustCompile(`(?i)^(?:come|a\s+comer|hora\s+de\s+comer|qu[eé]\s+hay\s+de\s+(?:desayuno|almuerzo|comida|cena))`),
+			"ja": regexp.MustCompile(`^(?:ご飯|ごはん|食べて|いただきます)`),
+		},
 	},
 	{
-		parse: regexp.MustCompile(`^(?i:\**(?:head\s*)?p[ae]t|(?:chin\s*)scritch|(?:cheek|shoulder|back|foot)?\s*rub|(?:bi+g\s+)hug|g[ou]+d\s+(?:girl|gril|boy|bot|pet|wife|waifu|h[ua]su?bando?|partner|spouse|daddy|mommy))|^(?::?\w+P[aAeE][tT][sS]?:?\s*)+\W*$`),
-		fn:    command.Pat,
-		name:  "pat",
+		parse:        regexp.MustCompile(`^(?i:(?:let(?:'|\s*u)s|go)?\s*clean)`),
+		fn:           command.Clean,
+		name:         "clean",
+		desc:         "Help me clean up.",
+		examples:     []string{"let's clean"},
+		cooldown:     5 * time.Minute,
+		chanCooldown: 30 * time.Second,
+		notify:       true,
+		alt: map[string]*regexp.Regexp{
+			"es": regexp.MustCompile(`(?i)^(?:vamos\s+a\s+)?limpia(?:r)?`),
+			"ja": regexp.MustCompile(`^(?:掃除|そうじ|お掃除)`),
+		},
 	},
 	{
-		parse: regexp.MustCompile(`(?i)^hap+y?\s+bir(?:f|th)(?:day)?`),
-		fn:    command.HappyBirthdayToYou,
-		name:  "birthday",
+		parse:        regexp.MustCompile(`(?i)^(?:let(?:'|\s*u)?s\s+)?play(?:\s+(?:with\s+me|a\s+game|games?))?[.!]*$`),
+		fn:           command.Play,
+		name:         "play",
+		desc:         "Play with me.",
+		examples:     []string{"let's play"},
+		cooldown:     5 * time.Minute,
+		chanCooldown: 30 * time.Second,
+		notify:       true,
+		alt: map[string]*regexp.Regexp{
+			"es": regexp.MustCompile(`(?i)^(?:(?:vamos\s+a\s+)?jugar|juguemos)(?:\s+conmigo)?[.!]*$`),
+			"ja": regexp.MustCompile(`^(?:遊ぼう|あそぼう|遊んで|あそんで)[!！]*$`),
+		},
 	},
 	{
-		parse: regexp.MustCompile(`^(?i:say|generate)\s*(?i:something)?\s*(?i:starting)?\s*(?i:with)?\s+(?<prompt>.*)`),
-		fn:    command.Speak,
-		name:  "speak",
+		parse:        regexp.MustCompile(`(?i)^(?:go\s+to\s+(?:bed|sleep)|(?:time\s+(?:for|to)\s+)?(?:bed|sleep)|bed\s*time|nap\s*time)[.!]*$`),
+		fn:           command.Sleep,
+		name:         "sleep",
+		desc:         "Put me to bed. I need sleep once a day.",
+		examples:     []string{"go to bed", "bedtime"},
+		cooldown:     5 * time.Minute,
+		chanCooldown: 30 * time.Second,
+		notify:       true,
+		alt: map[string]*regexp.Regexp{
+			"es": regexp.MustCompile(`(?i)^(?:(?:ve\s+)?a\s+dormir|hora\s+de\s+dormir)[.!]*$`),
+			"ja": regexp.MustCompile(`^(?:おやすみ|寝て|ねて|寝よう|ねよう)`),
+		},
+	},
+	{
+		parse:        regexp.MustCompile(`(?i)^(?:(?:let(?:'|\s*u)?s\s+)?do\s+(?:the\s+|your\s+|some\s+)?laundry|laundry)[.!]*$`),
+		fn:           command.Laundry,
+		name:         "laundry",
+		desc:         "Help me do my laundry.",
+		examples:     []string{"do the laundry"},
+		cooldown:     5 * time.Minute,
+		chanCooldown: 30 * time.Second,
+		notify:       true,
+		alt: map[string]*regexp.Regexp{
+			"es": regexp.MustCompile(`(?i)^(?:lavar?\s+(?:la\s+)?ropa|colada)[.!]*$`),
+			"ja": regexp.MustCompile(`^(?:洗濯|せんたく)`),
+		},
+	},
+	{
+		// NOTE(zeph): This command must be before pat, which would otherwise
+		// take "good bot" as pats. Anything more than "good bot" is still pats.
+		parse:    regexp.MustCompile(`(?i)^(?:g[ou]+d|nice|great|(?<bad>bad|naughty))\s+(?:ro)?bot[.!]*$`),
+		fn:       command.Feedback,
+		name:     "feedback",
+		desc:     "Tell me whether my last message, or the one you reply to, was good or bad.",
+		examples: []string{"good bot", "bad bot"},
+		cooldown: time.Minute,
+		alt: map[string]*regexp.Regexp{
+			"es": regexp.MustCompile(`(?i)^(?:buen|(?<bad>mal))\s+(?:ro)?bot[.!]*$`),
+			"ja": regexp.MustCompile(`^(?:いい|(?<bad>悪い|わるい))(?:ボット|bot)[!！]*$`),
+		},
+	},
+	{
+		parse:    regexp.MustCompile(`^(?i:\**(?:head\s*)?p[ae]t|(?:chin\s*)scritch|(?:cheek|shoulder|back|foot)?\s*rub|(?:bi+g\s+)hug|g[ou]+d\s+(?:girl|gril|boy|bot|pet|wife|waifu|h[ua]su?bando?|partner|spouse|daddy|mommy))|^(?::?\w+P[aAeE][tT][sS]?:?\s*)+\W*$`),
+		fn:       command.Pat,
+		name:     "pat",
+		desc:     "Give me pats.",
+		examples: []string{"pat", "head pat"},
+		cooldown: 30 * time.Second,
+		alt: map[string]*regexp.Regexp{
+			"es": regexp.MustCompile(`(?i)^\**(?:caricias?|acariciar?|abrazo)`),
+			"ja": regexp.MustCompile(`^(?:なでなで|よしよし|ぎゅー)`),
+		},
+	},
+	{
+		parse:        regexp.MustCompile(`(?i)^quote\s+(?:that|this)[.!]*$`),
+		fn:           command.QuoteThat,
+		name:         "quote-that",
+		desc:         "Save my last message, or the one you reply to, as a quote.",
+		examples:     []string{"quote that"},
+		cooldown:     time.Minute,
+		chanCooldown: 10 * time.Second,
+	},
+	{
+		parse:        regexp.MustCompile(`(?i)^(?:random\s+)?quote(?:\s+#?(?<num>\d+))?\s*$`),
+		fn:           command.ShowQuote,
+		name:         "quote",
+		desc:         "Show a saved quote, by number or at random.",
+		examples:     []string{"quote #12", "random quote"},
+		chanCooldown: 30 * time.Second,
+	},
+	{
+		parse:        regexp.MustCompile(`(?i)^(?:why\s+(?:did|would)\s+you\s+say\s+(?:that|this)|where\s+(?:did|does)\s+(?:that|this)\s+come\s+from)\s*\??$`),
+		fn:           command.Why,
+		name:         "why",
+		desc:         "Explain where my last message, or the one you reply to, came from.",
+		examples:     []string{"why did you say that?"},
+		cooldown:     30 * time.Second,
+		chanCooldown: 5 * time.Second,
+	},
+	{
+		parse:        regexp.MustCompile(`(?i)^(?:let'?s\s+play\s+)?real\s+or\s+(?:ro)?bot\s*[.!?]*$`),
+		fn:           command.RealOrRobot,
+		name:         "real-or-robot",
+		desc:         "Play real or robot: guess whether my next message is something someone really said.",
+		examples:     []string{"real or robot?", "let's play real or robot"},
+		chanCooldown: 5 * time.Minute,
+		notify:       true,
+		alt: map[string]*regexp.Regexp{
+			"es": regexp.MustCompile(`(?i)^(?:juguemos\s+)?¿?real\s+o\s+(?:ro)?bot\s*[.!?]*$`),
+			"ja": regexp.MustCompile(`^本物かロボットか[?？]*$`),
+		},
+	},
+	{
+		parse:        regexp.MustCompile(`(?i)^(?:real\s+or\s+robot\s+)?(?:leaderboard|scores)\s*[.!?]*$`),
+		fn:           command.Leaderboard,
+		name:         "leaderboard",
+		desc:         "Show the best real or robot players in this channel.",
+		examples:     []string{"leaderboard"},
+		cooldown:     time.Minute,
+		chanCooldown: 30 * time.Second,
+	},
+	{
+		parse:    regexp.MustCompile(`(?i)^hap+y?\s+bir(?:f|th)(?:day)?`),
+		fn:       command.HappyBirthdayToYou,
+		name:     "birthday",
+		desc:     "Wish me a happy birthday.",
+		examples: []string{"happy birthday"},
+	},
+	{
+		parse:    regexp.MustCompile(`^(?i:say|generate)\s*(?i:something)?\s*(?i:starting)?\s*(?i:with)?\s+(?<prompt>.*)`),
+		fn:       command.Speak,
+		name:     "speak",
+		desc:     "Say something, optionally starting with a prompt.",
+		examples: []string{"say bocchi", "generate something starting with bocchi"},
+		alt: map[string]*regexp.Regexp{
+			"es": regexp.MustCompile(`(?i)^(?:di|genera)\s+(?:algo\s+)?(?:que\s+empiece\s+)?(?:con\s+)?(?<prompt>.*)`),
+		},
 	},
 	{
 		// NOTE(zeph): This command MUST be after the normal speak command,
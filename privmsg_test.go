@@ -1,6 +1,19 @@
 package main
 
-import "testing"
+import (
+	"context"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"gitlab.com/zephyrtronium/tmi"
+
+	"github.com/zephyrtronium/robot/channel"
+	"github.com/zephyrtronium/robot/command"
+	"github.com/zephyrtronium/robot/message"
+)
 
 func TestParseCommand(t *testing.T) {
 	cases := []struct {
@@ -36,3 +49,425 @@ func TestParseCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestMessageCategories(t *testing.T) {
+	long := strings.Repeat("bocchi ", 50)
+	cases := []struct {
+		name   string
+		detect func(string) bool
+		in     string
+		want   bool
+	}{
+		{"caps-shout", isCaps, "BOCCHI THE ROCK", true},
+		{"caps-mostly", isCaps, "BOCCHI THE ROCKs!!", true},
+		{"caps-normal", isCaps, "Bocchi the Rock", false},
+		{"caps-short", isCaps, "LOL OK", false},
+		{"caps-empty", isCaps, "", false},
+		{"caps-symbols", isCaps, "!!!!!!!!!!!!", false},
+		{"long-long", isLong, long, true},
+		{"long-short", isLong, "bocchi the rock", false},
+		{"long-runes", isLong, strings.Repeat("ぼ", 300), false},
+		{"repeated-spam", isRepeated, "lmaoooooooooooo", true},
+		{"repeated-exact", isRepeated, "aaaaaaaaaa", true},
+		{"repeated-short", isRepeated, "lmaoooo", false},
+		{"repeated-spaces", isRepeated, "bocchi           ryo", false},
+		{"repeated-interrupted", isRepeated, "aaaaabaaaaa", false},
+		{"repeated-runes", isRepeated, "ぼぼぼぼぼぼぼぼぼぼ", true},
+		{"mention-start", func(s string) bool { return isMention(s, false) }, "@ryo hi", true},
+		{"mention-middle", func(s string) bool { return isMention(s, false) }, "hi @ryo", true},
+		{"mention-none", func(s string) bool { return isMention(s, false) }, "bocchi the rock", false},
+		{"mention-bare-at", func(s string) bool { return isMention(s, false) }, "meet me @ starry", false},
+		{"mention-email-like", func(s string) bool { return isMention(s, false) }, "bocchi@starry", false},
+		{"mention-reply", func(s string) bool { return isMention(s, true) }, "@ryo hi", false},
+		{"mention-reply-another", func(s string) bool { return isMention(s, true) }, "@ryo hi @kita", true},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.detect(c.in); got != c.want {
+				t.Errorf("wrong result for %q: want %t, got %t", c.in, c.want, got)
+			}
+		})
+	}
+}
+
+func TestIsEmoteOnly(t *testing.T) {
+	cases := []struct {
+		name   string
+		text   string
+		emotes string
+		want   bool
+	}{
+		{"none", "bocchi the rock", "", false},
+		{"single", "Kappa", "25:0-4", true},
+		{"repeated", "Kappa Kappa", "25:0-4,6-10", true},
+		{"several", "Kappa LUL", "25:0-4/425618:6-8", true},
+		{"trailing-space", "Kappa ", "25:0-4", true},
+		{"mixed", "Kappa bocchi", "25:0-4", false},
+		{"emotesv2", "hello, bocchiHi", "emotesv2_994bd9ea759349d1aa51dca6acca627e:7-14", false},
+		{"runes", "ぼ Kappa", "25:2-6", false},
+		{"runes-only", "ぼっち Kappa", "1:0-2/25:4-8", true},
+		{"out-of-range", "Kappa", "25:0-9", false},
+		{"malformed", "Kappa", "25", false},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := isEmoteOnly(c.text, c.emotes); got != c.want {
+				t.Errorf("wrong result for %q with emotes %q: want %t, got %t", c.text, c.emotes, c.want, got)
+			}
+		})
+	}
+}
+
+// Messages in shared chat between #barrycarlyon (room 15185913) and #bocchi
+// (room 141981764). The shared ones are built from the tags Twitch documents
+// for shared chat: the copy in the source room has source-room-id equal to
+// room-id, and the copy relayed to the other room keeps the source message's
+// ID in source-id and the sender's badges from the source room in
+// source-badges.
+const (
+	// Regular message in #barrycarlyon.
+	sharedRegular = `@badge-info=;badges=moderator/1;client-nonce=ba6030bcdfa3b13c602415dfc69b4786;color=#008000;display-name=BarryCarIyon;emotes=;first-msg=0;flags=;id=b51051ff-742c-491d-8069-6248bc786e6f;mod=1;returning-chatter=0;room-id=15185913;subscriber=0;tmi-sent-ts=1766005013352;turbo=0;user-id=794780266;user-type=mod :barrycariyon!barrycariyon@barrycariyon.tmi.twitch.tv PRIVMSG #barrycarlyon :test`
+	// Command from a moderator of #barrycarlyon during shared chat, as
+	// received there.
+	sharedSource = `@badge-info=;badges=moderator/1;client-nonce=4700ddc3648908af33a82f3af349fdbe;color=#008000;display-name=BarryCarIyon;emotes=;first-msg=0;flags=;id=92798aaa-2f19-4daf-b52c-26628ae73d99;mod=1;returning-chatter=0;room-id=15185913;source-badge-info=;source-badges=moderator/1;source-id=92798aaa-2f19-4daf-b52c-26628ae73d99;source-only=0;source-room-id=15185913;subscriber=0;tmi-sent-ts=1766005913352;turbo=0;user-id=794780266;user-type=mod :barrycariyon!barrycariyon@barrycariyon.tmi.twitch.tv PRIVMSG #barrycarlyon :@robot echo bocchi`
+	// The same command as relayed to #bocchi, where the sender isn't a
+	// moderator.
+	sharedRelay = `@badge-info=;badges=;client-nonce=8766b0b96715d0c86b198ff1a7cd4ce8;color=#008000;display-name=BarryCarIyon;emotes=;first-msg=0;flags=;id=363b5951-ab1a-4127-b892-1cc48bf76ff4;mod=0;returning-chatter=0;room-id=141981764;source-badge-info=;source-badges=moderator/1;source-id=92798aaa-2f19-4daf-b52c-26628ae73d99;source-only=0;source-room-id=15185913;subscriber=0;tmi-sent-ts=1766005913352;turbo=0;user-id=794780266;user-type= :barrycariyon!barrycariyon@barrycariyon.tmi.twitch.tv PRIVMSG #bocchi :@robot echo bocchi`
+)
+
+func TestSharedChat(t *testing.T) {
+	cases := []struct {
+		name     string
+		msg      string
+		rooms    map[string]string
+		channels []string
+		shared   bool
+		joined   bool
+	}{
+		{
+			name:     "regular",
+			msg:      sharedRegular,
+			rooms:    map[string]string{"15185913": "#barrycarlyon"},
+			channels: []string{"#barrycarlyon"},
+			shared:   false,
+			joined:   false,
+		},
+		{
+			name:     "source",
+			msg:      sharedSource,
+			rooms:    map[string]string{"15185913": "#barrycarlyon", "141981764": "#bocchi"},
+			channels: []string{"#barrycarlyon", "#bocchi"},
+			shared:   false,
+			joined:   false,
+		},
+		{
+			name:     "shared-unknown",
+			msg:      sharedRelay,
+			rooms:    map[string]string{"141981764": "#bocchi"},
+			channels: []string{"#bocchi"},
+			shared:   true,
+			joined:   false,
+		},
+		{
+			name:     "shared-joined",
+			msg:      sharedRelay,
+			rooms:    map[string]string{"15185913": "#barrycarlyon", "141981764": "#bocchi"},
+			channels: []string{"#barrycarlyon", "#bocchi"},
+			shared:   true,
+			joined:   true,
+		},
+		{
+			name:     "shared-parted",
+			msg:      sharedRelay,
+			rooms:    map[string]string{"15185913": "#barrycarlyon", "141981764": "#bocchi"},
+			channels: []string{"#bocchi"},
+			shared:   true,
+			joined:   false,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			msg, err := tmi.Parse(strings.NewReader(c.msg + "\r\n"))
+			if err != nil && err != io.EOF {
+				t.Fatal(err)
+			}
+			robo := New(make([]byte, 64), 1)
+			for id, name := range c.rooms {
+				robo.rooms.Store(id, name)
+			}
+			for _, name := range c.channels {
+				robo.channels.Store(name, &channel.Channel{Name: name})
+			}
+			shared, joined := robo.sharedChat(msg)
+			if shared != c.shared {
+				t.Errorf("wrong sharedness: want %t, got %t", c.shared, shared)
+			}
+			if joined != c.joined {
+				t.Errorf("wrong joinedness: want %t, got %t", c.joined, joined)
+			}
+		})
+	}
+}
+
+func TestSharedChatCommands(t *testing.T) {
+	cases := []struct {
+		name     string
+		msg      string
+		channels []string
+		// want is the channel and text of each message sent in response.
+		want []string
+	}{
+		{
+			name:     "source",
+			msg:      sharedSource,
+			channels: []string{"#barrycarlyon", "#bocchi"},
+			want:     []string{"#barrycarlyon bocchi"},
+		},
+		{
+			name:     "relay-unjoined",
+			msg:      sharedRelay,
+			channels: []string{"#bocchi"},
+			want:     []string{"#bocchi bocchi"},
+		},
+		{
+			name:     "relay-joined",
+			msg:      sharedRelay,
+			channels: []string{"#barrycarlyon", "#bocchi"},
+			want:     nil,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			msg, err := tmi.Parse(strings.NewReader(c.msg + "\r\n"))
+			if err != nil && err != io.EOF {
+				t.Fatal(err)
+			}
+			robo := New(make([]byte, 64), 1)
+			robo.tmi = &client[*tmi.Message, *tmi.Message]{name: "robot"}
+			robo.rooms.Store("15185913", "#barrycarlyon")
+			robo.rooms.Store("141981764", "#bocchi")
+			var got []string
+			for _, name := range c.channels {
+				robo.channels.Store(name, &channel.Channel{
+					Name:        name,
+					Links:       channel.Learn,
+					BotCommands: channel.Learn,
+					OneWord:     channel.Learn,
+					Caps:        channel.Learn,
+					EmoteOnly:   channel.Learn,
+					Long:        channel.Learn,
+					Repeated:    channel.Learn,
+					Mentions:    channel.Learn,
+					Block:       channel.NewBlocker(regexp.MustCompile(`$^`)),
+					Message: func(ctx context.Context, msg message.Sent) {
+						got = append(got, name+" "+msg.Text)
+					},
+				})
+			}
+			// The echo command is only for moderators, and the sender is
+			// a moderator only in the source room.
+			robo.tmiMessage(context.Background(), nil, msg)
+			if !slices.Equal(got, c.want) {
+				t.Errorf("wrong responses: want %q, got %q", c.want, got)
+			}
+		})
+	}
+}
+
+func TestFindCustom(t *testing.T) {
+	cfg := ChannelCfg{
+		Channels: []string{"#bocchi"},
+		Commands: []CommandCfg{
+			{Name: "hug", Regex: `^hug\s+(?<target>\S+)`, Response: `{{.Sender}} hugs {{.Args.target}}`},
+			{Name: "kick", Regex: `^kick\s+(?<target>\S+)`, Level: "moderator", Response: `bye {{.Args.target}}`},
+			{Name: "shutdown", Regex: `^shutdown`, Level: "Owner", Response: `no`},
+		},
+	}
+	robo := New(make([]byte, 64), 1)
+	chs, err := robo.twitchChannels("bocchi", Global{}, &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmds := chs[0].Commands
+	cases := []struct {
+		name   string
+		level  string
+		text   string
+		want   string
+		target string
+	}{
+		{"any", "any", "hug kita", "hug", "kita"},
+		{"any-mod", "any", "kick kita", "", ""},
+		{"mod", "moderator", "kick kita", "kick", "kita"},
+		{"mod-any", "moderator", "hug kita", "", ""},
+		{"owner", "owner", "shutdown", "shutdown", ""},
+		{"none", "any", "bocchi the rock", "", ""},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cmd, args := findCustom(cmds, c.level, c.text)
+			if cmd == nil {
+				if c.want != "" {
+					t.Errorf("no command; want %q", c.want)
+				}
+				return
+			}
+			if cmd.name != c.want {
+				t.Errorf("wrong command: want %q, got %q", c.want, cmd.name)
+			}
+			if args["target"] != c.target {
+				t.Errorf("wrong target: want %q, got %q", c.target, args["target"])
+			}
+		})
+	}
+
+	bad := []CommandCfg{
+		{Name: "level", Regex: `^x`, Level: "admin"},
+		{Name: "regex", Regex: `^(x`},
+		{Name: "template", Regex: `^x`, Response: `{{.Sender`},
+	}
+	for _, b := range bad {
+		t.Run("bad-"+b.Name, func(t *testing.T) {
+			cfg := ChannelCfg{Channels: []string{"#bocchi"}, Commands: []CommandCfg{b}}
+			if _, err := robo.twitchChannels("bocchi", Global{}, &cfg); err == nil {
+				t.Error("no error")
+			}
+		})
+	}
+}
+
+func TestCommandExamples(t *testing.T) {
+	tables := []struct {
+		name string
+		cmds []twitchCommand
+	}{
+		{"owner", twitchOwner},
+		{"mod", twitchMod},
+		{"any", twitchAny},
+	}
+	for _, tb := range tables {
+		for _, c := range tb.cmds {
+			if c.desc == "" {
+				if len(c.examples) != 0 {
+					t.Errorf("%s command %s has examples but no description", tb.name, c.name)
+				}
+				continue
+			}
+			for _, e := range c.examples {
+				got, _ := findTwitch(tb.cmds, "", e)
+				if got == nil || got.name != c.name {
+					t.Errorf("%s example %q for %s doesn't invoke it", tb.name, e, c.name)
+				}
+			}
+		}
+	}
+}
+
+func TestTwitchUsage(t *testing.T) {
+	cmds := []*channel.Command{
+		{Name: "hug", Level: "any", Help: "Hug someone."},
+		{Name: "kick", Level: "moderator"},
+	}
+	has := func(u []command.Usage, name string) bool {
+		return slices.ContainsFunc(u, func(u command.Usage) bool { return u.Name == name })
+	}
+	cases := []struct {
+		level string
+		want  []string
+		not   []string
+	}{
+		{"any", []string{"help", "speak", "hug"}, []string{"kick", "forget", "join"}},
+		{"mod", []string{"help", "hug", "kick", "forget"}, []string{"join", "hte"}},
+		{"owner", []string{"help", "hug", "kick", "forget", "join"}, []string{"hte"}},
+	}
+	for _, c := range cases {
+		t.Run(c.level, func(t *testing.T) {
+			u := twitchUsage(cmds, c.level)
+			for _, n := range c.want {
+				if !has(u, n) {
+					t.Errorf("missing %s", n)
+				}
+			}
+			for _, n := range c.not {
+				if has(u, n) {
+					t.Errorf("unexpected %s", n)
+				}
+			}
+			seen := make(map[string]bool)
+			for _, v := range u {
+				if seen[v.Name] {
+					t.Errorf("duplicate %s", v.Name)
+				}
+				seen[v.Name] = true
+			}
+		})
+	}
+}
+
+func TestCommandAlternatives(t *testing.T) {
+	for _, tb := range [][]twitchCommand{twitchOwner, twitchMod, twitchAny} {
+		for _, c := range tb {
+			names := c.parse.SubexpNames()
+			for lang, re := range c.alt {
+				for _, n := range re.SubexpNames() {
+					if !slices.Contains(names, n) {
+						t.Errorf("%s alternative for %s has capture %q not in the original", lang, c.name, n)
+					}
+				}
+			}
+		}
+	}
+
+	cases := []struct {
+		name string
+		lang string
+		text string
+		want string
+		args map[string]string
+	}{
+		{"es-help", "es", "ayuda", "help", nil},
+		{"es-help-command", "es", "ayuda marry", "help", map[string]string{"command": "marry"}},
+		{"es-MX-help", "es-MX", "¿qué puedes hacer?", "help", nil},
+		{"ja-help", "ja", "ヘルプ", "help", nil},
+		{"en-help-in-ja", "ja", "help", "help", nil},
+		{"ja-help-in-en", "en", "ヘルプ", "speak", nil},
+		{"es-marry", "es", "¿te quieres casar conmigo?", "marry", nil},
+		{"es-marry-kind", "es", "quieres ser mi esposa", "marry", map[string]string{"partnership": "esposa"}},
+		{"ja-marry", "ja", "結婚して", "marry", nil},
+		{"es-eat", "es", "a comer", "eat", nil},
+		{"ja-eat", "ja", "ごはん", "eat", nil},
+		{"es-clean", "es", "vamos a limpiar", "clean", nil},
+		{"ja-status", "ja", "ステータス", "tamagotchi", nil},
+		{"es-speak", "es", "di bocchi", "speak", map[string]string{"prompt": "bocchi"}},
+		{"ja-private", "ja", "学習しないで", "private", nil},
+		{"good-bot", "en", "good bot", "feedback", map[string]string{"bad": ""}},
+		{"bad-bot", "en", "bad bot!", "feedback", map[string]string{"bad": "bad"}},
+		{"good-girl", "en", "good girl", "pat", nil},
+		{"good-bot-heart", "en", "good bot <3", "pat", nil},
+		{"good-bot-pats", "en", "good bot pat pat", "pat", nil},
+		{"es-bad-bot", "es", "mal bot", "feedback", map[string]string{"bad": "mal"}},
+		{"real-or-robot", "en", "real or robot?", "real-or-robot", nil},
+		{"game-leaderboard", "en", "real or robot leaderboard", "leaderboard", nil},
+		{"ja-real-or-robot", "ja", "本物かロボットか？", "real-or-robot", nil},
+		{"play", "en", "let's play!", "play", nil},
+		{"sleep", "en", "go to bed", "sleep", nil},
+		{"laundry", "en", "do the laundry", "laundry", nil},
+		{"es-sleep", "es", "a dormir", "sleep", nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, args := findTwitch(twitchAny, c.lang, c.text)
+			if got == nil {
+				t.Fatalf("no command; want %s", c.want)
+			}
+			if got.name != c.want {
+				t.Errorf("wrong command: want %s, got %s", c.want, got.name)
+			}
+			for k, v := range c.args {
+				if args[k] != v {
+					t.Errorf("wrong %s: want %q, got %q", k, v, args[k])
+				}
+			}
+		})
+	}
+}
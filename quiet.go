@@ -0,0 +1,220 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/zephyrtronium/robot/channel"
+	"github.com/zephyrtronium/robot/message"
+	"github.com/zephyrtronium/robot/silence"
+)
+
+// quietLoop applies channels' recurring quiet hours, checking at the start of
+// each minute.
+func (robo *Robot) quietLoop(ctx context.Context) error {
+	// applied maps channel names to the ends of the quiet hours the loop has
+	// applied to them, as nanoseconds since the Unix epoch. Quiet hours are
+	// applied only once each, so that moderators can end them early.
+	applied := make(map[string]int64)
+	for {
+		now := time.Now()
+		robo.applyQuiet(ctx, applied, now)
+		t := time.NewTimer(now.Truncate(time.Minute).Add(time.Minute).Sub(now))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C: // continue on
+		}
+	}
+}
+
+// applyQuiet starts and ends quiet hours as of now.
+func (robo *Robot) applyQuiet(ctx context.Context, applied map[string]int64, now time.Time) {
+	seen := make(map[string]bool, len(applied))
+	for name, ch := range robo.channels.All() {
+		seen[name] = true
+		start, end, ok := ch.Quiet.Next(now)
+		in := ok && !start.After(now)
+		prev := applied[name]
+		switch {
+		case in && prev != end.UnixNano():
+			n := end.UnixNano()
+			applied[name] = n
+			if ch.Silent.Load() >= n {
+				// Already quiet for at least as long.
+				continue
+			}
+			ch.Silent.Store(n)
+			slog.InfoContext(ctx, "quiet hours", slog.String("channel", name), slog.Time("until", end))
+			if ch.Quiet.Announce && ch.Message != nil {
+				ch.Message(ctx, message.Format(`It's my quiet time. I won't talk or learn until %s.`, quietClock(end.In(ch.Quiet.Loc), now)))
+			}
+		case !in && prev != 0:
+			delete(applied, name)
+			if ch.Silent.Load() != prev {
+				// Someone else changed the quiet time, so it isn't ours.
+				continue
+			}
+			if prev > now.UnixNano() {
+				// The schedule changed while we were in quiet hours.
+				ch.Silent.Store(now.UnixNano())
+			}
+			slog.InfoContext(ctx, "quiet hours ended", slog.String("channel", name))
+			if ch.Quiet != nil && ch.Quiet.Announce && ch.Message != nil {
+				ch.Message(ctx, message.Sent{Text: `My quiet time has ended.`})
+			}
+		}
+	}
+	for name := range applied {
+		if !seen[name] {
+			delete(applied, name)
+		}
+	}
+}
+
+// quietClock formats a time for announcing quiet hours, including the day if
+// it is not within a day of now.
+func quietClock(t, now time.Time) string {
+	if t.Sub(now) < 24*time.Hour {
+		return t.Format("15:04 MST")
+	}
+	return t.Format("Monday 15:04 MST")
+}
+
+// Quiet makes the bot quiet in a channel until a given time, recording it so
+// that it survives restarts. If event is not empty, the stream event also ends
+// the quiet time. Quiet blocks until the time passes, then mentions the actor
+// in the channel unless the quiet time changed meanwhile.
+func (robo *Robot) Quiet(ctx context.Context, ch *channel.Channel, actor string, until time.Time, event silence.Event) {
+	if event == silence.NextStream && !ch.Enabled.Load() {
+		// Already offline, so the next stream is just the next time online.
+		event = silence.Online
+	}
+	ch.Silent.Store(until.UnixNano())
+	q := silence.Quiet{Channel: ch.Name, Until: until, Actor: actor, Event: event}
+	if robo.silence != nil {
+		if err := robo.silence.Set(ctx, q); err != nil {
+			slog.ErrorContext(ctx, "failed to record quiet time", slog.String("channel", ch.Name), slog.Any("err", err))
+		}
+	}
+	robo.awaitQuiet(ctx, q)
+}
+
+// RestoreQuiet applies the recorded quiet times that have not yet ended and
+// clears the rest. It must be called after channels are set.
+func (robo *Robot) RestoreQuiet(ctx context.Context) error {
+	l, err := robo.silence.All(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, q := range l {
+		ch, ok := robo.channels.Load(q.Channel)
+		if !ok || !q.Until.After(now) {
+			if err := robo.silence.Clear(ctx, q.Channel, q.Until); err != nil {
+				return err
+			}
+			continue
+		}
+		ch.Silent.Store(q.Until.UnixNano())
+		slog.InfoContext(ctx, "restore quiet time", slog.String("channel", q.Channel), slog.Time("until", q.Until))
+	}
+	return nil
+}
+
+// rearmQuiet starts waiting for the ends of restored quiet times.
+func (robo *Robot) rearmQuiet(ctx context.Context, group *errgroup.Group) error {
+	l, err := robo.silence.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, q := range l {
+		group.Go(func() error {
+			robo.awaitQuiet(ctx, q)
+			return nil
+		})
+	}
+	return nil
+}
+
+// awaitQuiet waits for a quiet time to end. If it is still the channel's
+// quiet time at that point, awaitQuiet clears it and mentions the actor.
+func (robo *Robot) awaitQuiet(ctx context.Context, q silence.Quiet) {
+	t := time.NewTimer(time.Until(q.Until))
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		// Leave the record so that it's restored next time.
+		return
+	case <-t.C: // continue on
+	}
+	ch, ok := robo.channels.Load(q.Channel)
+	if !ok {
+		return
+	}
+	// If the quiet time is different from what we set it to, then something
+	// else changed it. That will handle its end instead.
+	if ch.Silent.Load() != q.Until.UnixNano() {
+		return
+	}
+	if robo.silence != nil {
+		if err := robo.silence.Clear(ctx, q.Channel, q.Until); err != nil {
+			slog.ErrorContext(ctx, "failed to clear quiet time", slog.String("channel", q.Channel), slog.Any("err", err))
+		}
+	}
+	if ch.Message != nil {
+		ch.Message(ctx, message.Format(`@%s My quiet time has ended.`, q.Actor))
+	}
+}
+
+// streamsQuiet ends quiet times tied to stream events given the current
+// online status of each channel, keyed by lowercased login without '#'.
+func (robo *Robot) streamsQuiet(ctx context.Context, online map[string]bool) {
+	if robo.silence == nil {
+		return
+	}
+	l, err := robo.silence.All(ctx)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to list quiet times", slog.Any("err", err))
+		return
+	}
+	for _, q := range l {
+		if q.Event == "" {
+			continue
+		}
+		ch, ok := robo.channels.Load(q.Channel)
+		if !ok {
+			continue
+		}
+		live := online[strings.ToLower(strings.TrimPrefix(ch.Name, "#"))]
+		switch {
+		case q.Event == silence.NextStream && !live:
+			q.Event = silence.Online
+			if err := robo.silence.Set(ctx, q); err != nil {
+				slog.ErrorContext(ctx, "failed to record quiet time", slog.String("channel", q.Channel), slog.Any("err", err))
+			}
+			continue
+		case q.Event == silence.Offline && !live, q.Event == silence.Online && live:
+			// End it below.
+		default:
+			continue
+		}
+		if err := robo.silence.Clear(ctx, q.Channel, q.Until); err != nil {
+			slog.ErrorContext(ctx, "failed to clear quiet time", slog.String("channel", q.Channel), slog.Any("err", err))
+		}
+		// If the quiet time is different from what we set it to, then
+		// something else changed it. That will handle its end instead.
+		if !ch.Silent.CompareAndSwap(q.Until.UnixNano(), time.Now().UnixNano()) {
+			continue
+		}
+		slog.InfoContext(ctx, "quiet time ended by stream", slog.String("channel", q.Channel), slog.String("event", string(q.Event)))
+		if ch.Message != nil {
+			ch.Message(ctx, message.Format(`@%s My quiet time has ended.`, q.Actor))
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+
+	"github.com/zephyrtronium/robot/channel"
+	"github.com/zephyrtronium/robot/message"
+	"github.com/zephyrtronium/robot/silence"
+)
+
+func TestApplyQuiet(t *testing.T) {
+	q, err := channel.ParseQuietHours([]string{"sun 14:00-18:00"}, "UTC", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2024-06-02 is a Sunday.
+	at := func(h, m int) time.Time { return time.Date(2024, 6, 2, h, m, 0, 0, time.UTC) }
+	type step struct {
+		now    time.Time
+		before time.Time // silent time to set before applying, if not zero
+		silent time.Time
+		sent   int
+	}
+	cases := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "cycle",
+			steps: []step{
+				{now: at(13, 59), silent: time.Unix(0, 0)},
+				{now: at(14, 0), silent: at(18, 0), sent: 1},
+				{now: at(15, 0), silent: at(18, 0), sent: 1},
+				{now: at(18, 0), silent: at(18, 0), sent: 2},
+				{now: at(18, 1), silent: at(18, 0), sent: 2},
+			},
+		},
+		{
+			name: "ended-early",
+			steps: []step{
+				{now: at(14, 0), silent: at(18, 0), sent: 1},
+				{now: at(15, 0), before: at(15, 0), silent: at(15, 0), sent: 1},
+				{now: at(16, 0), silent: at(15, 0), sent: 1},
+				{now: at(18, 0), silent: at(15, 0), sent: 1},
+			},
+		},
+		{
+			name: "already-quiet",
+			steps: []step{
+				{now: at(13, 0), before: at(20, 0), silent: at(20, 0)},
+				{now: at(14, 0), silent: at(20, 0)},
+				{now: at(18, 0), silent: at(20, 0)},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			robo := New(make([]byte, 64), 1)
+			var sent int
+			ch := &channel.Channel{
+				Name:    "#bocchi",
+				Message: func(ctx context.Context, msg message.Sent) { sent++ },
+				Silent:  new(atomic.Int64),
+				Quiet:   q,
+			}
+			robo.channels.Store(ch.Name, ch)
+			applied := make(map[string]int64)
+			for i, s := range c.steps {
+				if !s.before.IsZero() {
+					ch.Silent.Store(s.before.UnixNano())
+				}
+				robo.applyQuiet(t.Context(), applied, s.now)
+				if got := ch.SilentTime(); !got.Equal(s.silent) {
+					t.Errorf("step %d: wrong silent time: want %v, got %v", i, s.silent, got)
+				}
+				if sent != s.sent {
+					t.Errorf("step %d: wrong number of messages: want %d, got %d", i, s.sent, sent)
+				}
+			}
+		})
+	}
+}
+
+func TestStreamsQuiet(t *testing.T) {
+	type step struct {
+		live  bool
+		quiet bool
+		sent  int
+	}
+	cases := []struct {
+		name  string
+		event silence.Event
+		live  bool
+		steps []step
+	}{
+		{
+			name:  "offline",
+			event: silence.Offline,
+			live:  true,
+			steps: []step{
+				{live: true, quiet: true, sent: 0},
+				{live: false, quiet: false, sent: 1},
+				{live: true, quiet: false, sent: 1},
+			},
+		},
+		{
+			name:  "next-stream-live",
+			event: silence.NextStream,
+			live:  true,
+			steps: []step{
+				{live: true, quiet: true, sent: 0},
+				{live: false, quiet: true, sent: 0},
+				{live: false, quiet: true, sent: 0},
+				{live: true, quiet: false, sent: 1},
+			},
+		},
+		{
+			name:  "next-stream-offline",
+			event: silence.NextStream,
+			live:  false,
+			steps: []step{
+				{live: false, quiet: true, sent: 0},
+				{live: true, quiet: false, sent: 1},
+			},
+		},
+		{
+			name:  "timed",
+			event: "",
+			live:  true,
+			steps: []step{
+				{live: false, quiet: true, sent: 0},
+				{live: true, quiet: true, sent: 0},
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(t.Context())
+			defer cancel()
+			pool, err := sqlitex.NewPool("file:quiet-"+c.name+".db?mode=memory&cache=shared", sqlitex.PoolOptions{Flags: sqlite.OpenReadWrite | sqlite.OpenCreate | sqlite.OpenMemory | sqlite.OpenSharedCache | sqlite.OpenURI})
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer pool.Close()
+			robo := New(make([]byte, 64), 1)
+			robo.silence, err = silence.Open(ctx, pool)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var sent atomic.Int32
+			ch := &channel.Channel{
+				Name:    "#bocchi",
+				Message: func(ctx context.Context, msg message.Sent) { sent.Add(1) },
+				Silent:  new(atomic.Int64),
+				Enabled: new(atomic.Bool),
+			}
+			ch.Enabled.Store(c.live)
+			robo.channels.Store(ch.Name, ch)
+			done := make(chan struct{})
+			go func() {
+				robo.Quiet(ctx, ch, "kita", time.Now().Add(time.Hour), c.event)
+				close(done)
+			}()
+			// Wait for the quiet time to be recorded.
+			for {
+				l, err := robo.silence.All(ctx)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(l) != 0 {
+					break
+				}
+				time.Sleep(time.Millisecond)
+			}
+			for i, s := range c.steps {
+				robo.streamsQuiet(ctx, map[string]bool{"bocchi": s.live})
+				if got := time.Now().Before(ch.SilentTime()); got != s.quiet {
+					t.Errorf("step %d: wrong quietness: want %t, got %t", i, s.quiet, got)
+				}
+				if got := int(sent.Load()); got != s.sent {
+					t.Errorf("step %d: wrong number of messages: want %d, got %d", i, s.sent, got)
+				}
+			}
+			cancel()
+			<-done
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+
+	"github.com/zephyrtronium/robot/channel"
+)
+
+// reloadLoop reloads the configuration file on SIGHUP or on request through
+// robo.reloads.
+func (robo *Robot) reloadLoop(ctx context.Context) error {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-hup:
+			slog.InfoContext(ctx, "reload on SIGHUP")
+			if err := robo.Reload(ctx); err != nil {
+				slog.ErrorContext(ctx, "reload failed; keeping current config", slog.Any("err", err))
+			}
+		case res := <-robo.reloads:
+			slog.InfoContext(ctx, "reload on request")
+			err := robo.Reload(ctx)
+			if err != nil {
+				slog.ErrorContext(ctx, "reload failed; keeping current config", slog.Any("err", err))
+			}
+			res <- err
+		}
+	}
+}
+
+// Reload loads the configuration file and applies changes to channel
+// configuration. Channels that were added or removed are joined or parted.
+// If the new configuration is invalid, the current one remains in use.
+// Reload must be called only from the reload loop.
+func (robo *Robot) Reload(ctx context.Context) error {
+	r, err := os.Open(robo.configFile)
+	if err != nil {
+		return fmt.Errorf("couldn't open config file: %w", err)
+	}
+	cfg, _, err := Load(ctx, r)
+	r.Close()
+	if err != nil {
+		return fmt.Errorf("couldn't load config: %w", err)
+	}
+	if robo.tmi != nil {
+		if err := robo.twitchPrivileges(ctx, cfg.Global.Privileges.Twitch, cfg.Twitch); err != nil {
+			return err
+		}
+	}
+	robo.cfgMu.Lock()
+	defer robo.cfgMu.Unlock()
+	// Build every channel before applying any so that an error leaves the
+	// current configuration intact.
+	built := make(map[string]*channel.Channel)
+	applied := twitchApplied{
+		global:   cfg.Global,
+		channels: make(map[string]*ChannelCfg),
+		joined:   make(map[string]string),
+	}
+	for nm, ch := range cfg.Twitch {
+		vs, err := robo.twitchChannels(nm, cfg.Global, ch)
+		if err != nil {
+			return err
+		}
+		for _, v := range vs {
+			if _, ok := built[v.Name]; ok {
+				return fmt.Errorf("channel %s is configured more than once", v.Name)
+			}
+			built[v.Name] = v
+			applied.channels[v.Name] = ch
+		}
+	}
+	old := robo.twitchCfg
+	var unjoin []string
+	for name, id := range old.joined {
+		if _, ok := built[name]; ok {
+			// The config file takes over channels that were joined at runtime.
+			unjoin = append(unjoin, name)
+			continue
+		}
+		ch := joinedCfg(cfg.Global, name)
+		vs, err := robo.twitchChannels("join", cfg.Global, ch)
+		if err != nil {
+			return err
+		}
+		built[name] = vs[0]
+		applied.channels[name] = ch
+		applied.joined[name] = id
+	}
+	for _, name := range unjoin {
+		if err := robo.roster.Remove(ctx, name); err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "reload configured joined channel", slog.String("channel", name))
+	}
+
+	var join, part []string
+	for name, v := range built {
+		prev, _ := robo.channels.Load(name)
+		oc := old.channels[name]
+		switch {
+		case prev == nil:
+			if err := robo.loadBlockTerms(ctx, v); err != nil {
+				// Not worth failing the whole reload.
+				slog.ErrorContext(ctx, "reload couldn't load blocked terms", slog.String("channel", name), slog.Any("err", err))
+			}
+			robo.channels.Store(name, v)
+			join = append(join, name)
+			slog.InfoContext(ctx, "reload added channel", slog.String("channel", name))
+			continue
+		case oc == nil:
+			// We're already in the channel, but it wasn't in the config.
+			// Keep its state, but otherwise it's all new.
+			v.History, v.Silent, v.Cooldowns, v.Extra, v.Enabled = prev.History, prev.Silent, prev.Cooldowns, prev.Extra, prev.Enabled
+			v.Block.SetTerms(prev.Block.Terms())
+			robo.channels.Store(name, v)
+			slog.InfoContext(ctx, "reload configured channel", slog.String("channel", name))
+			continue
+		}
+		changes := twitchChanges(old.global, oc, cfg.Global, applied.channels[name])
+		if len(changes) == 0 {
+			continue
+		}
+		v.History, v.Silent, v.Cooldowns, v.Extra, v.Enabled = prev.History, prev.Silent, prev.Cooldowns, prev.Extra, prev.Enabled
+		v.Block.SetTerms(prev.Block.Terms())
+		// Keep settings that may have been tuned at runtime if the config
+		// doesn't change them.
+		if oc.Responses == applied.channels[name].Responses {
+			v.SetResponses(prev.Responses())
+		}
+		if oc.Rate == applied.channels[name].Rate {
+			v.Rate = prev.Rate
+		}
+		if oc.Copypasta == applied.channels[name].Copypasta {
+			v.Memery = prev.Memery
+		}
+		if oc.Quota == applied.channels[name].Quota {
+			v.Quota = prev.Quota
+		}
+		robo.channels.Store(name, v)
+		for _, c := range changes {
+			slog.InfoContext(ctx, "reload changed channel",
+				slog.String("channel", name),
+				slog.String("setting", c.setting),
+				slog.Any("old", c.old),
+				slog.Any("new", c.new),
+			)
+		}
+	}
+	for name := range old.channels {
+		if _, ok := built[name]; ok {
+			continue
+		}
+		robo.channels.Delete(name)
+		part = append(part, name)
+		slog.InfoContext(ctx, "reload removed channel", slog.String("channel", name))
+	}
+	robo.twitchCfg = applied
+	if robo.tmi != nil {
+		go func() {
+			batchTwitch(ctx, robo.tmi.send, "PART", part)
+			batchTwitch(ctx, robo.tmi.send, "JOIN", join)
+		}()
+	}
+	return nil
+}
+
+// change is a single setting changed by a reload.
+type change struct {
+	setting string
+	old     any
+	new     any
+}
+
+// changed appends a change to r if old and new differ.
+func changed[T comparable](r []change, setting string, old, new T) []change {
+	if old == new {
+		return r
+	}
+	return append(r, change{setting: setting, old: old, new: new})
+}
+
+// changedMap appends a change to r if old and new differ.
+func changedMap(r []change, setting string, old, new map[string]int) []change {
+	if maps.Equal(old, new) {
+		return r
+	}
+	return append(r, change{setting: setting, old: old, new: new})
+}
+
+// twitchChanges lists the effective settings of a Twitch channel that differ
+// between two configurations.
+func twitchChanges(og Global, oc *ChannelCfg, ng Global, nc *ChannelCfg) []change {
+	var r []change
+	r = changed(r, "learn", oc.Learn, nc.Learn)
+	r = changed(r, "send", oc.Send, nc.Send)
+	r = changed(r, "lang", oc.Lang, nc.Lang)
+	r = changed(r, "pet", oc.Pet, nc.Pet)
+	r = changed(r, "links", cmp.Or(oc.Links, og.Links, channel.Block), cmp.Or(nc.Links, ng.Links, channel.Block))
+	r = changed(r, "botcommands", cmp.Or(oc.BotCommands, og.BotCommands, channel.Block), cmp.Or(nc.BotCommands, ng.BotCommands, channel.Block))
+	r = changed(r, "oneword", cmp.Or(oc.OneWord, og.OneWord, channel.Block), cmp.Or(nc.OneWord, ng.OneWord, channel.Block))
+	r = changed(r, "caps", cmp.Or(oc.Caps, og.Caps, channel.Learn), cmp.Or(nc.Caps, ng.Caps, channel.Learn))
+	r = changed(r, "emoteonly", cmp.Or(oc.EmoteOnly, og.EmoteOnly, channel.Learn), cmp.Or(nc.EmoteOnly, ng.EmoteOnly, channel.Learn))
+	r = changed(r, "long", cmp.Or(oc.Long, og.Long, channel.Learn), cmp.Or(nc.Long, ng.Long, channel.Learn))
+	r = changed(r, "repeated", cmp.Or(oc.Repeated, og.Repeated, channel.Learn), cmp.Or(nc.Repeated, ng.Repeated, channel.Learn))
+	r = changed(r, "mentions", cmp.Or(oc.Mentions, og.Mentions, channel.Learn), cmp.Or(nc.Mentions, ng.Mentions, channel.Learn))
+	r = changed(r, "block", mergedre(og.Block, oc.Block), mergedre(ng.Block, nc.Block))
+	r = changed(r, "meme", mergedre(og.Meme, oc.Meme), mergedre(ng.Meme, nc.Meme))
+	r = changed(r, "responses", oc.Responses, nc.Responses)
+	r = changed(r, "rate", oc.Rate, nc.Rate)
+	r = changed(r, "copypasta", oc.Copypasta, nc.Copypasta)
+	r = changed(r, "quota", oc.Quota, nc.Quota)
+	if !slices.Equal(oc.Quiet.Windows, nc.Quiet.Windows) || oc.Quiet.TZ != nc.Quiet.TZ || oc.Quiet.Announce != nc.Quiet.Announce {
+		r = append(r, change{setting: "quiet", old: oc.Quiet, new: nc.Quiet})
+	}
+	if !slices.Equal(oc.Commands, nc.Commands) {
+		r = append(r, change{setting: "commands", old: oc.Commands, new: nc.Commands})
+	}
+	r = changedMap(r, "emotes", mergemaps(og.Emotes, oc.Emotes), mergemaps(ng.Emotes, nc.Emotes))
+	r = changedMap(r, "effects", mergemaps(og.Effects, oc.Effects), mergemaps(ng.Effects, nc.Effects))
+	if !slices.Equal(og.Privileges.Twitch, ng.Privileges.Twitch) || !slices.Equal(oc.Privileges, nc.Privileges) {
+		r = append(r, change{
+			setting: "privileges",
+			old:     slices.Concat(og.Privileges.Twitch, oc.Privileges),
+			new:     slices.Concat(ng.Privileges.Twitch, nc.Privileges),
+		})
+	}
+	return r
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/zephyrtronium/robot/channel"
+)
+
+func TestTwitchChanges(t *testing.T) {
+	base := func() (Global, *ChannelCfg) {
+		g := Global{
+			Links:       channel.Block,
+			BotCommands: channel.Meme,
+			Block:       "bad",
+			Emotes:      map[string]int{"": 1},
+			Privileges:  GlobalPrivs{Twitch: []Privilege{{ID: "1", Name: "nightbot", Level: "ignore"}}},
+		}
+		c := &ChannelCfg{
+			Channels:  []string{"#bocchi"},
+			Learn:     "kessoku",
+			Send:      "kessoku",
+			Responses: 0.02,
+			Rate:      Rate{Every: 10, Num: 2},
+			Emotes:    map[string]int{"btw": 1},
+		}
+		return g, c
+	}
+	cases := []struct {
+		name string
+		edit func(g *Global, c *ChannelCfg)
+		want []string
+	}{
+		{
+			name: "none",
+			edit: func(g *Global, c *ChannelCfg) {},
+			want: nil,
+		},
+		{
+			name: "channels",
+			edit: func(g *Global, c *ChannelCfg) { c.Channels = append(c.Channels, "#ryo") },
+			want: nil,
+		},
+		{
+			name: "responses",
+			edit: func(g *Global, c *ChannelCfg) { c.Responses = 0.5 },
+			want: []string{"responses"},
+		},
+		{
+			name: "rate",
+			edit: func(g *Global, c *ChannelCfg) { c.Rate.Num = 3 },
+			want: []string{"rate"},
+		},
+		{
+			name: "caps-default",
+			edit: func(g *Global, c *ChannelCfg) { g.Caps = channel.Learn },
+			want: nil,
+		},
+		{
+			name: "mentions",
+			edit: func(g *Global, c *ChannelCfg) { c.Mentions = channel.Meme },
+			want: []string{"mentions"},
+		},
+		{
+			name: "lang",
+			edit: func(g *Global, c *ChannelCfg) { c.Lang = "ja" },
+			want: []string{"lang"},
+		},
+		{
+			name: "quota",
+			edit: func(g *Global, c *ChannelCfg) { c.Quota = Rate{Every: 600, Num: 20} },
+			want: []string{"quota"},
+		},
+		{
+			name: "quiet",
+			edit: func(g *Global, c *ChannelCfg) { c.Quiet.Windows = []string{"sun 12:00-18:00"} },
+			want: []string{"quiet"},
+		},
+		{
+			name: "commands",
+			edit: func(g *Global, c *ChannelCfg) {
+				c.Commands = []CommandCfg{{Name: "hug", Regex: `^hug`, Response: `{{.Sender}} hugs`}}
+			},
+			want: []string{"commands"},
+		},
+		{
+			name: "quiet-announce",
+			edit: func(g *Global, c *ChannelCfg) { c.Quiet.Announce = true },
+			want: []string{"quiet"},
+		},
+		{
+			name: "block-global",
+			edit: func(g *Global, c *ChannelCfg) { g.Block = "worse" },
+			want: []string{"block"},
+		},
+		{
+			name: "links-override",
+			edit: func(g *Global, c *ChannelCfg) { c.Links = channel.Block },
+			want: nil,
+		},
+		{
+			name: "botcommands-override",
+			edit: func(g *Global, c *ChannelCfg) { c.BotCommands = channel.Learn },
+			want: []string{"botcommands"},
+		},
+		{
+			name: "emotes",
+			edit: func(g *Global, c *ChannelCfg) { c.Emotes = map[string]int{"btw": 2} },
+			want: []string{"emotes"},
+		},
+		{
+			name: "effects",
+			edit: func(g *Global, c *ChannelCfg) { g.Effects = map[string]int{"OwO": 1} },
+			want: []string{"effects"},
+		},
+		{
+			name: "privileges",
+			edit: func(g *Global, c *ChannelCfg) {
+				c.Privileges = []Privilege{{ID: "2", Name: "kita", Level: "moderator"}}
+			},
+			want: []string{"privileges"},
+		},
+		{
+			name: "many",
+			edit: func(g *Global, c *ChannelCfg) {
+				c.Learn = "sickhack"
+				c.Copypasta.Need = 3
+				g.Meme = "meme"
+			},
+			want: []string{"learn", "meme", "copypasta"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			og, oc := base()
+			ng, nc := base()
+			c.edit(&ng, nc)
+			var got []string
+			for _, v := range twitchChanges(og, oc, ng, nc) {
+				got = append(got, v.setting)
+			}
+			if !slices.Equal(got, c.want) {
+				t.Errorf("wrong changes: want %q, got %q", c.want, got)
+			}
+		})
+	}
+}
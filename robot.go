@@ -8,18 +8,24 @@ import (
 	"log/slog"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"gitlab.com/zephyrtronium/tmi"
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/time/rate"
 
+	"github.com/zephyrtronium/robot/audit"
 	"github.com/zephyrtronium/robot/auth"
+	"github.com/zephyrtronium/robot/blocklist"
 	"github.com/zephyrtronium/robot/brain"
 	"github.com/zephyrtronium/robot/channel"
+	"github.com/zephyrtronium/robot/leaderboard"
 	"github.com/zephyrtronium/robot/metrics"
 	"github.com/zephyrtronium/robot/pet"
 	"github.com/zephyrtronium/robot/privacy"
+	"github.com/zephyrtronium/robot/roster"
+	"github.com/zephyrtronium/robot/silence"
 	"github.com/zephyrtronium/robot/spoken"
 	"github.com/zephyrtronium/robot/syncmap"
 	"github.com/zephyrtronium/robot/twitch"
@@ -36,6 +42,8 @@ type Robot struct {
 	spoken *spoken.History
 	// channels are the channels.
 	channels *syncmap.Map[string, *channel.Channel]
+	// rooms maps Twitch room IDs to the names of channels the bot has joined.
+	rooms *syncmap.Map[string, string]
 	// works is the worker queue.
 	works chan chan func(context.Context)
 	// hashes is a function that obtains userhashers.
@@ -49,10 +57,29 @@ type Robot struct {
 	tmi *client[*tmi.Message, *tmi.Message]
 	// twitch is the Twitch API client.
 	twitch twitch.Client
-	// pet is the robot's pet status.
-	pet pet.Status
+	// pets is the robot's pets, shared among channels by name.
+	pets *pet.Store
 	// metrics are a collection of custom domain specific metrics.
 	metrics *metrics.Metrics
+	// configFile is the path to the configuration file, for reloading.
+	configFile string
+	// blocklist is the per-channel list of terms blocked by moderators.
+	blocklist *blocklist.List
+	// audit is the moderation audit log.
+	audit *audit.Log
+	// silence is the record of quiet times in effect.
+	silence *silence.List
+	// leaderboard is the scores for real or robot.
+	leaderboard *leaderboard.Board
+	// roster is the record of channels joined at runtime.
+	roster *roster.Roster
+	// cfgMu serializes changes to the channel list and twitchCfg.
+	cfgMu sync.Mutex
+	// twitchCfg is the Twitch channel configuration currently applied.
+	twitchCfg twitchApplied
+	// reloads carries requests to reload the configuration.
+	// Each request receives the result of the reload.
+	reloads chan chan error
 }
 
 // client is the settings for OAuth2 and related elements.
@@ -80,9 +107,11 @@ type client[Send, Receive any] struct {
 func New(usersKey []byte, poolSize int) *Robot {
 	return &Robot{
 		channels: syncmap.New[string, *channel.Channel](),
+		rooms:    syncmap.New[string, string](),
 		works:    make(chan chan func(context.Context), poolSize),
 		hashes:   func() userhash.Hasher { return userhash.New(usersKey) },
 		metrics:  newMetrics(),
+		reloads:  make(chan chan error),
 	}
 }
 
@@ -92,6 +121,12 @@ func (robo *Robot) Run(ctx context.Context, listen string) error {
 	if robo.tmi != nil {
 		group.Go(func() error { return robo.runTwitch(ctx, group) })
 	}
+	if robo.configFile != "" {
+		group.Go(func() error { return robo.reloadLoop(ctx) })
+	}
+	if robo.audit != nil {
+		group.Go(func() error { return robo.auditLoop(ctx) })
+	}
 	if listen != "" {
 		group.Go(func() error { return robo.api(ctx, listen, new(http.ServeMux), robo.metrics.Collectors()) })
 	}
@@ -115,6 +150,15 @@ func (robo *Robot) runTwitch(ctx context.Context, group *errgroup.Group) error {
 	group.Go(func() error {
 		return robo.streamsLoop(ctx, robo.channels)
 	})
+	group.Go(func() error {
+		return robo.quietLoop(ctx)
+	})
+	group.Go(func() error {
+		return robo.petLoop(ctx)
+	})
+	if err := robo.rearmQuiet(ctx, group); err != nil {
+		return err
+	}
 	tok, err := robo.tmi.tokens.Token(ctx)
 	if err != nil {
 		return err
@@ -181,6 +225,25 @@ func (robo *Robot) twitchValidateLoop(ctx context.Context) error {
 	}
 }
 
+// streamsQuery appends the query for the online status of each channel to
+// streams. Channels joined at runtime are queried by their owners' user IDs,
+// which survive name changes. The map gives the channel names for those IDs.
+func (robo *Robot) streamsQuery(streams []twitch.Stream, channels *syncmap.Map[string, *channel.Channel]) ([]twitch.Stream, map[string]string) {
+	robo.cfgMu.Lock()
+	defer robo.cfgMu.Unlock()
+	byID := make(map[string]string, len(robo.twitchCfg.joined))
+	for _, ch := range channels.All() {
+		n := strings.ToLower(strings.TrimPrefix(ch.Name, "#"))
+		if id := robo.twitchCfg.joined[ch.Name]; id != "" {
+			byID[id] = n
+			streams = append(streams, twitch.Stream{UserID: id})
+			continue
+		}
+		streams = append(streams, twitch.Stream{UserLogin: n})
+	}
+	return streams, byID
+}
+
 func (robo *Robot) streamsLoop(ctx context.Context, channels *syncmap.Map[string, *channel.Channel]) error {
 	// TODO(zeph): one day we should switch to eventsub
 	// TODO(zeph): remove anything learned since the last check when offline
@@ -191,11 +254,7 @@ func (robo *Robot) streamsLoop(ctx context.Context, channels *syncmap.Map[string
 	streams := make([]twitch.Stream, 0, channels.Len())
 	m := make(map[string]bool, channels.Len())
 	// Run once at the start so we start learning in online streams immediately.
-	streams = streams[:0]
-	for _, ch := range channels.All() {
-		n := strings.ToLower(strings.TrimPrefix(ch.Name, "#"))
-		streams = append(streams, twitch.Stream{UserLogin: n})
-	}
+	streams, byID := robo.streamsQuery(streams[:0], channels)
 	for range 5 {
 		// TODO(zeph): limit to 100
 		streams, err = twitch.UserStreams(ctx, robo.twitch, tok, streams)
@@ -212,12 +271,16 @@ func (robo *Robot) streamsLoop(ctx context.Context, channels *syncmap.Map[string
 				)
 				n := strings.ToLower(s.UserLogin)
 				m[n] = true
+				if n, ok := byID[s.UserID]; ok {
+					m[n] = true
+				}
 			}
 			// Now loop all streams.
 			for _, ch := range channels.All() {
 				n := strings.ToLower(strings.TrimPrefix(ch.Name, "#"))
 				ch.Enabled.Store(m[n])
 			}
+			robo.streamsQuiet(ctx, m)
 		case errors.Is(err, twitch.ErrNeedRefresh):
 			tok, err = robo.tmi.tokens.Refresh(ctx, tok)
 			if err != nil {
@@ -244,10 +307,7 @@ func (robo *Robot) streamsLoop(ctx context.Context, channels *syncmap.Map[string
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-tick.C:
-			for _, ch := range channels.All() {
-				n := strings.TrimPrefix(ch.Name, "#")
-				streams = append(streams, twitch.Stream{UserLogin: n})
-			}
+			streams, byID = robo.streamsQuery(streams, channels)
 			for range 5 {
 				// TODO(zeph): limit to 100
 				streams, err = twitch.UserStreams(ctx, robo.twitch, tok, streams)
@@ -264,12 +324,16 @@ func (robo *Robot) streamsLoop(ctx context.Context, channels *syncmap.Map[string
 						)
 						n := strings.ToLower(s.UserLogin)
 						m[n] = true
+						if n, ok := byID[s.UserID]; ok {
+							m[n] = true
+						}
 					}
 					// Now loop all streams.
 					for _, ch := range channels.All() {
 						n := strings.ToLower(strings.TrimPrefix(ch.Name, "#"))
 						ch.Enabled.Store(m[n])
 					}
+					robo.streamsQuiet(ctx, m)
 				case errors.Is(err, twitch.ErrNeedRefresh):
 					tok, err = robo.tmi.tokens.Refresh(ctx, tok)
 					if err != nil {
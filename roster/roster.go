@@ -0,0 +1,89 @@
+// Package roster records channels joined at runtime.
+package roster
+
+import (
+	"context"
+	"fmt"
+
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+)
+
+// Roster is the set of channels joined at runtime, backed by an SQL database.
+type Roster struct {
+	db *sqlitex.Pool
+}
+
+// Channel is a channel in the roster.
+type Channel struct {
+	// Name is the channel name.
+	Name string
+	// ID is the platform ID of the channel's owner.
+	ID string
+}
+
+// Open opens an existing roster in an SQL database.
+func Open(ctx context.Context, db *sqlitex.Pool) (*Roster, error) {
+	conn, err := db.Take(ctx)
+	defer db.Put(conn)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get connection from pool: %w", err)
+	}
+	const schemaSQL = `CREATE TABLE IF NOT EXISTS roster (channel TEXT PRIMARY KEY, id TEXT NOT NULL) STRICT, WITHOUT ROWID`
+	if err := sqlitex.ExecuteTransient(conn, schemaSQL, nil); err != nil {
+		return nil, fmt.Errorf("couldn't run migration: %w", err)
+	}
+	return &Roster{db: db}, nil
+}
+
+// Add adds a channel to the roster.
+// If the channel is already present, its ID is updated.
+func (r *Roster) Add(ctx context.Context, ch Channel) error {
+	conn, err := r.db.Take(ctx)
+	defer r.db.Put(conn)
+	if err != nil {
+		return fmt.Errorf("couldn't get connection to add channel to roster: %w", err)
+	}
+	opts := sqlitex.ExecOptions{Args: []any{ch.Name, ch.ID}}
+	err = sqlitex.Execute(conn, `INSERT OR REPLACE INTO roster (channel, id) VALUES (?, ?)`, &opts)
+	if err != nil {
+		return fmt.Errorf("couldn't add channel to roster: %w", err)
+	}
+	return nil
+}
+
+// Remove removes a channel from the roster.
+func (r *Roster) Remove(ctx context.Context, name string) error {
+	conn, err := r.db.Take(ctx)
+	defer r.db.Put(conn)
+	if err != nil {
+		return fmt.Errorf("couldn't get connection to remove channel from roster: %w", err)
+	}
+	opts := sqlitex.ExecOptions{Args: []any{name}}
+	err = sqlitex.Execute(conn, `DELETE FROM roster WHERE channel=?`, &opts)
+	if err != nil {
+		return fmt.Errorf("couldn't remove channel from roster: %w", err)
+	}
+	return nil
+}
+
+// All gets all channels in the roster.
+func (r *Roster) All(ctx context.Context) ([]Channel, error) {
+	conn, err := r.db.Take(ctx)
+	defer r.db.Put(conn)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get connection to list roster: %w", err)
+	}
+	var l []Channel
+	opts := sqlitex.ExecOptions{
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			l = append(l, Channel{Name: stmt.ColumnText(0), ID: stmt.ColumnText(1)})
+			return nil
+		},
+	}
+	err = sqlitex.Execute(conn, `SELECT channel, id FROM roster ORDER BY channel`, &opts)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list roster: %w", err)
+	}
+	return l, nil
+}
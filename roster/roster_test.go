@@ -0,0 +1,88 @@
+package roster_test
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"testing"
+
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+
+	"github.com/zephyrtronium/robot/roster"
+)
+
+var dbcount atomic.Uint64
+
+func testConn() *sqlitex.Pool {
+	k := dbcount.Add(1)
+	pool, err := sqlitex.NewPool(fmt.Sprintf("file:roster-%d.db?mode=memory&cache=shared", k), sqlitex.PoolOptions{Flags: sqlite.OpenReadWrite | sqlite.OpenCreate | sqlite.OpenMemory | sqlite.OpenSharedCache | sqlite.OpenURI})
+	if err != nil {
+		panic(err)
+	}
+	return pool
+}
+
+func TestRoster(t *testing.T) {
+	cases := []struct {
+		name string
+		add  []roster.Channel
+		rem  []string
+		want []roster.Channel
+	}{
+		{
+			name: "empty",
+			want: nil,
+		},
+		{
+			name: "present",
+			add:  []roster.Channel{{"#bocchi", "1"}, {"#ryo", "2"}},
+			want: []roster.Channel{{"#bocchi", "1"}, {"#ryo", "2"}},
+		},
+		{
+			name: "replace",
+			add:  []roster.Channel{{"#bocchi", "1"}, {"#bocchi", "3"}},
+			want: []roster.Channel{{"#bocchi", "3"}},
+		},
+		{
+			name: "remove-none",
+			add:  []roster.Channel{{"#bocchi", "1"}},
+			rem:  []string{"#nijika"},
+			want: []roster.Channel{{"#bocchi", "1"}},
+		},
+		{
+			name: "remove",
+			add:  []roster.Channel{{"#bocchi", "1"}, {"#ryo", "2"}, {"#kita", "4"}},
+			rem:  []string{"#ryo"},
+			want: []roster.Channel{{"#bocchi", "1"}, {"#kita", "4"}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			r, err := roster.Open(ctx, testConn())
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, v := range c.add {
+				if err := r.Add(ctx, v); err != nil {
+					t.Errorf("couldn't add %v: %v", v, err)
+				}
+			}
+			for _, v := range c.rem {
+				if err := r.Remove(ctx, v); err != nil {
+					t.Errorf("couldn't remove %q: %v", v, err)
+				}
+			}
+			got, err := r.All(ctx)
+			if err != nil {
+				t.Errorf("couldn't list: %v", err)
+			}
+			if !slices.Equal(got, c.want) {
+				t.Errorf("wrong roster: want %v, got %v", c.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Package silence records channels' quiet times so that they survive restarts.
+package silence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+)
+
+// List is the set of quiet times in effect, backed by an SQL database.
+type List struct {
+	db *sqlitex.Pool
+}
+
+// Quiet is a quiet time in a channel.
+type Quiet struct {
+	// Channel is the channel name.
+	Channel string
+	// Until is the time at which the quiet time ends.
+	Until time.Time
+	// Actor is the name of the user who requested the quiet time.
+	Actor string
+	// Event is the stream event that ends the quiet time early, if any.
+	Event Event
+}
+
+// Event is a stream event that can end a quiet time.
+type Event string
+
+const (
+	// Offline ends a quiet time when the stream is offline.
+	Offline Event = "offline"
+	// Online ends a quiet time when the stream is online.
+	Online Event = "online"
+	// NextStream ends a quiet time when the stream goes offline and then
+	// online again. Once the stream is offline, it becomes Online.
+	NextStream Event = "stream"
+)
+
+// Open opens an existing list of quiet times in an SQL database.
+func Open(ctx context.Context, db *sqlitex.Pool) (*List, error) {
+	conn, err := db.Take(ctx)
+	defer db.Put(conn)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get connection from pool: %w", err)
+	}
+	const schemaSQL = `CREATE TABLE IF NOT EXISTS silence (channel TEXT PRIMARY KEY, until INTEGER NOT NULL, actor TEXT NOT NULL, event TEXT NOT NULL) STRICT, WITHOUT ROWID`
+	if err := sqlitex.ExecuteTransient(conn, schemaSQL, nil); err != nil {
+		return nil, fmt.Errorf("couldn't run migration: %w", err)
+	}
+	// Tables from before quiet times could end with stream events have no
+	// event column. Add it with no event for the existing quiet times.
+	var hasEvent bool
+	opts := sqlitex.ExecOptions{
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			hasEvent = true
+			return nil
+		},
+	}
+	if err := sqlitex.ExecuteTransient(conn, `SELECT 1 FROM pragma_table_info('silence') WHERE name = 'event'`, &opts); err != nil {
+		return nil, fmt.Errorf("couldn't check quiet time columns: %w", err)
+	}
+	if !hasEvent {
+		if err := sqlitex.ExecuteTransient(conn, `ALTER TABLE silence ADD COLUMN event TEXT NOT NULL DEFAULT ''`, nil); err != nil {
+			return nil, fmt.Errorf("couldn't add event column: %w", err)
+		}
+	}
+	return &List{db: db}, nil
+}
+
+// Set records a channel's quiet time, replacing any existing one.
+func (l *List) Set(ctx context.Context, q Quiet) error {
+	conn, err := l.db.Take(ctx)
+	defer l.db.Put(conn)
+	if err != nil {
+		return fmt.Errorf("couldn't get connection to set quiet time: %w", err)
+	}
+	opts := sqlitex.ExecOptions{Args: []any{q.Channel, q.Until.UnixNano(), q.Actor, string(q.Event)}}
+	err = sqlitex.Execute(conn, `INSERT OR REPLACE INTO silence (channel, until, actor, event) VALUES (?, ?, ?, ?)`, &opts)
+	if err != nil {
+		return fmt.Errorf("couldn't set quiet time: %w", err)
+	}
+	return nil
+}
+
+// Clear removes a channel's quiet time if it ends at the given time.
+// A quiet time that has since been replaced is kept.
+func (l *List) Clear(ctx context.Context, channel string, until time.Time) error {
+	conn, err := l.db.Take(ctx)
+	defer l.db.Put(conn)
+	if err != nil {
+		return fmt.Errorf("couldn't get connection to clear quiet time: %w", err)
+	}
+	opts := sqlitex.ExecOptions{Args: []any{channel, until.UnixNano()}}
+	err = sqlitex.Execute(conn, `DELETE FROM silence WHERE channel=? AND until=?`, &opts)
+	if err != nil {
+		return fmt.Errorf("couldn't clear quiet time: %w", err)
+	}
+	return nil
+}
+
+// All gets all recorded quiet times, including ones that have ended but
+// have not been cleared.
+func (l *List) All(ctx context.Context) ([]Quiet, error) {
+	conn, err := l.db.Take(ctx)
+	defer l.db.Put(conn)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get connection to list quiet times: %w", err)
+	}
+	var r []Quiet
+	opts := sqlitex.ExecOptions{
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			r = append(r, Quiet{
+				Channel: stmt.ColumnText(0),
+				Until:   time.Unix(0, stmt.ColumnInt64(1)),
+				Actor:   stmt.ColumnText(2),
+				Event:   Event(stmt.ColumnText(3)),
+			})
+			return nil
+		},
+	}
+	err = sqlitex.Execute(conn, `SELECT channel, until, actor, event FROM silence ORDER BY channel`, &opts)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list quiet times: %w", err)
+	}
+	return r, nil
+}
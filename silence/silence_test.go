@@ -0,0 +1,149 @@
+package silence_test
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+
+	"github.com/zephyrtronium/robot/silence"
+)
+
+var dbcount atomic.Uint64
+
+func testConn() *sqlitex.Pool {
+	k := dbcount.Add(1)
+	pool, err := sqlitex.NewPool(fmt.Sprintf("file:silence-%d.db?mode=memory&cache=shared", k), sqlitex.PoolOptions{Flags: sqlite.OpenReadWrite | sqlite.OpenCreate | sqlite.OpenMemory | sqlite.OpenSharedCache | sqlite.OpenURI})
+	if err != nil {
+		panic(err)
+	}
+	return pool
+}
+
+func TestList(t *testing.T) {
+	type clear struct {
+		channel string
+		until   time.Time
+	}
+	at := func(h int) time.Time { return time.Date(2024, 6, 2, h, 0, 0, 0, time.UTC) }
+	cases := []struct {
+		name string
+		set  []silence.Quiet
+		rem  []clear
+		want []silence.Quiet
+	}{
+		{
+			name: "empty",
+			want: nil,
+		},
+		{
+			name: "present",
+			set:  []silence.Quiet{{"#bocchi", at(1), "kita", ""}, {"#ryo", at(2), "nijika", ""}},
+			want: []silence.Quiet{{"#bocchi", at(1), "kita", ""}, {"#ryo", at(2), "nijika", ""}},
+		},
+		{
+			name: "replace",
+			set:  []silence.Quiet{{"#bocchi", at(1), "kita", ""}, {"#bocchi", at(3), "nijika", ""}},
+			want: []silence.Quiet{{"#bocchi", at(3), "nijika", ""}},
+		},
+		{
+			name: "clear",
+			set:  []silence.Quiet{{"#bocchi", at(1), "kita", ""}, {"#ryo", at(2), "nijika", ""}},
+			rem:  []clear{{"#bocchi", at(1)}},
+			want: []silence.Quiet{{"#ryo", at(2), "nijika", ""}},
+		},
+		{
+			name: "clear-replaced",
+			set:  []silence.Quiet{{"#bocchi", at(1), "kita", ""}, {"#bocchi", at(3), "nijika", ""}},
+			rem:  []clear{{"#bocchi", at(1)}},
+			want: []silence.Quiet{{"#bocchi", at(3), "nijika", ""}},
+		},
+		{
+			name: "event",
+			set:  []silence.Quiet{{"#bocchi", at(1), "kita", silence.Offline}, {"#ryo", at(2), "nijika", silence.NextStream}},
+			want: []silence.Quiet{{"#bocchi", at(1), "kita", silence.Offline}, {"#ryo", at(2), "nijika", silence.NextStream}},
+		},
+		{
+			name: "clear-none",
+			set:  []silence.Quiet{{"#bocchi", at(1), "kita", ""}},
+			rem:  []clear{{"#seika", at(1)}},
+			want: []silence.Quiet{{"#bocchi", at(1), "kita", ""}},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			ctx := context.Background()
+			l, err := silence.Open(ctx, testConn())
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, v := range c.set {
+				if err := l.Set(ctx, v); err != nil {
+					t.Errorf("couldn't set %v: %v", v, err)
+				}
+			}
+			for _, v := range c.rem {
+				if err := l.Clear(ctx, v.channel, v.until); err != nil {
+					t.Errorf("couldn't clear %v: %v", v, err)
+				}
+			}
+			got, err := l.All(ctx)
+			if err != nil {
+				t.Errorf("couldn't list: %v", err)
+			}
+			if len(got) != len(c.want) {
+				t.Fatalf("wrong quiet times: want %v, got %v", c.want, got)
+			}
+			for i, w := range c.want {
+				g := got[i]
+				if g.Channel != w.Channel || !g.Until.Equal(w.Until) || g.Actor != w.Actor || g.Event != w.Event {
+					t.Errorf("wrong quiet time %d: want %v, got %v", i, w, g)
+				}
+			}
+		})
+	}
+}
+
+func TestMigrateEvent(t *testing.T) {
+	ctx := context.Background()
+	db := testConn()
+	conn, err := db.Take(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Schema from before quiet times had events.
+	const old = `CREATE TABLE silence (channel TEXT PRIMARY KEY, until INTEGER NOT NULL, actor TEXT NOT NULL) STRICT, WITHOUT ROWID`
+	if err := sqlitex.ExecuteTransient(conn, old, nil); err != nil {
+		t.Fatal(err)
+	}
+	until := time.Unix(1e9, 0)
+	opts := sqlitex.ExecOptions{Args: []any{"#bocchi", until.UnixNano(), "kita"}}
+	if err := sqlitex.Execute(conn, `INSERT INTO silence (channel, until, actor) VALUES (?, ?, ?)`, &opts); err != nil {
+		t.Fatal(err)
+	}
+	db.Put(conn)
+	l, err := silence.Open(ctx, db)
+	if err != nil {
+		t.Fatalf("couldn't open old list: %v", err)
+	}
+	got, err := l.All(ctx)
+	if err != nil {
+		t.Fatalf("couldn't list: %v", err)
+	}
+	want := silence.Quiet{Channel: "#bocchi", Until: until, Actor: "kita"}
+	if len(got) != 1 || got[0].Channel != want.Channel || !got[0].Until.Equal(want.Until) || got[0].Actor != want.Actor || got[0].Event != want.Event {
+		t.Errorf("wrong quiet times after migration: want [%v], got %v", want, got)
+	}
+	if err := l.Set(ctx, silence.Quiet{Channel: "#ryo", Until: until, Actor: "nijika", Event: silence.Offline}); err != nil {
+		t.Errorf("couldn't set after migration: %v", err)
+	}
+	// Opening again must not try to add the column twice.
+	if _, err := silence.Open(ctx, db); err != nil {
+		t.Errorf("couldn't reopen migrated list: %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+package spoken
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+)
+
+// Score is the feedback chat has given on a spoken message.
+type Score struct {
+	Text string    `json:"text"`
+	Time time.Time `json:"time"`
+	Good int       `json:"good"`
+	Bad  int       `json:"bad"`
+}
+
+// Rate records a user's feedback on the most recent instance of a message,
+// replacing any feedback the same user gave it before.
+// It reports whether the message has been recorded.
+func (h *History) Rate(ctx context.Context, tag, msg, user string, good bool) (ok bool, err error) {
+	conn, err := h.db.Take(ctx)
+	defer h.db.Put(conn)
+	if err != nil {
+		return false, fmt.Errorf("couldn't get connection to rate message: %w", err)
+	}
+	// Drop cached weights only once the rating is committed, so that they
+	// can't be recomputed from the old feedback in between.
+	defer func() {
+		if ok && err == nil {
+			h.mu.Lock()
+			h.rated++
+			delete(h.weights, tag)
+			h.mu.Unlock()
+		}
+	}()
+	defer sqlitex.Transaction(conn)(&err)
+	g, b := 0, 1
+	if good {
+		g, b = 1, 0
+	}
+	const rate = `INSERT INTO feedback (tag, msg, time, user, good, bad)
+SELECT tag, msg, time, :user, :good, :bad FROM spoken WHERE tag=:tag AND msg=:msg ORDER BY time DESC LIMIT 1
+ON CONFLICT DO UPDATE SET good=excluded.good, bad=excluded.bad`
+	opts := sqlitex.ExecOptions{Named: map[string]any{":tag": tag, ":msg": msg, ":user": user, ":good": g, ":bad": b}}
+	if err := sqlitex.Execute(conn, rate, &opts); err != nil {
+		return false, fmt.Errorf("couldn't rate message: %w", err)
+	}
+	return conn.Changes() > 0, nil
+}
+
+// Weights computes generation weights for the messages which have
+// contributed to spoken messages with feedback. Each good rating of a spoken
+// message raises the weights of the messages in its trace, and each bad
+// rating lowers them. Messages without net feedback are omitted.
+// The result is cached until the next rating and must not be modified.
+func (h *History) Weights(ctx context.Context, tag string) (map[string]float64, error) {
+	h.mu.Lock()
+	r, ok := h.weights[tag]
+	rated := h.rated
+	h.mu.Unlock()
+	if ok {
+		return r, nil
+	}
+	conn, err := h.db.Take(ctx)
+	defer h.db.Put(conn)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get connection to compute weights: %w", err)
+	}
+	r = make(map[string]float64)
+	opts := sqlitex.ExecOptions{
+		Named: map[string]any{":tag": tag},
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			r[stmt.ColumnText(0)] = weight(stmt.ColumnInt(1))
+			return nil
+		},
+	}
+	const sel = `SELECT value, SUM(feedback.good - feedback.bad) AS score
+FROM feedback
+	JOIN spoken USING (tag, msg, time),
+	JSON_EACH(spoken.trace)
+WHERE feedback.tag=:tag
+GROUP BY value
+HAVING score != 0`
+	if err := sqlitex.Execute(conn, sel, &opts); err != nil {
+		return nil, fmt.Errorf("couldn't compute weights: %w", err)
+	}
+	h.mu.Lock()
+	if h.rated == rated {
+		h.weights[tag] = r
+	}
+	h.mu.Unlock()
+	return r, nil
+}
+
+// weight converts a feedback score to a generation weight.
+// Every four points doubles or halves the weight, up to a factor of 16.
+func weight(score int) float64 {
+	return math.Exp2(max(-16, min(16, float64(score))) / 4)
+}
+
+// Scores lists the feedback on spoken messages in a tag, best first.
+func (h *History) Scores(ctx context.Context, tag string) ([]Score, error) {
+	conn, err := h.db.Take(ctx)
+	defer h.db.Put(conn)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get connection to list scores: %w", err)
+	}
+	var r []Score
+	opts := sqlitex.ExecOptions{
+		Named: map[string]any{":tag": tag},
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			r = append(r, Score{
+				Text: stmt.ColumnText(0),
+				Time: time.Unix(0, stmt.ColumnInt64(1)),
+				Good: stmt.ColumnInt(2),
+				Bad:  stmt.ColumnInt(3),
+			})
+			return nil
+		},
+	}
+	const sel = `SELECT msg, time, SUM(good) AS g, SUM(bad) AS b FROM feedback WHERE tag=:tag GROUP BY msg, time ORDER BY g - b DESC, time DESC`
+	if err := sqlitex.Execute(conn, sel, &opts); err != nil {
+		return nil, fmt.Errorf("couldn't list scores: %w", err)
+	}
+	return r, nil
+}
@@ -0,0 +1,154 @@
+package spoken_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"zombiezen.com/go/sqlite/sqlitex"
+
+	"github.com/zephyrtronium/robot/spoken"
+)
+
+func TestRate(t *testing.T) {
+	ctx := context.Background()
+	h, err := spoken.Open(ctx, testDB())
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := []struct {
+		tag   string
+		msg   string
+		trace []string
+		time  int64
+	}{
+		{"kessoku", "bocchi", []string{"1", "2"}, 1},
+		{"kessoku", "ryo", []string{"2", "3"}, 2},
+		{"kessoku", "bocchi", []string{"4"}, 3},
+		{"sickhack", "kikuri", []string{"5"}, 4},
+	}
+	for _, r := range records {
+		if err := h.Record(ctx, r.tag, r.msg, r.trace, time.Unix(0, r.time), 0, r.msg, "", "", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ratings := []struct {
+		tag  string
+		msg  string
+		user string
+		good bool
+		ok   bool
+	}{
+		{"kessoku", "bocchi", "kita", true, true},
+		{"kessoku", "bocchi", "nijika", true, true},
+		// Rating again replaces the user's earlier rating.
+		{"kessoku", "ryo", "kita", true, true},
+		{"kessoku", "ryo", "kita", false, true},
+		{"kessoku", "nijika", "kita", true, false},
+		{"sickhack", "ryo", "kita", true, false},
+		{"sickhack", "kikuri", "kita", false, true},
+	}
+	for _, r := range ratings {
+		ok, err := h.Rate(ctx, r.tag, r.msg, r.user, r.good)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != r.ok {
+			t.Errorf("wrong result rating %s/%s: want %t, got %t", r.tag, r.msg, r.ok, ok)
+		}
+	}
+
+	scores, err := h.Scores(ctx, "kessoku")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []spoken.Score{
+		{Text: "bocchi", Time: time.Unix(0, 3), Good: 2},
+		{Text: "ryo", Time: time.Unix(0, 2), Bad: 1},
+	}
+	if diff := cmp.Diff(want, scores); diff != "" {
+		t.Errorf("wrong scores (+got/-want):\n%s", diff)
+	}
+
+	w, err := h.Weights(ctx, "kessoku")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the most recent bocchi was rated, so message 1 has no feedback,
+	// and message 2 only has the bad rating from ryo.
+	wantw := map[string]float64{"2": math.Exp2(-0.25), "3": math.Exp2(-0.25), "4": math.Exp2(0.5)}
+	if diff := cmp.Diff(wantw, w); diff != "" {
+		t.Errorf("wrong weights (+got/-want):\n%s", diff)
+	}
+}
+
+func TestWeightsCache(t *testing.T) {
+	ctx := context.Background()
+	h, err := spoken.Open(ctx, testDB())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := h.Record(ctx, "kessoku", "bocchi", []string{"1"}, time.Unix(0, 1), 0, "bocchi", "", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	w, err := h.Weights(ctx, "kessoku")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(w) != 0 {
+		t.Errorf("weights without feedback: %v", w)
+	}
+	if _, err := h.Rate(ctx, "kessoku", "bocchi", "kita", true); err != nil {
+		t.Fatal(err)
+	}
+	w, err = h.Weights(ctx, "kessoku")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]float64{"1": math.Exp2(0.25)}
+	if diff := cmp.Diff(want, w); diff != "" {
+		t.Errorf("wrong weights after rating (+got/-want):\n%s", diff)
+	}
+}
+
+func TestMigrateFeedback(t *testing.T) {
+	ctx := context.Background()
+	db := testDB()
+	conn, err := db.Take(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Schema from before feedback was tracked per user.
+	const old = `CREATE TABLE feedback (tag TEXT NOT NULL, msg TEXT NOT NULL, time INTEGER NOT NULL, good INTEGER NOT NULL DEFAULT 0, bad INTEGER NOT NULL DEFAULT 0, PRIMARY KEY (tag, msg, time)) STRICT`
+	if err := sqlitex.ExecuteTransient(conn, old, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := sqlitex.ExecuteTransient(conn, `INSERT INTO feedback (tag, msg, time, good, bad) VALUES ('kessoku', 'bocchi', 1, 3, 1)`, nil); err != nil {
+		t.Fatal(err)
+	}
+	db.Put(conn)
+	h, err := spoken.Open(ctx, db)
+	if err != nil {
+		t.Fatalf("couldn't open old history: %v", err)
+	}
+	if err := h.Record(ctx, "kessoku", "bocchi", []string{"1"}, time.Unix(0, 1), 0, "bocchi", "", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := h.Rate(ctx, "kessoku", "bocchi", "kita", true); err != nil {
+		t.Fatal(err)
+	}
+	scores, err := h.Scores(ctx, "kessoku")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []spoken.Score{{Text: "bocchi", Time: time.Unix(0, 1), Good: 4, Bad: 1}}
+	if diff := cmp.Diff(want, scores); diff != "" {
+		t.Errorf("wrong scores after migration (+got/-want):\n%s", diff)
+	}
+	// Opening again must not migrate again.
+	if _, err := spoken.Open(ctx, db); err != nil {
+		t.Errorf("couldn't reopen migrated history: %v", err)
+	}
+}
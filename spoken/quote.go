@@ -0,0 +1,165 @@
+package spoken
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-json-experiment/json"
+	"zombiezen.com/go/sqlite"
+	"zombiezen.com/go/sqlite/sqlitex"
+)
+
+// Quote is a recorded message saved to a quote book.
+type Quote struct {
+	// Num is the quote's number within its tag.
+	Num int
+	// Text is the text of the quoted message.
+	Text string
+	// Trace is the trace of the quoted message.
+	Trace []string
+	// Time is the time the quoted message was spoken.
+	Time time.Time
+	// Quoter is the name of the user who added the quote.
+	Quoter string
+}
+
+// AddQuote saves the most recent instance of a message as a quote, keeping
+// its trace. If the message is already quoted, the result is the existing
+// quote's number. If the message has not been recorded, the result is zero
+// with a nil error.
+func (h *History) AddQuote(ctx context.Context, tag, msg, quoter string) (num int, err error) {
+	conn, err := h.db.Take(ctx)
+	defer h.db.Put(conn)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get connection to add quote: %w", err)
+	}
+	defer sqlitex.Transaction(conn)(&err)
+	var (
+		trace string
+		tm    int64
+		found bool
+	)
+	opts := sqlitex.ExecOptions{
+		Named: map[string]any{":tag": tag, ":msg": msg},
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			trace, tm, found = stmt.ColumnText(0), stmt.ColumnInt64(1), true
+			return nil
+		},
+	}
+	const sel = `SELECT JSON(trace), time FROM spoken WHERE tag=:tag AND msg=:msg ORDER BY time DESC LIMIT 1`
+	if err := sqlitex.Execute(conn, sel, &opts); err != nil {
+		return 0, fmt.Errorf("couldn't find message to quote: %w", err)
+	}
+	if !found {
+		return 0, nil
+	}
+	opts = sqlitex.ExecOptions{
+		Named: map[string]any{":tag": tag, ":msg": msg, ":time": tm},
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			num = stmt.ColumnInt(0)
+			return nil
+		},
+	}
+	const existing = `SELECT num FROM quote WHERE tag=:tag AND msg=:msg AND time=:time LIMIT 1`
+	if err := sqlitex.Execute(conn, existing, &opts); err != nil {
+		return 0, fmt.Errorf("couldn't check for existing quote: %w", err)
+	}
+	if num != 0 {
+		return num, nil
+	}
+	opts = sqlitex.ExecOptions{
+		Named: map[string]any{":tag": tag, ":msg": msg, ":trace": trace, ":time": tm, ":quoter": quoter},
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			num = stmt.ColumnInt(0)
+			return nil
+		},
+	}
+	const insert = `INSERT INTO quote (tag, num, msg, trace, time, quoter)
+SELECT :tag, COALESCE(MAX(num), 0) + 1, :msg, JSONB(:trace), :time, :quoter FROM quote WHERE tag=:tag
+RETURNING num`
+	if err := sqlitex.Execute(conn, insert, &opts); err != nil {
+		return 0, fmt.Errorf("couldn't add quote: %w", err)
+	}
+	return num, nil
+}
+
+// Quote gets a quote by number. If num is not positive, it gets a random
+// quote instead. If there is no such quote, the result has a zero Num with a
+// nil error.
+func (h *History) Quote(ctx context.Context, tag string, num int) (Quote, error) {
+	conn, err := h.db.Take(ctx)
+	defer h.db.Put(conn)
+	if err != nil {
+		return Quote{}, fmt.Errorf("couldn't get connection to find quote: %w", err)
+	}
+	var (
+		q   Quote
+		tr  string
+		sel = `SELECT num, msg, JSON(trace), time, quoter FROM quote WHERE tag=:tag AND num=:num`
+	)
+	if num <= 0 {
+		sel = `SELECT num, msg, JSON(trace), time, quoter FROM quote WHERE tag=:tag ORDER BY RANDOM() LIMIT 1`
+	}
+	opts := sqlitex.ExecOptions{
+		Named: map[string]any{":tag": tag, ":num": num},
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			q.Num = stmt.ColumnInt(0)
+			q.Text = stmt.ColumnText(1)
+			tr = stmt.ColumnText(2)
+			q.Time = time.Unix(0, stmt.ColumnInt64(3))
+			q.Quoter = stmt.ColumnText(4)
+			return nil
+		},
+	}
+	if num <= 0 {
+		delete(opts.Named, ":num")
+	}
+	if err := sqlitex.Execute(conn, sel, &opts); err != nil {
+		return Quote{}, fmt.Errorf("couldn't find quote: %w", err)
+	}
+	if q.Num == 0 {
+		return Quote{}, nil
+	}
+	if err := json.Unmarshal([]byte(tr), &q.Trace); err != nil {
+		return Quote{}, fmt.Errorf("couldn't decode quote trace: %w", err)
+	}
+	return q, nil
+}
+
+// DeleteQuote removes a quote by number. It reports whether the quote existed.
+func (h *History) DeleteQuote(ctx context.Context, tag string, num int) (bool, error) {
+	conn, err := h.db.Take(ctx)
+	defer h.db.Put(conn)
+	if err != nil {
+		return false, fmt.Errorf("couldn't get connection to delete quote: %w", err)
+	}
+	opts := sqlitex.ExecOptions{Named: map[string]any{":tag": tag, ":num": num}}
+	if err := sqlitex.Execute(conn, `DELETE FROM quote WHERE tag=:tag AND num=:num`, &opts); err != nil {
+		return false, fmt.Errorf("couldn't delete quote: %w", err)
+	}
+	return conn.Changes() > 0, nil
+}
+
+// DropQuotes removes all quotes in any tag whose traces include any of the
+// given message IDs. It returns the number of quotes removed.
+func (h *History) DropQuotes(ctx context.Context, ids ...string) (n int, err error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+	conn, err := h.db.Take(ctx)
+	defer h.db.Put(conn)
+	if err != nil {
+		return 0, fmt.Errorf("couldn't get connection to drop quotes: %w", err)
+	}
+	defer sqlitex.Transaction(conn)(&err)
+	const del = `DELETE FROM quote WHERE EXISTS (SELECT 1 FROM JSON_EACH(quote.trace) WHERE value = :id)`
+	for _, id := range ids {
+		opts := sqlitex.ExecOptions{Named: map[string]any{":id": id}}
+		if err := sqlitex.Execute(conn, del, &opts); err != nil {
+			return n, fmt.Errorf("couldn't drop quotes: %w", err)
+		}
+		n += conn.Changes()
+	}
+	return n, nil
+}
@@ -0,0 +1,187 @@
+package spoken_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/zephyrtronium/robot/spoken"
+)
+
+func quoteFixture(t *testing.T) (context.Context, *spoken.History) {
+	t.Helper()
+	ctx := context.Background()
+	h, err := spoken.Open(ctx, testDB())
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := []struct {
+		tag   string
+		msg   string
+		trace []string
+		time  int64
+	}{
+		{"kessoku", "bocchi", []string{"1", "2"}, 1},
+		{"kessoku", "ryo", []string{"3"}, 2},
+		{"kessoku", "bocchi", []string{"4", "5"}, 3},
+		{"sickhack", "kikuri", []string{"6"}, 4},
+	}
+	for _, r := range records {
+		if err := h.Record(ctx, r.tag, r.msg, r.trace, time.Unix(r.time, 0), 0, r.msg, "", "", ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return ctx, h
+}
+
+func TestAddQuote(t *testing.T) {
+	ctx, h := quoteFixture(t)
+	cases := []struct {
+		name string
+		tag  string
+		msg  string
+		want int
+	}{
+		{"first", "kessoku", "bocchi", 1},
+		{"second", "kessoku", "ryo", 2},
+		{"again", "kessoku", "bocchi", 1},
+		{"other-tag", "sickhack", "kikuri", 1},
+		{"unspoken", "kessoku", "nijika", 0},
+		{"wrong-tag", "sickhack", "ryo", 0},
+	}
+	// Cases depend on each other, so they don't run in parallel.
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got, err := h.AddQuote(ctx, c.tag, c.msg, "kita")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != c.want {
+				t.Errorf("wrong quote number: want %d, got %d", c.want, got)
+			}
+		})
+	}
+	q, err := h.Quote(ctx, "kessoku", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The most recent instance of the message is the one quoted.
+	want := spoken.Quote{Num: 1, Text: "bocchi", Trace: []string{"4", "5"}, Time: time.Unix(3, 0), Quoter: "kita"}
+	if q.Num != want.Num || q.Text != want.Text || !slices.Equal(q.Trace, want.Trace) || !q.Time.Equal(want.Time) || q.Quoter != want.Quoter {
+		t.Errorf("wrong quote: want %+v, got %+v", want, q)
+	}
+}
+
+func TestQuote(t *testing.T) {
+	ctx, h := quoteFixture(t)
+	for _, msg := range []string{"bocchi", "ryo"} {
+		if _, err := h.AddQuote(ctx, "kessoku", msg, "kita"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cases := []struct {
+		name string
+		tag  string
+		num  int
+		want []string
+	}{
+		{"one", "kessoku", 1, []string{"bocchi"}},
+		{"two", "kessoku", 2, []string{"ryo"}},
+		{"missing", "kessoku", 3, []string{""}},
+		{"random", "kessoku", 0, []string{"bocchi", "ryo"}},
+		{"random-empty", "sickhack", 0, []string{""}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			q, err := h.Quote(ctx, c.tag, c.num)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Contains(c.want, q.Text) {
+				t.Errorf("wrong quote: want one of %q, got %q", c.want, q.Text)
+			}
+			if (q.Num == 0) != (q.Text == "") {
+				t.Errorf("inconsistent quote: %+v", q)
+			}
+		})
+	}
+}
+
+func TestDeleteQuote(t *testing.T) {
+	ctx, h := quoteFixture(t)
+	for _, msg := range []string{"bocchi", "ryo"} {
+		if _, err := h.AddQuote(ctx, "kessoku", msg, "kita"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ok, err := h.DeleteQuote(ctx, "kessoku", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Error("quote 1 didn't exist")
+	}
+	ok, err = h.DeleteQuote(ctx, "kessoku", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok {
+		t.Error("quote 1 existed twice")
+	}
+	if q, _ := h.Quote(ctx, "kessoku", 2); q.Text != "ryo" {
+		t.Errorf("deleted the wrong quote: got %+v", q)
+	}
+	// Numbers keep counting up.
+	n, err := h.AddQuote(ctx, "kessoku", "bocchi", "kita")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Errorf("wrong number after delete: want 3, got %d", n)
+	}
+}
+
+func TestDropQuotes(t *testing.T) {
+	cases := []struct {
+		name string
+		ids  []string
+		n    int
+		left []int
+	}{
+		{"none", nil, 0, []int{1, 2}},
+		{"unrelated", []string{"1", "6"}, 0, []int{1, 2}},
+		{"one", []string{"5"}, 1, []int{2}},
+		{"both", []string{"3", "4"}, 2, nil},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx, h := quoteFixture(t)
+			for _, msg := range []string{"bocchi", "ryo"} {
+				if _, err := h.AddQuote(ctx, "kessoku", msg, "kita"); err != nil {
+					t.Fatal(err)
+				}
+			}
+			n, err := h.DropQuotes(ctx, c.ids...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != c.n {
+				t.Errorf("wrong number dropped: want %d, got %d", c.n, n)
+			}
+			var left []int
+			for _, k := range []int{1, 2} {
+				q, err := h.Quote(ctx, "kessoku", k)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if q.Num != 0 {
+					left = append(left, q.Num)
+				}
+			}
+			if !slices.Equal(left, c.left) {
+				t.Errorf("wrong quotes left: want %v, got %v", c.left, left)
+			}
+		})
+	}
+}
@@ -14,8 +14,45 @@ CREATE TABLE IF NOT EXISTS spoken (
 	-- 	"emote": Emote appended to the message.
 	-- 	"effect": Name of the effect applied to the message.
 	-- 	"cost": Time in nanoseconds spent generating the message.
+	-- 	"prompt": Prompt the message was generated from.
 	meta BLOB NOT NULL
 ) STRICT;
 
 -- Covering index for lookup.
 CREATE INDEX IF NOT EXISTS traces ON spoken (tag, msg, time DESC, trace);
+
+CREATE TABLE IF NOT EXISTS quote (
+	-- Tag or tenant for the quote. As for spoken, this is the speaking tag.
+	tag TEXT NOT NULL,
+	-- Quote number, counting up within the tag.
+	num INTEGER NOT NULL,
+	-- Quoted message text, as recorded in spoken.
+	msg TEXT NOT NULL,
+	-- Trace of the quoted message, copied from spoken as a JSONB array.
+	trace BLOB NOT NULL,
+	-- Time the quoted message was spoken as nanoseconds from the UNIX epoch.
+	time INTEGER NOT NULL,
+	-- Name of the user who added the quote.
+	quoter TEXT NOT NULL,
+	PRIMARY KEY (tag, num)
+) STRICT;
+
+CREATE TABLE IF NOT EXISTS feedback (
+	-- Tag or tenant for the feedback. As for spoken, this is the speaking tag.
+	tag TEXT NOT NULL,
+	-- Text of the spoken message receiving feedback.
+	msg TEXT NOT NULL,
+	-- Time the message was spoken as nanoseconds from the UNIX epoch.
+	-- Together with tag and msg, this identifies the row in spoken.
+	time INTEGER NOT NULL,
+	-- ID of the user giving the feedback.
+	-- Empty for feedback recorded before it was tracked per user.
+	user TEXT NOT NULL,
+	-- Number of times the user called the message good.
+	-- At most one, except for feedback not tracked per user.
+	good INTEGER NOT NULL DEFAULT 0,
+	-- Number of times the user called the message bad.
+	-- At most one, except for feedback not tracked per user.
+	bad INTEGER NOT NULL DEFAULT 0,
+	PRIMARY KEY (tag, msg, time, user)
+) STRICT;
@@ -5,15 +5,25 @@ import (
 	_ "embed"
 	"fmt"
 	"iter"
+	"sync"
 	"time"
 
 	"github.com/go-json-experiment/json"
+	"zombiezen.com/go/sqlite"
 	"zombiezen.com/go/sqlite/sqlitex"
 )
 
 // History records messages generated by robot.
 type History struct {
 	db *sqlitex.Pool
+
+	// mu guards the cached feedback weights.
+	mu sync.Mutex
+	// weights caches feedback weights by tag.
+	weights map[string]map[string]float64
+	// rated counts ratings so that weights computed concurrently with a
+	// rating aren't cached.
+	rated uint64
 }
 
 // meta is metadata that may be associated with a generated message.
@@ -27,6 +37,8 @@ type meta struct {
 	Effect string `json:"effect,omitempty"`
 	// Cost is the time in nanoseconds spent generating the message.
 	Cost int64 `json:"cost,omitempty,omitzero"`
+	// Prompt is the prompt the message was generated from, if any.
+	Prompt string `json:"prompt,omitempty"`
 }
 
 // Open opens an existing history in a DB.
@@ -39,14 +51,51 @@ func Open(ctx context.Context, db *sqlitex.Pool) (*History, error) {
 	if err := sqlitex.ExecuteScript(conn, schemaSQL, nil); err != nil {
 		return nil, fmt.Errorf("couldn't initialize spoken messages schema: %w", err)
 	}
-	return &History{db}, nil
+	if err := migrateFeedback(conn); err != nil {
+		return nil, err
+	}
+	return &History{db: db, weights: make(map[string]map[string]float64)}, nil
+}
+
+// migrateFeedback moves feedback from before it was tracked per user into
+// the current table, keeping the counts under an empty user.
+func migrateFeedback(conn *sqlite.Conn) (err error) {
+	var hasUser bool
+	opts := sqlitex.ExecOptions{
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			hasUser = true
+			return nil
+		},
+	}
+	if err := sqlitex.ExecuteTransient(conn, `SELECT 1 FROM pragma_table_info('feedback') WHERE name = 'user'`, &opts); err != nil {
+		return fmt.Errorf("couldn't check feedback columns: %w", err)
+	}
+	if hasUser {
+		return nil
+	}
+	defer sqlitex.Save(conn)(&err)
+	if err := sqlitex.ExecuteTransient(conn, `ALTER TABLE feedback RENAME TO feedback_old`, nil); err != nil {
+		return fmt.Errorf("couldn't rename old feedback: %w", err)
+	}
+	if err := sqlitex.ExecuteScript(conn, schemaSQL, nil); err != nil {
+		return fmt.Errorf("couldn't create new feedback table: %w", err)
+	}
+	const move = `INSERT INTO feedback (tag, msg, time, user, good, bad) SELECT tag, msg, time, '', good, bad FROM feedback_old`
+	if err := sqlitex.ExecuteTransient(conn, move, nil); err != nil {
+		return fmt.Errorf("couldn't copy old feedback: %w", err)
+	}
+	if err := sqlitex.ExecuteTransient(conn, `DROP TABLE feedback_old`, nil); err != nil {
+		return fmt.Errorf("couldn't drop old feedback: %w", err)
+	}
+	return nil
 }
 
 //go:embed schema.sql
 var schemaSQL string
 
 // Record records a message with its trace and metadata.
-func (h *History) Record(ctx context.Context, tag, msg string, trace []string, tm time.Time, cost time.Duration, orig, emote, effect string) error {
+// The prompt is empty if the message was generated without one.
+func (h *History) Record(ctx context.Context, tag, msg string, trace []string, tm time.Time, cost time.Duration, orig, emote, effect, prompt string) error {
 	conn, err := h.db.Take(ctx)
 	defer h.db.Put(conn)
 	if err != nil {
@@ -67,6 +116,7 @@ func (h *History) Record(ctx context.Context, tag, msg string, trace []string, t
 		Emote:  emote,
 		Effect: effect,
 		Cost:   cost.Nanoseconds(),
+		Prompt: prompt,
 	}
 	md, err := json.Marshal(m)
 	if err != nil {
@@ -170,6 +220,7 @@ type Message struct {
 	Cost     string    `json:"cost"`
 	Emote    string    `json:"emote,omitzero"`
 	Effect   string    `json:"effect,omitzero"`
+	Prompt   string    `json:"prompt,omitzero"`
 }
 
 // Previous gets the most recent n messages and their traces.
@@ -195,11 +246,7 @@ func (h *History) Previous(ctx context.Context, tag string, n int) (iter.Seq[Mes
 		defer h.db.Put(conn)
 		defer st.Reset()
 		for {
-			var (
-				m    Message
-				meta meta
-				ok   bool
-			)
+			var ok bool
 			ok, err = st.Step()
 			if err != nil {
 				err = fmt.Errorf("couldn't get previous messages: %w", err)
@@ -208,20 +255,11 @@ func (h *History) Previous(ctx context.Context, tag string, n int) (iter.Seq[Mes
 			if !ok {
 				return
 			}
-			m.Text = st.ColumnText(0)
-			if err = json.Unmarshal([]byte(st.ColumnText(1)), &m.Trace); err != nil {
-				err = fmt.Errorf("couldn't decode trace: %w", err)
-				return
-			}
-			m.Time = time.Unix(0, st.ColumnInt64(2))
-			if err = json.Unmarshal([]byte(st.ColumnText(3)), &meta); err != nil {
-				err = fmt.Errorf("couldn't decode metadata: %w", err)
+			var m Message
+			m, err = scanMessage(st)
+			if err != nil {
 				return
 			}
-			m.Original = meta.Orig
-			m.Cost = time.Duration(meta.Cost).String()
-			m.Emote = meta.Emote
-			m.Effect = meta.Effect
 			if !yield(m) {
 				return
 			}
@@ -229,3 +267,51 @@ func (h *History) Previous(ctx context.Context, tag string, n int) (iter.Seq[Mes
 	}
 	return it, errf
 }
+
+// Find gets the most recent instance of a message with its trace and
+// metadata. If the message has not been recorded, the result is the zero
+// Message with a nil error.
+func (h *History) Find(ctx context.Context, tag, msg string) (Message, error) {
+	conn, err := h.db.Take(ctx)
+	defer h.db.Put(conn)
+	if err != nil {
+		return Message{}, fmt.Errorf("couldn't get connection to find message: %w", err)
+	}
+	var m Message
+	opts := sqlitex.ExecOptions{
+		Named: map[string]any{":tag": tag, ":msg": msg},
+		ResultFunc: func(stmt *sqlite.Stmt) error {
+			var err error
+			m, err = scanMessage(stmt)
+			return err
+		},
+	}
+	const sel = `SELECT msg, JSON(trace), time, JSON(meta) FROM spoken WHERE tag=:tag AND msg=:msg ORDER BY time DESC LIMIT 1`
+	if err := sqlitex.Execute(conn, sel, &opts); err != nil {
+		return Message{}, fmt.Errorf("couldn't find message: %w", err)
+	}
+	return m, nil
+}
+
+// scanMessage decodes a message from a row of msg, JSON(trace), time, and
+// JSON(meta).
+func scanMessage(st *sqlite.Stmt) (Message, error) {
+	var (
+		m    Message
+		meta meta
+	)
+	m.Text = st.ColumnText(0)
+	if err := json.Unmarshal([]byte(st.ColumnText(1)), &m.Trace); err != nil {
+		return Message{}, fmt.Errorf("couldn't decode trace: %w", err)
+	}
+	m.Time = time.Unix(0, st.ColumnInt64(2))
+	if err := json.Unmarshal([]byte(st.ColumnText(3)), &meta); err != nil {
+		return Message{}, fmt.Errorf("couldn't decode metadata: %w", err)
+	}
+	m.Original = meta.Orig
+	m.Cost = time.Duration(meta.Cost).String()
+	m.Emote = meta.Emote
+	m.Effect = meta.Effect
+	m.Prompt = meta.Prompt
+	return m, nil
+}
@@ -40,7 +40,7 @@ func TestRecord(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	err = h.Record(ctx, "kessoku", "boccho ryo xD", []string{"1", "2"}, time.Unix(1, 0), time.Second, "bocchi ryo", "xD", "o")
+	err = h.Record(ctx, "kessoku", "boccho ryo xD", []string{"1", "2"}, time.Unix(1, 0), time.Second, "bocchi ryo", "xD", "o", "bocchi")
 	if err != nil {
 		t.Errorf("couldn't record: %v", err)
 	}
@@ -78,6 +78,7 @@ func TestRecord(t *testing.T) {
 				"emote":  "xD",
 				"effect": "o",
 				"cost":   float64(time.Second.Nanoseconds()),
+				"prompt": "bocchi",
 			}
 			if !maps.Equal(md, want) {
 				t.Errorf("wrong metadata recorded: want %v, got %v from %q", want, md, meta)
@@ -407,3 +408,78 @@ func TestPrevious(t *testing.T) {
 		})
 	}
 }
+
+func TestFind(t *testing.T) {
+	ctx := context.Background()
+	h, err := spoken.Open(ctx, testDB())
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := []struct {
+		tag    string
+		msg    string
+		trace  []string
+		time   int64
+		prompt string
+	}{
+		{"kessoku", "bocchi", []string{"1"}, 10, ""},
+		{"kessoku", "ryo", []string{"2"}, 20, ""},
+		{"kessoku", "bocchi", []string{"3", "4"}, 30, "bo"},
+		{"sickhack", "ryo", []string{"5"}, 40, ""},
+	}
+	for _, r := range records {
+		if err := h.Record(ctx, r.tag, r.msg, r.trace, time.Unix(0, r.time), time.Second, r.msg, "", "", r.prompt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cases := []struct {
+		name string
+		tag  string
+		msg  string
+		want spoken.Message
+	}{
+		{
+			name: "none",
+			tag:  "kessoku",
+			msg:  "nijika",
+			want: spoken.Message{},
+		},
+		{
+			name: "latest",
+			tag:  "kessoku",
+			msg:  "bocchi",
+			want: spoken.Message{
+				Text:     "bocchi",
+				Trace:    []string{"3", "4"},
+				Time:     time.Unix(0, 30),
+				Original: "bocchi",
+				Cost:     "1s",
+				Prompt:   "bo",
+			},
+		},
+		{
+			name: "tagged",
+			tag:  "kessoku",
+			msg:  "ryo",
+			want: spoken.Message{
+				Text:     "ryo",
+				Trace:    []string{"2"},
+				Time:     time.Unix(0, 20),
+				Original: "ryo",
+				Cost:     "1s",
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := h.Find(t.Context(), c.tag, c.msg)
+			if err != nil {
+				t.Error(err)
+			}
+			if diff := cmp.Diff(c.want, got); diff != "" {
+				t.Errorf("wrong result (+got/-want):\n%s", diff)
+			}
+		})
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"gitlab.com/zephyrtronium/tmi"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/zephyrtronium/robot/audit"
 	"github.com/zephyrtronium/robot/brain"
 	"github.com/zephyrtronium/robot/metrics"
 )
@@ -44,6 +45,10 @@ func (robo *Robot) tmiLoop(ctx context.Context, group *errgroup.Group, send chan
 				})
 			case "HOSTTARGET":
 				// nothing yet
+			case "ROOMSTATE":
+				if id, ok := msg.Tag("room-id"); ok && len(msg.Params) > 0 {
+					robo.rooms.Store(id, msg.To())
+				}
 			case "USERSTATE":
 				// We used to check our badges and update our hard rate limit
 				// per-channel, but per-channel rate limits only really make
@@ -66,12 +71,18 @@ func (robo *Robot) joinTwitch(ctx context.Context, send chan<- *tmi.Message) {
 	for _, ch := range robo.channels.All() {
 		ls = append(ls, ch.Name)
 	}
+	batchTwitch(ctx, send, "JOIN", ls)
+}
+
+// batchTwitch sends a JOIN or PART for each of a list of channels, in batches
+// that respect Twitch's rate limits on joins.
+func batchTwitch(ctx context.Context, send chan<- *tmi.Message, command string, ls []string) {
 	burst := 20
 	for len(ls) > 0 {
 		l := ls[:min(burst, len(ls))]
 		ls = ls[len(l):]
 		msg := tmi.Message{
-			Command: "JOIN",
+			Command: command,
 			Params:  []string{strings.Join(l, ",")},
 		}
 		select {
@@ -98,15 +109,23 @@ func (robo *Robot) clearchat(ctx context.Context, msg *tmi.Message) {
 		return
 	}
 	t, _ := msg.Tag("target-user-id")
+	e := audit.Entry{
+		Time:    msg.Time(),
+		Actor:   "twitch",
+		Channel: ch.Name,
+		Action:  "clearchat",
+	}
 	switch t {
 	case "":
 		// Delete all recent chat.
 		tag := ch.Learn
+		e.Reason, e.Tag = "clear chat", tag
 		slog.InfoContext(ctx, "clear all chat", slog.String("channel", msg.To()), slog.String("tag", tag))
 		for m := range ch.History.All() {
 			slog.DebugContext(ctx, "forget all chat", slog.String("channel", msg.To()), slog.String("id", m.ID))
 			robo.metrics.ForgotCount.Observe(1)
-			err := robo.brain.Forget(ctx, tag, m.ID)
+			e.Messages = append(e.Messages, m.ID)
+			err := brain.ForgetFor(ctx, robo.brain, tag, m.ID, brain.Cleared)
 			if err != nil {
 				slog.ErrorContext(ctx, "failed to forget while clearing all chat",
 					slog.Any("err", err),
@@ -118,6 +137,7 @@ func (robo *Robot) clearchat(ctx context.Context, msg *tmi.Message) {
 	case robo.tmi.userID:
 		// We use the send tag because we are forgetting something we sent.
 		tag := ch.Send
+		e.Reason, e.Tag = "bot timed out", tag
 		slog.InfoContext(ctx, "forget recent generated", slog.String("channel", msg.To()), slog.String("tag", tag))
 		for id, err := range robo.spoken.Since(ctx, tag, msg.Time().Add(-15*time.Minute)) {
 			if err != nil {
@@ -130,7 +150,8 @@ func (robo *Robot) clearchat(ctx context.Context, msg *tmi.Message) {
 			}
 			slog.DebugContext(ctx, "forget from recent trace", slog.String("channel", msg.To()), slog.String("id", id))
 			robo.metrics.ForgotCount.Observe(1)
-			if err := robo.brain.Forget(ctx, tag, id); err != nil {
+			e.Messages = append(e.Messages, id)
+			if err := brain.ForgetFor(ctx, robo.brain, tag, id, brain.Cleared); err != nil {
 				slog.ErrorContext(ctx, "failed to forget from recent trace",
 					slog.Any("err", err),
 					slog.String("channel", msg.To()),
@@ -141,13 +162,18 @@ func (robo *Robot) clearchat(ctx context.Context, msg *tmi.Message) {
 		}
 	default:
 		// Delete from user.
+		e.Reason, e.Tag = "ban or timeout of user "+t, ch.Learn
+		if d, ok := msg.Tag("ban-duration"); ok {
+			e.Reason += " for " + d + "s"
+		}
 		for m := range ch.History.All() {
 			if m.Sender.ID != t {
 				continue
 			}
 			slog.DebugContext(ctx, "forget from user", slog.String("channel", msg.To()), slog.String("id", m.ID))
 			robo.metrics.ForgotCount.Observe(1)
-			if err := robo.brain.Forget(ctx, ch.Learn, m.ID); err != nil {
+			e.Messages = append(e.Messages, m.ID)
+			if err := brain.ForgetFor(ctx, robo.brain, ch.Learn, m.ID, brain.Cleared); err != nil {
 				slog.ErrorContext(ctx, "failed to forget from user",
 					slog.Any("err", err),
 					slog.String("channel", msg.To()),
@@ -156,6 +182,8 @@ func (robo *Robot) clearchat(ctx context.Context, msg *tmi.Message) {
 			}
 		}
 	}
+	robo.dropQuotes(ctx, e.Messages...)
+	robo.record(ctx, e)
 }
 
 func (robo *Robot) clearmsg(ctx context.Context, msg *tmi.Message) {
@@ -173,6 +201,16 @@ func (robo *Robot) clearmsg(ctx context.Context, msg *tmi.Message) {
 		// Forget a message from someone else.
 		log.InfoContext(ctx, "forget message", slog.String("tag", ch.Learn), slog.String("id", t))
 		forget(ctx, log, robo.metrics.ForgotCount, robo.brain, ch.Learn, t)
+		robo.dropQuotes(ctx, t)
+		robo.record(ctx, audit.Entry{
+			Time:     msg.Time(),
+			Actor:    "twitch",
+			Channel:  ch.Name,
+			Action:   "clearmsg",
+			Reason:   "message from " + u,
+			Tag:      ch.Learn,
+			Messages: []string{t},
+		})
 		return
 	}
 	// Forget a message from the robo.
@@ -193,6 +231,16 @@ func (robo *Robot) clearmsg(ctx context.Context, msg *tmi.Message) {
 	}
 	log.InfoContext(ctx, "forget trace", slog.String("tag", ch.Send), slog.Any("spoken", tm), slog.Any("trace", trace))
 	forget(ctx, log, robo.metrics.ForgotCount, robo.brain, ch.Send, trace...)
+	robo.dropQuotes(ctx, trace...)
+	robo.record(ctx, audit.Entry{
+		Time:     msg.Time(),
+		Actor:    "twitch",
+		Channel:  ch.Name,
+		Action:   "clearmsg",
+		Reason:   "message from bot",
+		Tag:      ch.Send,
+		Messages: trace,
+	})
 }
 
 func forget(ctx context.Context, log *slog.Logger, forgetCount metrics.Observer, brain brain.Interface, tag string, trace ...string) {
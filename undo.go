@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/zephyrtronium/robot/audit"
+	"github.com/zephyrtronium/robot/brain"
+	"github.com/zephyrtronium/robot/channel"
+	"github.com/zephyrtronium/robot/privacy"
+)
+
+// errNoAudit is the error for undoing a forget without an audit log.
+var errNoAudit = errors.New("no audit log to find forgets to undo")
+
+const (
+	// defaultUndoWindow is the window for undoing a forget when the config
+	// does not set one.
+	defaultUndoWindow = 5 * time.Minute
+	// maxUndoWindow is the longest window for undoing a forget.
+	// Restoring a message requires its sender from channel history, which
+	// lasts fifteen minutes.
+	maxUndoWindow = 15 * time.Minute
+)
+
+// UndoForget restores the messages forgotten by the most recent forget
+// command in a channel, if it happened within the configured window.
+// Messages which were also removed by Twitch moderation or forgotten by user
+// and messages from users who are private stay forgotten.
+// The actor is recorded in the audit log as who requested it.
+// It returns the number of messages restored and the number in the batch.
+// If there is no batch to undo, both are zero.
+// Without an audit log, there is no way to find the batch, so the result is
+// an error.
+func (robo *Robot) UndoForget(ctx context.Context, ch *channel.Channel, actor string) (int, int, error) {
+	if robo.audit == nil {
+		return 0, 0, errNoAudit
+	}
+	robo.cfgMu.Lock()
+	window := fseconds(robo.twitchCfg.global.Forget.Undo)
+	robo.cfgMu.Unlock()
+	if window <= 0 {
+		window = defaultUndoWindow
+	}
+	window = min(window, maxUndoWindow)
+	now := time.Now()
+	// The batch only contains messages from channel history, so anything else
+	// that happened to them is at most one history length before the batch.
+	l, err := robo.audit.Query(ctx, audit.Filter{Channel: ch.Name, Since: now.Add(-window - maxUndoWindow)})
+	if err != nil {
+		return 0, 0, err
+	}
+	k := slices.IndexFunc(l, func(e audit.Entry) bool { return e.Action == "forget" || e.Action == "unforget" })
+	if k < 0 || l[k].Action == "unforget" || l[k].Time.Before(now.Add(-window)) {
+		// No recent forget, or the most recent one is already undone.
+		return 0, 0, nil
+	}
+	batch := l[k]
+	keep := make(map[string]bool)
+	for _, e := range l {
+		switch e.Action {
+		case "clearchat", "clearmsg", "forget-user":
+			for _, id := range e.Messages {
+				keep[id] = true
+			}
+		}
+	}
+	senders := make(map[string]string)
+	for m := range ch.History.All() {
+		senders[m.ID] = m.Sender.ID
+	}
+	var ids []string
+	for _, id := range batch.Messages {
+		if keep[id] {
+			continue
+		}
+		sender, ok := senders[id]
+		if !ok {
+			// We can't tell who sent it, so we can't tell whether they're
+			// private. Leave it forgotten.
+			continue
+		}
+		switch err := robo.privacy.Check(ctx, sender); {
+		case err == nil: // do nothing
+		case errors.Is(err, privacy.ErrPrivate):
+			continue
+		default:
+			return 0, len(batch.Messages), err
+		}
+		ids = append(ids, id)
+	}
+	restored, err := brain.Restore(ctx, robo.brain, batch.Tag, ids...)
+	slog.InfoContext(ctx, "undo forget",
+		slog.String("channel", ch.Name),
+		slog.String("tag", batch.Tag),
+		slog.Time("batch", batch.Time),
+		slog.Int("count", len(restored)),
+		slog.Int("of", len(batch.Messages)),
+		slog.Any("err", err),
+	)
+	robo.record(ctx, audit.Entry{
+		Time:     now,
+		Actor:    actor,
+		Channel:  ch.Name,
+		Action:   "unforget",
+		Reason:   fmt.Sprintf("forget by %s at %s", batch.Actor, batch.Time.Format(time.RFC3339)),
+		Tag:      batch.Tag,
+		Messages: restored,
+	})
+	return len(restored), len(batch.Messages), err
+}